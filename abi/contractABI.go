@@ -0,0 +1,509 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/parsers"
+	"github.com/subrahamanyam341/andes-vm-common-1234/returnData"
+	"github.com/subrahamanyam341/andes-vm-common-1234/txDataBuilder"
+)
+
+const constructorName = "init"
+
+// Parameter is a named input or output of an endpoint or event
+type Parameter struct {
+	Name    string
+	Type    *returnData.Type
+	Indexed bool
+}
+
+// Endpoint holds the inputs and outputs of a contract endpoint
+type Endpoint struct {
+	Name    string
+	Inputs  []*Parameter
+	Outputs []*Parameter
+}
+
+// Event holds the inputs of a contract event. Indexed inputs are emitted as topics, the others as data.
+type Event struct {
+	Identifier string
+	Inputs     []*Parameter
+}
+
+type parameterJSON struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+type endpointJSON struct {
+	Name    string           `json:"name"`
+	Inputs  []*parameterJSON `json:"inputs"`
+	Outputs []*parameterJSON `json:"outputs"`
+}
+
+type eventJSON struct {
+	Identifier string           `json:"identifier"`
+	Inputs     []*parameterJSON `json:"inputs"`
+}
+
+type typeDefinitionJSON struct {
+	Type   string           `json:"type"`
+	Fields []*parameterJSON `json:"fields"`
+}
+
+type contractABIJSON struct {
+	Name        string                         `json:"name"`
+	Constructor *endpointJSON                  `json:"constructor"`
+	Endpoints   []*endpointJSON                `json:"endpoints"`
+	Events      []*eventJSON                   `json:"events"`
+	Types       map[string]*typeDefinitionJSON `json:"types"`
+}
+
+type contractABI struct {
+	name        string
+	constructor *Endpoint
+	endpoints   map[string]*Endpoint
+	events      map[string]*Event
+}
+
+// NewContractABIFromFile loads the contract ABI definition from the provided JSON file
+func NewContractABIFromFile(path string) (*contractABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewContractABI(data)
+}
+
+// NewContractABI loads the contract ABI definition from the provided JSON
+func NewContractABI(abiJSON []byte) (*contractABI, error) {
+	definition := &contractABIJSON{}
+	err := json.Unmarshal(abiJSON, definition)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidABI, err)
+	}
+
+	resolver := newTypeResolver(definition.Types)
+	contract := &contractABI{
+		name:        definition.Name,
+		constructor: &Endpoint{Name: constructorName},
+		endpoints:   make(map[string]*Endpoint, len(definition.Endpoints)),
+		events:      make(map[string]*Event, len(definition.Events)),
+	}
+	if definition.Constructor != nil {
+		definition.Constructor.Name = constructorName
+		contract.constructor, err = resolver.resolveEndpoint(definition.Constructor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, endpointDefinition := range definition.Endpoints {
+		if endpointDefinition == nil {
+			return nil, fmt.Errorf("%w: nil endpoint", ErrInvalidABI)
+		}
+		_, found := contract.endpoints[endpointDefinition.Name]
+		if found {
+			return nil, fmt.Errorf("%w: duplicated endpoint %s", ErrInvalidABI, endpointDefinition.Name)
+		}
+
+		endpoint, errResolve := resolver.resolveEndpoint(endpointDefinition)
+		if errResolve != nil {
+			return nil, errResolve
+		}
+		contract.endpoints[endpoint.Name] = endpoint
+	}
+	for _, eventDefinition := range definition.Events {
+		if eventDefinition == nil {
+			return nil, fmt.Errorf("%w: nil event", ErrInvalidABI)
+		}
+		_, found := contract.events[eventDefinition.Identifier]
+		if found {
+			return nil, fmt.Errorf("%w: duplicated event %s", ErrInvalidABI, eventDefinition.Identifier)
+		}
+
+		inputs, errResolve := resolver.resolveParameters(eventDefinition.Inputs, "event "+eventDefinition.Identifier)
+		if errResolve != nil {
+			return nil, errResolve
+		}
+		contract.events[eventDefinition.Identifier] = &Event{Identifier: eventDefinition.Identifier, Inputs: inputs}
+	}
+
+	return contract, nil
+}
+
+// Name returns the contract name
+func (contract *contractABI) Name() string {
+	return contract.name
+}
+
+// Constructor returns the contract constructor
+func (contract *contractABI) Constructor() *Endpoint {
+	return contract.constructor
+}
+
+// GetEndpoint returns the endpoint with the provided name
+func (contract *contractABI) GetEndpoint(name string) (*Endpoint, error) {
+	endpoint, found := contract.endpoints[name]
+	if !found {
+		return nil, fmt.Errorf("%w %s", ErrUnknownEndpoint, name)
+	}
+
+	return endpoint, nil
+}
+
+// GetEvent returns the event with the provided identifier
+func (contract *contractABI) GetEvent(identifier string) (*Event, error) {
+	event, found := contract.events[identifier]
+	if !found {
+		return nil, fmt.Errorf("%w %s", ErrUnknownEvent, identifier)
+	}
+
+	return event, nil
+}
+
+// EncodeArguments encodes the provided values as the arguments of the endpoint. Missing or nil trailing values
+// are allowed only for optional inputs.
+func (contract *contractABI) EncodeArguments(endpointName string, values ...interface{}) ([][]byte, error) {
+	endpoint, err := contract.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeParameters(endpoint.Inputs, values, "endpoint "+endpointName)
+}
+
+// EncodeConstructorArguments encodes the provided values as the arguments of the constructor
+func (contract *contractABI) EncodeConstructorArguments(values ...interface{}) ([][]byte, error) {
+	return encodeParameters(contract.constructor.Inputs, values, "constructor")
+}
+
+// EncodeCallData encodes the call of the endpoint as transaction data, in the function@hex@hex format
+func (contract *contractABI) EncodeCallData(endpointName string, values ...interface{}) (string, error) {
+	arguments, err := contract.EncodeArguments(endpointName, values...)
+	if err != nil {
+		return "", err
+	}
+
+	builder := txDataBuilder.NewBuilder().Func(endpointName)
+	for _, argument := range arguments {
+		builder.Bytes(argument)
+	}
+
+	return builder.ToString(), nil
+}
+
+// DecodeCallData parses the transaction data and decodes its arguments according to the called endpoint
+func (contract *contractABI) DecodeCallData(data string) (string, []interface{}, error) {
+	endpointName, arguments, err := parsers.NewCallArgsParser().ParseData(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	values, err := contract.DecodeArguments(endpointName, arguments)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return endpointName, values, nil
+}
+
+// DecodeArguments decodes the provided arguments according to the endpoint inputs
+func (contract *contractABI) DecodeArguments(endpointName string, arguments [][]byte) ([]interface{}, error) {
+	endpoint, err := contract.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeParameters(endpoint.Inputs, arguments, "endpoint "+endpointName)
+}
+
+// DecodeReturnData decodes the provided return data according to the endpoint outputs
+func (contract *contractABI) DecodeReturnData(endpointName string, data [][]byte) ([]interface{}, error) {
+	endpoint, err := contract.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeParameters(endpoint.Outputs, data, "endpoint "+endpointName)
+}
+
+// DecodeVMOutput decodes the return data of the VM output according to the endpoint outputs
+func (contract *contractABI) DecodeVMOutput(endpointName string, vmOutput *vmcommon.VMOutput) ([]interface{}, error) {
+	if vmOutput == nil {
+		return nil, returnData.ErrNilVMOutput
+	}
+
+	return contract.DecodeReturnData(endpointName, vmOutput.ReturnData)
+}
+
+// DecodeEvent decodes the log entry according to the event with the same identifier. When the log identifier is not
+// a known event, the first topic is used as the event identifier, the way contracts emit their events.
+// The decoded values are returned in the order of the event inputs.
+func (contract *contractABI) DecodeEvent(logEntry *vmcommon.LogEntry) (string, []interface{}, error) {
+	if logEntry == nil {
+		return "", nil, ErrNilLogEntry
+	}
+
+	topics := logEntry.Topics
+	event, found := contract.events[string(logEntry.Identifier)]
+	if !found && len(topics) > 0 {
+		event, found = contract.events[string(topics[0])]
+		topics = topics[1:]
+	}
+	if !found {
+		return "", nil, fmt.Errorf("%w %s", ErrUnknownEvent, logEntry.Identifier)
+	}
+
+	indexed := make([]*Parameter, 0, len(event.Inputs))
+	notIndexed := make([]*Parameter, 0, len(event.Inputs))
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+			continue
+		}
+		notIndexed = append(notIndexed, input)
+	}
+
+	context := "event " + event.Identifier
+	indexedValues, err := decodeParameters(indexed, topics, context+" topics")
+	if err != nil {
+		return "", nil, err
+	}
+	notIndexedValues, err := decodeParameters(notIndexed, logEntry.Data, context+" data")
+	if err != nil {
+		return "", nil, err
+	}
+
+	values := make([]interface{}, 0, len(event.Inputs))
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			values = append(values, indexedValues[0])
+			indexedValues = indexedValues[1:]
+			continue
+		}
+		values = append(values, notIndexedValues[0])
+		notIndexedValues = notIndexedValues[1:]
+	}
+
+	return event.Identifier, values, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (contract *contractABI) IsInterfaceNil() bool {
+	return contract == nil
+}
+
+func parameterTypes(parameters []*Parameter) []*returnData.Type {
+	types := make([]*returnData.Type, 0, len(parameters))
+	for _, parameter := range parameters {
+		types = append(types, parameter.Type)
+	}
+
+	return types
+}
+
+func decodeParameters(parameters []*Parameter, data [][]byte, context string) ([]interface{}, error) {
+	values, err := returnData.Decode(data, parameterTypes(parameters)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s", err, context)
+	}
+
+	return values, nil
+}
+
+func encodeParameters(parameters []*Parameter, values []interface{}, context string) ([][]byte, error) {
+	if len(values) > len(parameters) {
+		return nil, fmt.Errorf("%w for %s, expected at most %d values, got %d",
+			ErrArgumentsCountMismatch, context, len(parameters), len(values))
+	}
+
+	arguments := make([][]byte, 0, len(values))
+	for i, parameter := range parameters {
+		isMissing := i >= len(values) || values[i] == nil && parameter.Type.IsOptional()
+		if isMissing {
+			if !parameter.Type.IsOptional() {
+				return nil, fmt.Errorf("%w for %s, missing value for %s", ErrArgumentsCountMismatch, context, parameter.Name)
+			}
+			if !allNil(values[i:]) {
+				return nil, fmt.Errorf("%w for %s, missing optional %s followed by values",
+					ErrArgumentsCountMismatch, context, parameter.Name)
+			}
+			break
+		}
+
+		t := parameter.Type
+		if t.IsOptional() {
+			t = t.Element
+		}
+		argument, err := EncodeTopLevel(values[i], t)
+		if err != nil {
+			return nil, fmt.Errorf("%w for %s argument %s", err, context, parameter.Name)
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+func allNil(values []interface{}) bool {
+	for _, value := range values {
+		if value != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+type typeResolver struct {
+	definitions map[string]*typeDefinitionJSON
+	resolved    map[string]*returnData.Type
+	resolving   map[string]struct{}
+}
+
+func newTypeResolver(definitions map[string]*typeDefinitionJSON) *typeResolver {
+	return &typeResolver{
+		definitions: definitions,
+		resolved:    make(map[string]*returnData.Type),
+		resolving:   make(map[string]struct{}),
+	}
+}
+
+func (resolver *typeResolver) resolveEndpoint(definition *endpointJSON) (*Endpoint, error) {
+	context := "endpoint " + definition.Name
+	inputs, err := resolver.resolveParameters(definition.Inputs, context)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := resolver.resolveParameters(definition.Outputs, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Endpoint{Name: definition.Name, Inputs: inputs, Outputs: outputs}, nil
+}
+
+func (resolver *typeResolver) resolveParameters(definitions []*parameterJSON, context string) ([]*Parameter, error) {
+	parameters := make([]*Parameter, 0, len(definitions))
+	for i, definition := range definitions {
+		if definition == nil {
+			return nil, fmt.Errorf("%w: nil parameter %d of %s", ErrInvalidABI, i, context)
+		}
+		t, err := resolver.resolve(definition.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %s", err, context)
+		}
+		isAfterOptional := len(parameters) > 0 && parameters[len(parameters)-1].Type.IsOptional()
+		if isAfterOptional && !t.IsOptional() {
+			return nil, fmt.Errorf("%w: required parameter %s follows an optional one in %s", ErrInvalidABI, definition.Name, context)
+		}
+
+		parameters = append(parameters, &Parameter{Name: definition.Name, Type: t, Indexed: definition.Indexed})
+	}
+
+	return parameters, nil
+}
+
+func (resolver *typeResolver) resolve(name string) (*returnData.Type, error) {
+	name = strings.TrimSpace(name)
+	switch name {
+	case "u8":
+		return returnData.U8, nil
+	case "u16":
+		return returnData.U16, nil
+	case "u32":
+		return returnData.U32, nil
+	case "u64":
+		return returnData.U64, nil
+	case "i64":
+		return returnData.I64, nil
+	case "BigUint":
+		return returnData.BigUint, nil
+	case "BigInt":
+		return returnData.BigInt, nil
+	case "Address":
+		return returnData.Address, nil
+	case "bool":
+		return returnData.Bool, nil
+	case "utf-8 string", "string":
+		return returnData.String, nil
+	case "TokenIdentifier":
+		return returnData.TokenIdentifier, nil
+	case "bytes":
+		return returnData.Bytes, nil
+	}
+
+	for prefix, wrap := range map[string]func(*returnData.Type) *returnData.Type{
+		"List<":     returnData.List,
+		"Option<":   returnData.Option,
+		"optional<": returnData.Optional,
+	} {
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ">") {
+			continue
+		}
+
+		element, err := resolver.resolve(name[len(prefix) : len(name)-1])
+		if err != nil {
+			return nil, err
+		}
+		if element.IsOptional() {
+			return nil, fmt.Errorf("%w %s, optional can not be nested", ErrUnsupportedType, name)
+		}
+		return wrap(element), nil
+	}
+
+	return resolver.resolveCustom(name)
+}
+
+func (resolver *typeResolver) resolveCustom(name string) (*returnData.Type, error) {
+	t, found := resolver.resolved[name]
+	if found {
+		return t, nil
+	}
+	definition, found := resolver.definitions[name]
+	if !found || definition == nil {
+		return nil, fmt.Errorf("%w %s", ErrUnknownType, name)
+	}
+	if definition.Type != "struct" {
+		return nil, fmt.Errorf("%w %s of kind %s", ErrUnsupportedType, name, definition.Type)
+	}
+	_, found = resolver.resolving[name]
+	if found {
+		return nil, fmt.Errorf("%w %s", ErrCyclicType, name)
+	}
+
+	resolver.resolving[name] = struct{}{}
+	defer delete(resolver.resolving, name)
+
+	fields := make([]*returnData.Field, 0, len(definition.Fields))
+	fieldNames := make(map[string]struct{}, len(definition.Fields))
+	for _, fieldDefinition := range definition.Fields {
+		if fieldDefinition == nil {
+			return nil, fmt.Errorf("%w: nil field in %s", ErrInvalidABI, name)
+		}
+		_, found = fieldNames[fieldDefinition.Name]
+		if found {
+			return nil, fmt.Errorf("%w: duplicated field %s in %s", ErrInvalidABI, fieldDefinition.Name, name)
+		}
+		fieldNames[fieldDefinition.Name] = struct{}{}
+		fieldType, err := resolver.resolve(fieldDefinition.Type)
+		if err != nil {
+			return nil, err
+		}
+		if fieldType.IsOptional() {
+			return nil, fmt.Errorf("%w %s, optional can not be a field", ErrUnsupportedType, name)
+		}
+		fields = append(fields, &returnData.Field{Name: fieldDefinition.Name, Type: fieldType})
+	}
+
+	t = returnData.Struct(name, fields...)
+	resolver.resolved[name] = t
+
+	return t, nil
+}
@@ -0,0 +1,274 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/returnData"
+)
+
+const marketABIPath = "testdata/market.abi.json"
+
+func createMarketABI(t *testing.T) *contractABI {
+	contract, err := NewContractABIFromFile(marketABIPath)
+	require.Nil(t, err)
+
+	return contract
+}
+
+func TestNewContractABI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file should error", func(t *testing.T) {
+		t.Parallel()
+
+		contract, err := NewContractABIFromFile("testdata/missing.abi.json")
+		assert.NotNil(t, err)
+		assert.True(t, contract.IsInterfaceNil())
+	})
+	t.Run("invalid definitions should error", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name     string
+			abiJSON  string
+			expected error
+		}{
+			{name: "invalid JSON", abiJSON: `{`, expected: ErrInvalidABI},
+			{
+				name:     "unknown type",
+				abiJSON:  `{"endpoints":[{"name":"a","inputs":[{"name":"x","type":"List<Missing>"}]}]}`,
+				expected: ErrUnknownType,
+			},
+			{
+				name:     "duplicated endpoint",
+				abiJSON:  `{"endpoints":[{"name":"a"},{"name":"a"}]}`,
+				expected: ErrInvalidABI,
+			},
+			{
+				name:     "required after optional",
+				abiJSON:  `{"endpoints":[{"name":"a","inputs":[{"name":"x","type":"optional<u8>"},{"name":"y","type":"u8"}]}]}`,
+				expected: ErrInvalidABI,
+			},
+			{
+				name:     "nested optional",
+				abiJSON:  `{"endpoints":[{"name":"a","inputs":[{"name":"x","type":"List<optional<u8>>"}]}]}`,
+				expected: ErrUnsupportedType,
+			},
+			{
+				name:     "enum",
+				abiJSON:  `{"endpoints":[{"name":"a","outputs":[{"type":"Kind"}]}],"types":{"Kind":{"type":"enum"}}}`,
+				expected: ErrUnsupportedType,
+			},
+			{
+				name: "cyclic struct",
+				abiJSON: `{"endpoints":[{"name":"a","outputs":[{"type":"Node"}]}],` +
+					`"types":{"Node":{"type":"struct","fields":[{"name":"next","type":"Option<Node>"}]}}}`,
+				expected: ErrCyclicType,
+			},
+			{
+				name: "duplicated field",
+				abiJSON: `{"endpoints":[{"name":"a","outputs":[{"type":"Pair"}]}],` +
+					`"types":{"Pair":{"type":"struct","fields":[{"name":"x","type":"u8"},{"name":"x","type":"u8"}]}}}`,
+				expected: ErrInvalidABI,
+			},
+		}
+
+		for _, tc := range testCases {
+			_, err := NewContractABI([]byte(tc.abiJSON))
+			assert.True(t, errors.Is(err, tc.expected), tc.name)
+		}
+	})
+	t.Run("should load", func(t *testing.T) {
+		t.Parallel()
+
+		contract := createMarketABI(t)
+		assert.False(t, contract.IsInterfaceNil())
+		assert.Equal(t, "Market", contract.Name())
+		assert.Equal(t, "init", contract.Constructor().Name)
+
+		endpoint, err := contract.GetEndpoint("getOffer")
+		require.Nil(t, err)
+		require.Equal(t, 2, len(endpoint.Outputs))
+		assert.Equal(t, "Offer", endpoint.Outputs[0].Type.String())
+		assert.Equal(t, "Price", endpoint.Outputs[0].Type.Fields[1].Type.String())
+		assert.Equal(t, "optional<i64>", endpoint.Outputs[1].Type.String())
+
+		_, err = contract.GetEndpoint("missing")
+		assert.True(t, errors.Is(err, ErrUnknownEndpoint))
+		_, err = contract.GetEvent("missing")
+		assert.True(t, errors.Is(err, ErrUnknownEvent))
+	})
+}
+
+func TestContractABI_EncodeArguments(t *testing.T) {
+	t.Parallel()
+
+	contract := createMarketABI(t)
+
+	t.Run("should encode", func(t *testing.T) {
+		t.Parallel()
+
+		arguments, err := contract.EncodeArguments("createOffer", "TKN-abcdef", uint64(3), big.NewInt(1000), nil, []string{"a", "bc"})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{
+			[]byte("TKN-abcdef"),
+			{3},
+			{0x03, 0xe8},
+			{},
+			{0, 0, 0, 1, 'a', 0, 0, 0, 2, 'b', 'c'},
+		}, arguments)
+
+		arguments, err = contract.EncodeArguments("createOffer", "TKN-abcdef", 3, 1000, 50, []string{}, []byte("note"))
+		require.Nil(t, err)
+		assert.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 50}, arguments[3])
+		assert.Equal(t, []byte("note"), arguments[5])
+	})
+	t.Run("constructor should encode", func(t *testing.T) {
+		t.Parallel()
+
+		arguments, err := contract.EncodeConstructorArguments(5)
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{{5}}, arguments)
+	})
+	t.Run("arity mismatches should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := contract.EncodeArguments("createOffer", "TKN-abcdef", 3, 1000, nil)
+		assert.True(t, errors.Is(err, ErrArgumentsCountMismatch))
+
+		_, err = contract.EncodeArguments("getOffer", 1, 2)
+		assert.True(t, errors.Is(err, ErrArgumentsCountMismatch))
+
+		_, err = contract.EncodeConstructorArguments()
+		assert.True(t, errors.Is(err, ErrArgumentsCountMismatch))
+
+		_, err = contract.EncodeArguments("missing")
+		assert.True(t, errors.Is(err, ErrUnknownEndpoint))
+	})
+	t.Run("type mismatches should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := contract.EncodeArguments("getOffer", "1")
+		assert.True(t, errors.Is(err, ErrTypeMismatch))
+		assert.Contains(t, err.Error(), "endpoint getOffer argument id")
+	})
+}
+
+func TestContractABI_CallData(t *testing.T) {
+	t.Parallel()
+
+	contract := createMarketABI(t)
+	data, err := contract.EncodeCallData("createOffer", "TKN-abcdef", 3, 1000, nil, []string{"a"})
+	require.Nil(t, err)
+	assert.Equal(t, "createOffer@"+hex.EncodeToString([]byte("TKN-abcdef"))+"@03@03e8@@0000000161", data)
+
+	endpointName, values, err := contract.DecodeCallData(data)
+	require.Nil(t, err)
+	assert.Equal(t, "createOffer", endpointName)
+	assert.Equal(t, []interface{}{"TKN-abcdef", uint64(3), big.NewInt(1000), nil, []interface{}{"a"}, nil}, values)
+
+	_, _, err = contract.DecodeCallData("getOffer@0102030405")
+	assert.True(t, errors.Is(err, returnData.ErrInvalidEncoding))
+}
+
+func createOffer(seller []byte) (map[string]interface{}, []byte) {
+	offer := map[string]interface{}{
+		"seller": seller,
+		"price": map[string]interface{}{
+			"token":  "TKN-abcdef",
+			"amount": big.NewInt(-5),
+		},
+		"active": true,
+	}
+
+	encoded := append([]byte{}, seller...)
+	encoded = append(encoded, 0, 0, 0, 10)
+	encoded = append(encoded, "TKN-abcdef"...)
+	encoded = append(encoded, 0, 0, 0, 1, 0xfb, 1)
+
+	return offer, encoded
+}
+
+func TestContractABI_DecodeReturnData(t *testing.T) {
+	t.Parallel()
+
+	contract := createMarketABI(t)
+	seller := bytes.Repeat([]byte{1}, returnData.AddressLength)
+	offer, encodedOffer := createOffer(seller)
+
+	values, err := contract.DecodeReturnData("getOffer", [][]byte{encodedOffer})
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{offer, nil}, values)
+
+	values, err = contract.DecodeVMOutput("getOffer", &vmcommon.VMOutput{ReturnData: [][]byte{encodedOffer, {0xff}}})
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{offer, int64(-1)}, values)
+
+	encoded, err := EncodeTopLevel(offer, contract.endpoints["getOffer"].Outputs[0].Type)
+	require.Nil(t, err)
+	assert.Equal(t, encodedOffer, encoded)
+
+	_, err = contract.DecodeReturnData("getOffer", [][]byte{encodedOffer, {1}, {2}})
+	assert.True(t, errors.Is(err, returnData.ErrTooManyReturnData))
+	_, err = contract.DecodeReturnData("getOffer", nil)
+	assert.True(t, errors.Is(err, returnData.ErrMissingReturnData))
+	_, err = contract.DecodeVMOutput("getOffer", nil)
+	assert.Equal(t, returnData.ErrNilVMOutput, err)
+}
+
+func TestContractABI_DecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	contract := createMarketABI(t)
+	seller := bytes.Repeat([]byte{2}, returnData.AddressLength)
+	offer, encodedOffer := createOffer(seller)
+
+	t.Run("identifier as first topic", func(t *testing.T) {
+		t.Parallel()
+
+		identifier, values, err := contract.DecodeEvent(&vmcommon.LogEntry{
+			Identifier: []byte("createOffer"),
+			Topics:     [][]byte{[]byte("offerCreated"), {7}, seller},
+			Data:       [][]byte{encodedOffer},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, "offerCreated", identifier)
+		assert.Equal(t, []interface{}{uint32(7), offer, seller}, values)
+	})
+	t.Run("identifier as log identifier", func(t *testing.T) {
+		t.Parallel()
+
+		_, values, err := contract.DecodeEvent(&vmcommon.LogEntry{
+			Identifier: []byte("offerCreated"),
+			Topics:     [][]byte{{7}, seller},
+			Data:       [][]byte{encodedOffer},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, []interface{}{uint32(7), offer, seller}, values)
+	})
+	t.Run("invalid events should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := contract.DecodeEvent(nil)
+		assert.Equal(t, ErrNilLogEntry, err)
+
+		_, _, err = contract.DecodeEvent(&vmcommon.LogEntry{Identifier: []byte("other")})
+		assert.True(t, errors.Is(err, ErrUnknownEvent))
+
+		_, _, err = contract.DecodeEvent(&vmcommon.LogEntry{
+			Identifier: []byte("offerCreated"),
+			Topics:     [][]byte{{7}},
+			Data:       [][]byte{encodedOffer},
+		})
+		assert.True(t, errors.Is(err, returnData.ErrMissingReturnData))
+		assert.True(t, strings.Contains(err.Error(), "event offerCreated topics"))
+	})
+}
@@ -0,0 +1,296 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/returnData"
+)
+
+// EncodeTopLevel encodes the provided value as a single argument of the provided type. The accepted values are:
+// Go integers and *big.Int for the numeric types, []byte for Address, bool for bool, string or []byte for string,
+// TokenIdentifier and bytes, slices for List, nil or the element value for Option and map[string]interface{}
+// holding all the fields for structs.
+func EncodeTopLevel(value interface{}, t *returnData.Type) ([]byte, error) {
+	if t == nil {
+		return nil, returnData.ErrNilType
+	}
+
+	switch t.Kind {
+	case returnData.KindU8, returnData.KindU16, returnData.KindU32, returnData.KindU64, returnData.KindBigUint:
+		number, err := toUnsignedNumber(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return number.Bytes(), nil
+	case returnData.KindI64, returnData.KindBigInt:
+		number, err := toSignedNumber(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return toTwosComplement(number), nil
+	case returnData.KindBool:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, newTypeMismatchError(value, t)
+		}
+		if flag {
+			return []byte{1}, nil
+		}
+		return make([]byte, 0), nil
+	case returnData.KindString, returnData.KindTokenIdentifier, returnData.KindBytes:
+		return toBuffer(value, t)
+	case returnData.KindList:
+		return encodeList(value, t, false)
+	case returnData.KindOption:
+		if value == nil {
+			return make([]byte, 0), nil
+		}
+		return encodeNested([]byte{1}, value, t.Element)
+	case returnData.KindOptional:
+		return nil, fmt.Errorf("%w, %s can not be encoded as a single argument", ErrTypeMismatch, t)
+	}
+
+	return encodeNested(make([]byte, 0), value, t)
+}
+
+func encodeNested(buff []byte, value interface{}, t *returnData.Type) ([]byte, error) {
+	if t == nil {
+		return nil, returnData.ErrNilType
+	}
+
+	switch t.Kind {
+	case returnData.KindU8, returnData.KindU16, returnData.KindU32, returnData.KindU64:
+		number, err := toUnsignedNumber(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return append(buff, number.FillBytes(make([]byte, fixedWidth(t)))...), nil
+	case returnData.KindI64:
+		number, err := toSignedNumber(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(buff, uint64(number.Int64())), nil
+	case returnData.KindBigUint:
+		number, err := toUnsignedNumber(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return appendLengthPrefixed(buff, number.Bytes()), nil
+	case returnData.KindBigInt:
+		number, err := toSignedNumber(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return appendLengthPrefixed(buff, toTwosComplement(number)), nil
+	case returnData.KindAddress:
+		address, ok := value.([]byte)
+		if !ok || len(address) != returnData.AddressLength {
+			return nil, newTypeMismatchError(value, t)
+		}
+		return append(buff, address...), nil
+	case returnData.KindBool:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, newTypeMismatchError(value, t)
+		}
+		if flag {
+			return append(buff, 1), nil
+		}
+		return append(buff, 0), nil
+	case returnData.KindString, returnData.KindTokenIdentifier, returnData.KindBytes:
+		data, err := toBuffer(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return appendLengthPrefixed(buff, data), nil
+	case returnData.KindList:
+		list, err := encodeList(value, t, true)
+		if err != nil {
+			return nil, err
+		}
+		return append(buff, list...), nil
+	case returnData.KindOption:
+		if value == nil {
+			return append(buff, 0), nil
+		}
+		return encodeNested(append(buff, 1), value, t.Element)
+	case returnData.KindStruct:
+		return encodeStruct(buff, value, t)
+	}
+
+	return nil, fmt.Errorf("%w, %s can not be nested", ErrTypeMismatch, t)
+}
+
+func encodeList(value interface{}, t *returnData.Type, withLength bool) ([]byte, error) {
+	list := reflect.ValueOf(value)
+	isList := list.Kind() == reflect.Slice || list.Kind() == reflect.Array
+	if !isList {
+		return nil, newTypeMismatchError(value, t)
+	}
+
+	buff := make([]byte, 0)
+	if withLength {
+		buff = binary.BigEndian.AppendUint32(buff, uint32(list.Len()))
+	}
+	var err error
+	for i := 0; i < list.Len(); i++ {
+		buff, err = encodeNested(buff, list.Index(i).Interface(), t.Element)
+		if err != nil {
+			return nil, fmt.Errorf("%w for element %d", err, i)
+		}
+	}
+
+	return buff, nil
+}
+
+func encodeStruct(buff []byte, value interface{}, t *returnData.Type) ([]byte, error) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, newTypeMismatchError(value, t)
+	}
+	if len(fields) != len(t.Fields) {
+		return nil, fmt.Errorf("%w, %s has %d fields, got %d values", ErrTypeMismatch, t, len(t.Fields), len(fields))
+	}
+
+	var err error
+	for _, field := range t.Fields {
+		fieldValue, found := fields[field.Name]
+		if !found {
+			return nil, fmt.Errorf("%w, missing field %s of %s", ErrTypeMismatch, field.Name, t)
+		}
+		buff, err = encodeNested(buff, fieldValue, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%w for field %s", err, field.Name)
+		}
+	}
+
+	return buff, nil
+}
+
+func appendLengthPrefixed(buff []byte, data []byte) []byte {
+	buff = binary.BigEndian.AppendUint32(buff, uint32(len(data)))
+	return append(buff, data...)
+}
+
+func toBuffer(value interface{}, t *returnData.Type) ([]byte, error) {
+	var data []byte
+	switch typedValue := value.(type) {
+	case []byte:
+		data = typedValue
+	case string:
+		data = []byte(typedValue)
+	default:
+		return nil, newTypeMismatchError(value, t)
+	}
+
+	if t.Kind == returnData.KindTokenIdentifier && !vmcommon.ValidateToken(data) {
+		return nil, fmt.Errorf("%w, invalid token identifier %q", ErrTypeMismatch, data)
+	}
+
+	return data, nil
+}
+
+func toNumber(value interface{}) (*big.Int, bool) {
+	switch typedValue := value.(type) {
+	case *big.Int:
+		if typedValue == nil {
+			return nil, false
+		}
+		return big.NewInt(0).Set(typedValue), true
+	case int:
+		return big.NewInt(int64(typedValue)), true
+	case int8:
+		return big.NewInt(int64(typedValue)), true
+	case int16:
+		return big.NewInt(int64(typedValue)), true
+	case int32:
+		return big.NewInt(int64(typedValue)), true
+	case int64:
+		return big.NewInt(typedValue), true
+	case uint:
+		return big.NewInt(0).SetUint64(uint64(typedValue)), true
+	case uint8:
+		return big.NewInt(0).SetUint64(uint64(typedValue)), true
+	case uint16:
+		return big.NewInt(0).SetUint64(uint64(typedValue)), true
+	case uint32:
+		return big.NewInt(0).SetUint64(uint64(typedValue)), true
+	case uint64:
+		return big.NewInt(0).SetUint64(typedValue), true
+	}
+
+	return nil, false
+}
+
+func toUnsignedNumber(value interface{}, t *returnData.Type) (*big.Int, error) {
+	number, ok := toNumber(value)
+	if !ok {
+		return nil, newTypeMismatchError(value, t)
+	}
+
+	width := fixedWidth(t)
+	isTooLarge := width > 0 && number.BitLen() > width*8
+	if number.Sign() < 0 || isTooLarge {
+		return nil, fmt.Errorf("%w, %s for %s", ErrValueOutOfRange, number, t)
+	}
+
+	return number, nil
+}
+
+func toSignedNumber(value interface{}, t *returnData.Type) (*big.Int, error) {
+	number, ok := toNumber(value)
+	if !ok {
+		return nil, newTypeMismatchError(value, t)
+	}
+	if t.Kind == returnData.KindI64 && !number.IsInt64() {
+		return nil, fmt.Errorf("%w, %s for %s", ErrValueOutOfRange, number, t)
+	}
+
+	return number, nil
+}
+
+// toTwosComplement returns the shortest big endian two's complement representation of the provided number
+func toTwosComplement(number *big.Int) []byte {
+	if number.Sign() == 0 {
+		return make([]byte, 0)
+	}
+
+	magnitude := number
+	if number.Sign() < 0 {
+		magnitude = big.NewInt(0).Neg(number)
+		magnitude.Sub(magnitude, big.NewInt(1))
+	}
+	length := magnitude.BitLen()/8 + 1
+
+	value := number
+	if number.Sign() < 0 {
+		value = big.NewInt(0).Lsh(big.NewInt(1), uint(length*8))
+		value.Add(value, number)
+	}
+
+	return value.FillBytes(make([]byte, length))
+}
+
+func fixedWidth(t *returnData.Type) int {
+	switch t.Kind {
+	case returnData.KindU8:
+		return 1
+	case returnData.KindU16:
+		return 2
+	case returnData.KindU32:
+		return 4
+	case returnData.KindU64:
+		return 8
+	}
+
+	return 0
+}
+
+func newTypeMismatchError(value interface{}, t *returnData.Type) error {
+	return fmt.Errorf("%w, can not encode %T as %s", ErrTypeMismatch, value, t)
+}
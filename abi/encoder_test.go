@@ -0,0 +1,103 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-vm-common-1234/returnData"
+)
+
+func TestEncodeTopLevel(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{3}, returnData.AddressLength)
+	pair := returnData.Struct("Pair",
+		&returnData.Field{Name: "first", Type: returnData.U16},
+		&returnData.Field{Name: "second", Type: returnData.BigInt},
+	)
+	testCases := []struct {
+		name     string
+		value    interface{}
+		t        *returnData.Type
+		expected []byte
+	}{
+		{name: "zero u8", value: 0, t: returnData.U8, expected: []byte{}},
+		{name: "u16", value: uint16(258), t: returnData.U16, expected: []byte{1, 2}},
+		{name: "u64 from big int", value: big.NewInt(256), t: returnData.U64, expected: []byte{1, 0}},
+		{name: "negative i64", value: int64(-1), t: returnData.I64, expected: []byte{0xff}},
+		{name: "positive i64", value: 128, t: returnData.I64, expected: []byte{0x00, 0x80}},
+		{name: "BigUint", value: big.NewInt(65535), t: returnData.BigUint, expected: []byte{0xff, 0xff}},
+		{name: "negative BigInt", value: big.NewInt(-129), t: returnData.BigInt, expected: []byte{0xff, 0x7f}},
+		{name: "address", value: address, t: returnData.Address, expected: address},
+		{name: "true", value: true, t: returnData.Bool, expected: []byte{1}},
+		{name: "false", value: false, t: returnData.Bool, expected: []byte{}},
+		{name: "string", value: "text", t: returnData.String, expected: []byte("text")},
+		{name: "token identifier", value: []byte("TKN-abcdef"), t: returnData.TokenIdentifier, expected: []byte("TKN-abcdef")},
+		{name: "list", value: []uint16{1, 2}, t: returnData.List(returnData.U16), expected: []byte{0, 1, 0, 2}},
+		{name: "missing option", value: nil, t: returnData.Option(returnData.U8), expected: []byte{}},
+		{name: "present option", value: 9, t: returnData.Option(returnData.BigUint), expected: []byte{1, 0, 0, 0, 1, 9}},
+		{
+			name:     "nested lists",
+			value:    [][]bool{{true, false}, {}},
+			t:        returnData.List(returnData.List(returnData.Bool)),
+			expected: []byte{0, 0, 0, 2, 1, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "struct",
+			value:    map[string]interface{}{"first": 1, "second": big.NewInt(-1)},
+			t:        pair,
+			expected: []byte{0, 1, 0, 0, 0, 1, 0xff},
+		},
+	}
+
+	for _, tc := range testCases {
+		encoded, err := EncodeTopLevel(tc.value, tc.t)
+		require.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expected, encoded, tc.name)
+
+		decoded, err := returnData.DecodeTopLevel(encoded, tc.t)
+		require.Nil(t, err, tc.name)
+		reencoded, err := EncodeTopLevel(decoded, tc.t)
+		require.Nil(t, err, tc.name)
+		assert.Equal(t, encoded, reencoded, tc.name)
+	}
+}
+
+func TestEncodeTopLevel_InvalidValuesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pair := returnData.Struct("Pair", &returnData.Field{Name: "first", Type: returnData.U8})
+	testCases := []struct {
+		name     string
+		value    interface{}
+		t        *returnData.Type
+		expected error
+	}{
+		{name: "u8 overflow", value: 256, t: returnData.U8, expected: ErrValueOutOfRange},
+		{name: "negative u32", value: -1, t: returnData.U32, expected: ErrValueOutOfRange},
+		{name: "negative BigUint", value: big.NewInt(-1), t: returnData.BigUint, expected: ErrValueOutOfRange},
+		{name: "i64 overflow", value: uint64(1) << 63, t: returnData.I64, expected: ErrValueOutOfRange},
+		{name: "string as u64", value: "1", t: returnData.U64, expected: ErrTypeMismatch},
+		{name: "nil big int", value: (*big.Int)(nil), t: returnData.BigInt, expected: ErrTypeMismatch},
+		{name: "short address", value: []byte{1}, t: returnData.Address, expected: ErrTypeMismatch},
+		{name: "int as bool", value: 1, t: returnData.Bool, expected: ErrTypeMismatch},
+		{name: "invalid token identifier", value: "tkn", t: returnData.TokenIdentifier, expected: ErrTypeMismatch},
+		{name: "not a list", value: 1, t: returnData.List(returnData.U8), expected: ErrTypeMismatch},
+		{name: "invalid list element", value: []int{1, 300}, t: returnData.List(returnData.U8), expected: ErrValueOutOfRange},
+		{name: "missing field", value: map[string]interface{}{"other": 1}, t: pair, expected: ErrTypeMismatch},
+		{name: "extra field", value: map[string]interface{}{"first": 1, "other": 1}, t: pair, expected: ErrTypeMismatch},
+		{name: "optional", value: 1, t: returnData.Optional(returnData.U8), expected: ErrTypeMismatch},
+	}
+
+	for _, tc := range testCases {
+		_, err := EncodeTopLevel(tc.value, tc.t)
+		assert.True(t, errors.Is(err, tc.expected), tc.name)
+	}
+
+	_, err := EncodeTopLevel(1, nil)
+	assert.Equal(t, returnData.ErrNilType, err)
+}
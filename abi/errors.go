@@ -0,0 +1,33 @@
+package abi
+
+import "errors"
+
+// ErrInvalidABI signals that the provided ABI definition could not be loaded
+var ErrInvalidABI = errors.New("invalid ABI")
+
+// ErrUnknownType signals that a type name is neither a known type nor a defined custom type
+var ErrUnknownType = errors.New("unknown type")
+
+// ErrUnsupportedType signals that a custom type definition is not supported
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ErrCyclicType signals that a custom type contains itself
+var ErrCyclicType = errors.New("cyclic type")
+
+// ErrUnknownEndpoint signals that the ABI does not define the requested endpoint
+var ErrUnknownEndpoint = errors.New("unknown endpoint")
+
+// ErrUnknownEvent signals that the ABI does not define the requested event
+var ErrUnknownEvent = errors.New("unknown event")
+
+// ErrNilLogEntry signals that a nil log entry was provided
+var ErrNilLogEntry = errors.New("nil log entry")
+
+// ErrArgumentsCountMismatch signals that the number of values does not match the endpoint inputs
+var ErrArgumentsCountMismatch = errors.New("arguments count mismatch")
+
+// ErrTypeMismatch signals that a value can not be encoded as the requested type
+var ErrTypeMismatch = errors.New("type mismatch")
+
+// ErrValueOutOfRange signals that a numeric value does not fit the requested type
+var ErrValueOutOfRange = errors.New("value out of range")
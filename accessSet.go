@@ -0,0 +1,80 @@
+package vmcommon
+
+import "bytes"
+
+// AccessKey identifies the state touched by a built-in function call: a whole account when Key is empty,
+// or a single key of the account data trie otherwise
+type AccessKey struct {
+	Address []byte
+	Key     []byte
+}
+
+// AccessSet holds the state a built-in function call reads and writes
+type AccessSet struct {
+	Reads  []AccessKey
+	Writes []AccessKey
+}
+
+// AccessSetProvider defines the functionality of built-in functions that can declare, before running,
+// the state a call is going to read and write
+type AccessSetProvider interface {
+	AccessSet(vmInput *ContractCallInput) (*AccessSet, error)
+}
+
+// AddRead adds a read of the key from the account data trie, or of the whole account if the key is empty
+func (as *AccessSet) AddRead(address []byte, key []byte) {
+	as.Reads = append(as.Reads, AccessKey{Address: address, Key: key})
+}
+
+// AddWrite adds a write of the key from the account data trie, or of the whole account if the key is empty
+func (as *AccessSet) AddWrite(address []byte, key []byte) {
+	as.Writes = append(as.Writes, AccessKey{Address: address, Key: key})
+}
+
+// ConflictsWith returns true if the two calls can not run concurrently. Accounts are saved as a whole, so two
+// writes on the same account always conflict, while a read conflicts with a write only if the keys overlap.
+// A nil access set conflicts with everything.
+func (as *AccessSet) ConflictsWith(other *AccessSet) bool {
+	if as == nil || other == nil {
+		return true
+	}
+
+	for _, write := range as.Writes {
+		for _, otherWrite := range other.Writes {
+			if bytes.Equal(write.Address, otherWrite.Address) {
+				return true
+			}
+		}
+		if overlapsAny(write, other.Reads) {
+			return true
+		}
+	}
+	for _, otherWrite := range other.Writes {
+		if overlapsAny(otherWrite, as.Reads) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func overlapsAny(accessKey AccessKey, accessKeys []AccessKey) bool {
+	for _, otherAccessKey := range accessKeys {
+		if accessKey.overlaps(otherAccessKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ak AccessKey) overlaps(other AccessKey) bool {
+	if !bytes.Equal(ak.Address, other.Address) {
+		return false
+	}
+	if len(ak.Key) == 0 || len(other.Key) == 0 {
+		return true
+	}
+
+	return bytes.Equal(ak.Key, other.Key)
+}
@@ -0,0 +1,57 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessSet_ConflictsWith(t *testing.T) {
+	t.Parallel()
+
+	alice := []byte("alice")
+	bob := []byte("bob")
+	key1 := []byte("key1")
+	key2 := []byte("key2")
+
+	createAccessSet := func(reads []AccessKey, writes []AccessKey) *AccessSet {
+		return &AccessSet{Reads: reads, Writes: writes}
+	}
+
+	testCases := []struct {
+		name     string
+		first    *AccessSet
+		second   *AccessSet
+		conflict bool
+	}{
+		{"nil access set", nil, createAccessSet(nil, nil), true},
+		{"reads only", createAccessSet([]AccessKey{{alice, nil}}, nil), createAccessSet([]AccessKey{{alice, nil}}, nil), false},
+		{"writes on different accounts", createAccessSet(nil, []AccessKey{{alice, nil}}), createAccessSet(nil, []AccessKey{{bob, nil}}), false},
+		{"writes on different keys of the same account", createAccessSet(nil, []AccessKey{{alice, key1}}), createAccessSet(nil, []AccessKey{{alice, key2}}), true},
+		{"write and read of the same key", createAccessSet(nil, []AccessKey{{alice, key1}}), createAccessSet([]AccessKey{{alice, key1}}, nil), true},
+		{"write and read of different keys", createAccessSet(nil, []AccessKey{{alice, key1}}), createAccessSet([]AccessKey{{alice, key2}}, nil), false},
+		{"read and write of the whole account", createAccessSet([]AccessKey{{alice, key1}}, nil), createAccessSet(nil, []AccessKey{{alice, nil}}), true},
+		{"read and write on different accounts", createAccessSet([]AccessKey{{bob, nil}}, nil), createAccessSet(nil, []AccessKey{{alice, nil}}), false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.conflict, tc.first.ConflictsWith(tc.second))
+			assert.Equal(t, tc.conflict, tc.second.ConflictsWith(tc.first))
+		})
+	}
+}
+
+func TestAccessSet_AddReadAndWrite(t *testing.T) {
+	t.Parallel()
+
+	accessSet := &AccessSet{}
+	accessSet.AddRead([]byte("alice"), []byte("key"))
+	accessSet.AddWrite([]byte("bob"), nil)
+
+	assert.Equal(t, []AccessKey{{Address: []byte("alice"), Key: []byte("key")}}, accessSet.Reads)
+	assert.Equal(t, []AccessKey{{Address: []byte("bob")}}, accessSet.Writes)
+}
@@ -0,0 +1,121 @@
+package builtInFunctions
+
+import (
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// newAccountsAccessSet creates an access set writing the provided accounts. Built-in functions save the
+// sender and the destination as a whole, so their role keys and balances are covered by these writes.
+func newAccountsAccessSet(addresses ...[]byte) *vmcommon.AccessSet {
+	accessSet := &vmcommon.AccessSet{}
+	for _, address := range addresses {
+		if len(address) == 0 {
+			continue
+		}
+
+		accessSet.AddWrite(address, nil)
+	}
+
+	return accessSet
+}
+
+// addTokenSettingsReads adds the reads of the global settings of the token from the system account
+func addTokenSettingsReads(accessSet *vmcommon.AccessSet, tokenID []byte) {
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	dctTokenTransferRoleKey := append(append([]byte{}, transferAddressesKeyPrefix...), tokenID...)
+
+	accessSet.AddRead(vmcommon.SystemAccountAddress, dctTokenKey)
+	accessSet.AddRead(vmcommon.SystemAccountAddress, dctTokenTransferRoleKey)
+}
+
+// nftTransferAccess declares the system account keys used when transferring tokens. The entry of a token with a
+// nonce on the system account is always read, but it is declared as written only if the transfer changes it, so that
+// the transfers of the tokens which already have their metadata saved do not conflict with each other.
+type nftTransferAccess struct {
+	accounts            vmcommon.AccountsAdapter
+	marshaller          vmcommon.Marshalizer
+	shardCoordinator    vmcommon.Coordinator
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// addTokenAccess adds the system account keys used when transferring the token from the sender to the destination.
+// A nil destination is handled as a cross-shard one.
+func (nta *nftTransferAccess) addTokenAccess(
+	accessSet *vmcommon.AccessSet,
+	tokenID []byte,
+	nonce uint64,
+	sender []byte,
+	destination []byte,
+) error {
+	addTokenSettingsReads(accessSet, tokenID)
+	if nonce == 0 {
+		return nil
+	}
+
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+	accessSet.AddRead(vmcommon.SystemAccountAddress, dctNFTTokenKey)
+
+	isWritten, err := nta.isEntryWritten(dctNFTTokenKey, sender, destination)
+	if err != nil {
+		return err
+	}
+	if isWritten {
+		accessSet.AddWrite(vmcommon.SystemAccountAddress, dctNFTTokenKey)
+	}
+
+	return nil
+}
+
+// isEntryWritten mirrors the writes of the DCT data storage: a cross-shard transfer updates the liquidity or the
+// shards the metadata was sent to, while a transfer in the same shard saves the metadata only if the entry is
+// missing, or if the metadata is always saved and the entry does not mark it as already saved.
+func (nta *nftTransferAccess) isEntryWritten(dctNFTTokenKey []byte, sender []byte, destination []byte) (bool, error) {
+	if !nta.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() {
+		return false, nil
+	}
+	if len(destination) == 0 || !nta.shardCoordinator.SameShard(sender, destination) {
+		return true, nil
+	}
+
+	systemAccount, err := nta.accounts.LoadAccount(vmcommon.SystemAccountAddress)
+	if err != nil {
+		return false, err
+	}
+	userAccount, ok := systemAccount.(vmcommon.UserAccountHandler)
+	if !ok {
+		return false, ErrWrongTypeAssertion
+	}
+
+	marshaledData, _, err := userAccount.AccountDataHandler().RetrieveValue(dctNFTTokenKey)
+	if err != nil || len(marshaledData) == 0 {
+		return true, nil
+	}
+	if !nta.enableEpochsHandler.IsAlwaysSaveTokenMetaDataEnabled() || !nta.enableEpochsHandler.IsSendAlwaysFlagEnabled() {
+		return false, nil
+	}
+
+	dctData := &dct.DCToken{}
+	err = nta.marshaller.Unmarshal(dctData, marshaledData)
+	if err != nil {
+		return false, err
+	}
+
+	return len(dctData.Reserved) == 0, nil
+}
+
+// destinationFromArgument returns the argument if it can be a destination address, or nil otherwise
+func destinationFromArgument(vmInput *vmcommon.ContractCallInput, index int) []byte {
+	if len(vmInput.Arguments) <= index || len(vmInput.Arguments[index]) != len(vmInput.CallerAddr) {
+		return nil
+	}
+
+	return vmInput.Arguments[index]
+}
+
+func nonceFromArgument(argument []byte) uint64 {
+	return big.NewInt(0).SetBytes(argument).Uint64()
+}
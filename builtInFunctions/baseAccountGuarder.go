@@ -22,6 +22,8 @@ type BaseAccountGuarderArgs struct {
 
 type baseAccountGuarder struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	marshaller            marshal.Marshalizer
 	guardedAccountHandler vmcommon.GuardedAccountHandler
 
@@ -57,6 +59,7 @@ func (baf *baseAccountGuarder) checkBaseAccountGuarderArgs(
 	receiverAddr []byte,
 	value *big.Int,
 	funcCallGasProvided uint64,
+	funcGasCost uint64,
 	arguments [][]byte,
 	expectedNoOfArgs uint32,
 ) error {
@@ -73,7 +76,7 @@ func (baf *baseAccountGuarder) checkBaseAccountGuarderArgs(
 	if len(arguments) != int(expectedNoOfArgs) {
 		return fmt.Errorf("%w, expected %d, got %d ", ErrInvalidNumberOfArguments, expectedNoOfArgs, len(arguments))
 	}
-	if funcCallGasProvided < baf.funcGasCost {
+	if funcCallGasProvided < funcGasCost {
 		return ErrNotEnoughGas
 	}
 
@@ -154,6 +154,7 @@ func TestBaseAccountGuarder_CheckArgs(t *testing.T) {
 			test.vmInput().RecipientAddr,
 			test.vmInput().CallValue,
 			test.vmInput().GasProvided,
+			baseAccGuarder.funcGasCost,
 			test.vmInput().Arguments,
 			test.noOfArgs)
 		if test.expectedErr != nil {
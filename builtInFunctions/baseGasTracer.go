@@ -0,0 +1,84 @@
+package builtInFunctions
+
+import (
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// baseGasTracer reports the gas charges of a built-in function to the gas tracer, if one was set
+type baseGasTracer struct {
+	mutGasTracer sync.RWMutex
+	gasTracer    vmcommon.GasTracer
+}
+
+// SetGasTracer sets the gas tracer which will receive the gas charges
+func (b *baseGasTracer) SetGasTracer(gasTracer vmcommon.GasTracer) error {
+	if check.IfNil(gasTracer) {
+		return ErrNilGasTracer
+	}
+
+	b.mutGasTracer.Lock()
+	b.gasTracer = gasTracer
+	b.mutGasTracer.Unlock()
+
+	return nil
+}
+
+// traceGas attaches the non-empty charges to the output and reports them to the gas tracer
+func (b *baseGasTracer) traceGas(vmInput *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput, charges ...*vmcommon.GasCharge) {
+	b.mutGasTracer.RLock()
+	gasTracer := b.gasTracer
+	b.mutGasTracer.RUnlock()
+
+	if check.IfNil(gasTracer) || vmOutput == nil {
+		return
+	}
+
+	tracedCharges := make([]*vmcommon.GasCharge, 0, len(charges))
+	for _, charge := range charges {
+		if charge == nil || charge.Units == 0 {
+			continue
+		}
+		tracedCharges = append(tracedCharges, charge)
+	}
+	if len(tracedCharges) == 0 {
+		return
+	}
+
+	vmOutput.GasCharges = append(vmOutput.GasCharges, tracedCharges...)
+	gasTracer.TraceGasCharges(vmInput, tracedCharges)
+}
+
+// traceSenderGas traces the charges only in the sender shard, as the built-in functions consume the gas there
+func (b *baseGasTracer) traceSenderGas(
+	acntSnd vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+	vmOutput *vmcommon.VMOutput,
+	charges ...*vmcommon.GasCharge,
+) {
+	if check.IfNil(acntSnd) {
+		return
+	}
+
+	b.traceGas(vmInput, vmOutput, charges...)
+}
+
+func newBuiltInCostCharge(costParameter string, cost uint64) *vmcommon.GasCharge {
+	return newGasCharge(core.BuiltInCostString, costParameter, 1, cost)
+}
+
+func newBaseOperationCostCharge(costParameter string, units uint64, unitCost uint64) *vmcommon.GasCharge {
+	return newGasCharge(core.BaseOperationCostString, costParameter, units, unitCost)
+}
+
+func newGasCharge(category string, costParameter string, units uint64, unitCost uint64) *vmcommon.GasCharge {
+	return &vmcommon.GasCharge{
+		Category:      category,
+		CostParameter: costParameter,
+		Units:         units,
+		Amount:        units * unitCost,
+	}
+}
@@ -0,0 +1,135 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func TestBaseGasTracer_SetGasTracer(t *testing.T) {
+	t.Parallel()
+
+	b := &baseGasTracer{}
+	assert.Equal(t, ErrNilGasTracer, b.SetGasTracer(nil))
+	assert.Nil(t, b.SetGasTracer(&mock.GasTracerStub{}))
+}
+
+func TestBaseGasTracer_TraceGas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without gas tracer should not attach the charges", func(t *testing.T) {
+		t.Parallel()
+
+		b := &baseGasTracer{}
+		vmOutput := &vmcommon.VMOutput{}
+		b.traceGas(&vmcommon.ContractCallInput{}, vmOutput, newBuiltInCostCharge("DCTTransfer", 10))
+		assert.Nil(t, vmOutput.GasCharges)
+	})
+	t.Run("should attach and report the charged units only", func(t *testing.T) {
+		t.Parallel()
+
+		var tracedCharges []*vmcommon.GasCharge
+		b := &baseGasTracer{}
+		_ = b.SetGasTracer(&mock.GasTracerStub{
+			TraceGasChargesCalled: func(_ *vmcommon.ContractCallInput, charges []*vmcommon.GasCharge) {
+				tracedCharges = charges
+			},
+		})
+
+		vmOutput := &vmcommon.VMOutput{}
+		b.traceGas(
+			&vmcommon.ContractCallInput{},
+			vmOutput,
+			newBuiltInCostCharge("DCTNFTCreate", 10),
+			newBaseOperationCostCharge("StorePerByte", 4, 3),
+			newBaseOperationCostCharge("DataCopyPerByte", 0, 3),
+		)
+
+		expectedCharges := []*vmcommon.GasCharge{
+			{Category: core.BuiltInCostString, CostParameter: "DCTNFTCreate", Units: 1, Amount: 10},
+			{Category: core.BaseOperationCostString, CostParameter: "StorePerByte", Units: 4, Amount: 12},
+		}
+		assert.Equal(t, expectedCharges, vmOutput.GasCharges)
+		assert.Equal(t, expectedCharges, tracedCharges)
+	})
+	t.Run("nil sender should not trace", func(t *testing.T) {
+		t.Parallel()
+
+		b := &baseGasTracer{}
+		_ = b.SetGasTracer(&mock.GasTracerStub{
+			TraceGasChargesCalled: func(_ *vmcommon.ContractCallInput, _ []*vmcommon.GasCharge) {
+				assert.Fail(t, "should not have been called")
+			},
+		})
+
+		vmOutput := &vmcommon.VMOutput{}
+		b.traceSenderGas(nil, &vmcommon.ContractCallInput{}, vmOutput, newBuiltInCostCharge("DCTTransfer", 10))
+		assert.Nil(t, vmOutput.GasCharges)
+	})
+}
+
+func TestBuiltInFuncCreator_GasTracer(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+
+	numTraces := 0
+	args := createMockArguments()
+	args.Accounts = adb
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSaveToSystemAccountFlagEnabledField: true,
+	}
+	args.GasTracer = &mock.GasTracerStub{
+		TraceGasChargesCalled: func(_ *vmcommon.ContractCallInput, _ []*vmcommon.GasCharge) {
+			numTraces++
+		},
+	}
+	b, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	require.Nil(t, b.CreateBuiltInFunctionContainer())
+	require.Nil(t, b.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	function, _ := b.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	acntSnd := loadRecorderTestAccount(t, adb, alice)
+	acntDst := loadRecorderTestAccount(t, adb, bob)
+	vmOutput, err := function.ProcessBuiltinFunction(acntSnd, acntDst, createSimulationTransferInput(alice, bob, 40))
+	require.Nil(t, err)
+
+	funcGasCost := b.gasConfig.BuiltInCost.DCTTransfer
+	assert.Equal(t, []*vmcommon.GasCharge{
+		{Category: core.BuiltInCostString, CostParameter: "DCTTransfer", Units: 1, Amount: funcGasCost},
+	}, vmOutput.GasCharges)
+	assert.Equal(t, uint64(100)-funcGasCost, vmOutput.GasRemaining)
+	assert.Equal(t, 1, numTraces)
+
+	function, _ = b.BuiltInFunctionContainer().Get(core.BuiltInFunctionSaveKeyValue)
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			Arguments:   [][]byte{[]byte("key"), []byte("value")},
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+		},
+		RecipientAddr: alice,
+		Function:      core.BuiltInFunctionSaveKeyValue,
+	}
+	vmOutput, err = function.ProcessBuiltinFunction(acntSnd, acntSnd, input)
+	require.Nil(t, err)
+
+	var totalCharged uint64
+	for _, charge := range vmOutput.GasCharges {
+		totalCharged += charge.Amount
+	}
+	assert.Equal(t, 3, len(vmOutput.GasCharges))
+	assert.Equal(t, input.GasProvided-vmOutput.GasRemaining, totalCharged)
+	assert.Equal(t, 2, numTraces)
+}
@@ -26,6 +26,9 @@ func newBaseGuardAccount(args GuardAccountArgs) (*baseGuardAccount, error) {
 		return nil, err
 	}
 
+	base.baseGasSchedule = newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+		return builtInCost.GuardAccount
+	})
 	baseGuardAcc := &baseGuardAccount{
 		base,
 	}
@@ -36,6 +39,7 @@ func newBaseGuardAccount(args GuardAccountArgs) (*baseGuardAccount, error) {
 func (bfa *baseGuardAccount) checkGuardAccountArgs(
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
+	funcGasCost uint64,
 ) error {
 	if check.IfNil(acntSnd) {
 		return fmt.Errorf("%w for sender", ErrNilUserAccount)
@@ -54,6 +58,7 @@ func (bfa *baseGuardAccount) checkGuardAccountArgs(
 		vmInput.RecipientAddr,
 		vmInput.CallValue,
 		vmInput.GasProvided,
+		funcGasCost,
 		vmInput.Arguments,
 		noOfArgsGuardAccount)
 	if err != nil {
@@ -0,0 +1,155 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// ArgsBatchExecutor defines the arguments needed to create a batch executor
+type ArgsBatchExecutor struct {
+	BuiltInFunctions vmcommon.BuiltInFunctionContainer
+	Accounts         vmcommon.AccountsAdapter
+	ShardCoordinator vmcommon.Coordinator
+}
+
+// BatchCallResult holds the outcome of one built-in function call of a batch
+type BatchCallResult struct {
+	VMOutput *vmcommon.VMOutput
+	Err      error
+}
+
+// BatchResult holds the outcome of all the calls of a batch, in the order of the inputs, together with the
+// totals of the successful calls
+type BatchResult struct {
+	Results       []*BatchCallResult
+	NumSuccessful int
+	NumFailed     int
+
+	// TotalGasProvided is the gas provided to all the calls, including the failed ones.
+	TotalGasProvided  uint64
+	TotalGasRemaining uint64
+
+	// TotalGasConsumed is the gas provided minus the gas remaining of the successful calls, so it includes the
+	// gas forwarded through output transfers.
+	TotalGasConsumed uint64
+
+	NumLogs             int
+	NumLogsByIdentifier map[string]int
+}
+
+type batchExecutor struct {
+	*callProcessor
+}
+
+// NewBatchExecutor creates a component which processes an ordered list of built-in function calls. Every call
+// runs against a snapshot of the accounts adapter and a failed call is reverted without affecting the others.
+func NewBatchExecutor(args ArgsBatchExecutor) (*batchExecutor, error) {
+	processor, err := newCallProcessor(args.BuiltInFunctions, args.Accounts, args.ShardCoordinator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchExecutor{
+		callProcessor: processor,
+	}, nil
+}
+
+// ProcessBatch processes the calls one after the other. A call fails if the built-in function returns an error
+// or a return code other than Ok, in which case the accounts are reverted to the state before the call. The
+// returned error is not nil only if the inputs are invalid or the accounts adapter can not be reverted. The logs
+// are not published: the host either calls CommitBatch or reverts the whole batch.
+func (be *batchExecutor) ProcessBatch(vmInputs []*vmcommon.ContractCallInput) (*BatchResult, error) {
+	for i, vmInput := range vmInputs {
+		if vmInput == nil {
+			return nil, fmt.Errorf("%w at index %d", ErrNilVmInput, i)
+		}
+	}
+
+	batchResult := &BatchResult{
+		Results:             make([]*BatchCallResult, 0, len(vmInputs)),
+		NumLogsByIdentifier: make(map[string]int),
+	}
+	for i, vmInput := range vmInputs {
+		callResult, err := be.processCallWithSnapshot(vmInput)
+		if err != nil {
+			return nil, fmt.Errorf("%w while reverting call at index %d", err, i)
+		}
+
+		batchResult.addCallResult(vmInput, callResult)
+	}
+
+	return batchResult, nil
+}
+
+// CommitBatch commits the accounts adapter and publishes the logs of the successful calls of the batch to the log
+// subscribers of the container, in the order of the calls. The inputs must be the ones the batch was processed with.
+func (be *batchExecutor) CommitBatch(vmInputs []*vmcommon.ContractCallInput, batchResult *BatchResult) ([]byte, error) {
+	if batchResult == nil {
+		return nil, fmt.Errorf("%w, nil batch result", ErrInvalidArguments)
+	}
+
+	keptOutputs := make([]*vmcommon.VMOutput, len(batchResult.Results))
+	for i, callResult := range batchResult.Results {
+		if callResult.Err == nil {
+			keptOutputs[i] = callResult.VMOutput
+		}
+	}
+
+	return be.commit(vmInputs, keptOutputs)
+}
+
+func (be *batchExecutor) processCallWithSnapshot(vmInput *vmcommon.ContractCallInput) (*BatchCallResult, error) {
+	snapshot := be.accounts.JournalLen()
+	vmOutput, err := be.processCall(vmInput)
+	if err == nil && vmOutput != nil && vmOutput.ReturnCode == vmcommon.Ok {
+		return &BatchCallResult{VMOutput: vmOutput}, nil
+	}
+
+	if err == nil {
+		err = fmt.Errorf("%w: %s", ErrBuiltInFunctionFailed, returnCodeOf(vmOutput))
+	}
+
+	errRevert := be.accounts.RevertToSnapshot(snapshot)
+	if errRevert != nil {
+		return nil, errRevert
+	}
+
+	return &BatchCallResult{
+		VMOutput: vmOutput,
+		Err:      err,
+	}, nil
+}
+
+func returnCodeOf(vmOutput *vmcommon.VMOutput) string {
+	if vmOutput == nil {
+		return "nil output"
+	}
+
+	return vmOutput.ReturnCode.String()
+}
+
+func (br *BatchResult) addCallResult(vmInput *vmcommon.ContractCallInput, callResult *BatchCallResult) {
+	br.Results = append(br.Results, callResult)
+	br.TotalGasProvided += vmInput.GasProvided
+	if callResult.Err != nil {
+		br.NumFailed++
+		return
+	}
+
+	br.NumSuccessful++
+	vmOutput := callResult.VMOutput
+	br.TotalGasRemaining += vmOutput.GasRemaining
+	gasConsumed, _ := vmcommon.SafeSubUint64(vmInput.GasProvided, vmOutput.GasRemaining)
+	br.TotalGasConsumed += gasConsumed
+
+	br.NumLogs += len(vmOutput.Logs)
+	for _, logEntry := range vmOutput.Logs {
+		br.NumLogsByIdentifier[string(logEntry.Identifier)]++
+	}
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (be *batchExecutor) IsInterfaceNil() bool {
+	return be == nil
+}
@@ -0,0 +1,145 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func createBatchExecutorArgs(container vmcommon.BuiltInFunctionContainer, adb vmcommon.AccountsAdapter) ArgsBatchExecutor {
+	return ArgsBatchExecutor{
+		BuiltInFunctions: container,
+		Accounts:         adb,
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+	}
+}
+
+func TestNewBatchExecutor(t *testing.T) {
+	t.Parallel()
+
+	adb := inMemoryState.NewAccountsAdapter()
+	container := NewBuiltInFunctionContainer()
+
+	be, err := NewBatchExecutor(createBatchExecutorArgs(nil, adb))
+	assert.Nil(t, be)
+	assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+	be, err = NewBatchExecutor(createBatchExecutorArgs(container, nil))
+	assert.Nil(t, be)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	args := createBatchExecutorArgs(container, adb)
+	args.ShardCoordinator = nil
+	be, err = NewBatchExecutor(args)
+	assert.Nil(t, be)
+	assert.Equal(t, ErrNilShardCoordinator, err)
+
+	be, err = NewBatchExecutor(createBatchExecutorArgs(container, adb))
+	assert.Nil(t, err)
+	assert.False(t, be.IsInterfaceNil())
+}
+
+func TestBatchExecutor_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil input should error", func(t *testing.T) {
+		t.Parallel()
+
+		be, _ := NewBatchExecutor(createBatchExecutorArgs(NewBuiltInFunctionContainer(), inMemoryState.NewAccountsAdapter()))
+		batchResult, err := be.ProcessBatch([]*vmcommon.ContractCallInput{{}, nil})
+		assert.Nil(t, batchResult)
+		assert.ErrorIs(t, err, ErrNilVmInput)
+	})
+	t.Run("revert error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		adb := &mock.AccountsStub{
+			RevertToSnapshotCalled: func(_ int) error {
+				return expectedErr
+			},
+		}
+		be, _ := NewBatchExecutor(createBatchExecutorArgs(NewBuiltInFunctionContainer(), adb))
+		batchResult, err := be.ProcessBatch([]*vmcommon.ContractCallInput{{Function: "missing"}})
+		assert.Nil(t, batchResult)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+	t.Run("failed calls should be reverted", func(t *testing.T) {
+		t.Parallel()
+
+		alice := createSimulationAddress(1)
+		bob := createSimulationAddress(2)
+		carol := createSimulationAddress(3)
+		adb := inMemoryState.NewAccountsAdapter()
+		saveSimulationDCTBalance(t, adb, alice, 100)
+		b := createSimulationCreator(t, adb)
+		received := make([]*vmcommon.BuiltInLogEvent, 0)
+		err := b.BuiltInFunctionContainer().(vmcommon.AcceptLogSubscriber).RegisterLogSubscriber(&mock.LogSubscriberStub{
+			ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+				received = append(received, event)
+			},
+		})
+		require.Nil(t, err)
+
+		failingFunctionName := "failingFunction"
+		err = b.BuiltInFunctionContainer().Add(failingFunctionName, &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, Logs: []*vmcommon.LogEntry{{Identifier: []byte("reverted")}}}, nil
+			},
+		})
+		require.Nil(t, err)
+		failingCall := createSimulationTransferInput(carol, carol, 0)
+		failingCall.Function = failingFunctionName
+
+		vmInputs := []*vmcommon.ContractCallInput{
+			createSimulationTransferInput(alice, bob, 30),
+			createSimulationTransferInput(alice, bob, 100),
+			failingCall,
+			createSimulationTransferInput(bob, carol, 10),
+		}
+		be, _ := NewBatchExecutor(createBatchExecutorArgs(b.BuiltInFunctionContainer(), adb))
+		batchResult, err := be.ProcessBatch(vmInputs)
+		require.Nil(t, err)
+		require.Equal(t, 4, len(batchResult.Results))
+
+		assert.Nil(t, batchResult.Results[0].Err)
+		assert.ErrorIs(t, batchResult.Results[1].Err, ErrInsufficientFunds)
+		assert.ErrorIs(t, batchResult.Results[2].Err, ErrBuiltInFunctionFailed)
+		assert.Equal(t, vmcommon.UserError, batchResult.Results[2].VMOutput.ReturnCode)
+		assert.Nil(t, batchResult.Results[3].Err)
+
+		assert.Equal(t, 2, batchResult.NumSuccessful)
+		assert.Equal(t, 2, batchResult.NumFailed)
+		assert.Equal(t, uint64(400), batchResult.TotalGasProvided)
+		assert.Equal(t, uint64(2), batchResult.TotalGasConsumed)
+		assert.Equal(t, uint64(198), batchResult.TotalGasRemaining)
+		assert.Equal(t, 2, batchResult.NumLogs)
+		assert.Equal(t, map[string]int{core.BuiltInFunctionDCTTransfer: 2}, batchResult.NumLogsByIdentifier)
+
+		assert.Empty(t, received)
+		_, err = be.CommitBatch(nil, batchResult)
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+		assert.Empty(t, received)
+
+		_, err = be.CommitBatch(vmInputs, batchResult)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(received))
+		assert.Equal(t, alice, received[0].CallerAddr)
+		assert.Equal(t, bob, received[1].CallerAddr)
+		assert.Equal(t, core.BuiltInFunctionDCTTransfer, string(received[1].LogEntry.Identifier))
+
+		assert.Equal(t, int64(70), getSimulationDCTBalance(t, adb, alice))
+		assert.Equal(t, int64(20), getSimulationDCTBalance(t, adb, bob))
+		assert.Equal(t, int64(10), getSimulationDCTBalance(t, adb, carol))
+		carolAccount, _ := adb.LoadAccount(carol)
+		value, _, _ := carolAccount.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue([]byte("key"))
+		assert.Empty(t, value)
+	})
+}
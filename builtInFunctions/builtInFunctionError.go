@@ -0,0 +1,223 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// ErrorCode is a stable numeric identifier of a built-in function failure. The codes are never reused or
+// renumbered: a new error gets the next free code.
+type ErrorCode uint32
+
+// ErrorCodeUnknown is the code of the errors which are not defined by this package
+const ErrorCodeUnknown ErrorCode = 0
+
+type errorCodeEntry struct {
+	err        error
+	code       ErrorCode
+	returnCode vmcommon.ReturnCode
+}
+
+// errorCodes holds the code and the return code of every error defined by this package
+var errorCodes = []errorCodeEntry{
+	{ErrNilAccountsAdapter, 1, vmcommon.ExecutionFailed},
+	{ErrInsufficientFunds, 2, vmcommon.OutOfFunds},
+	{ErrNilValue, 3, vmcommon.UserError},
+	{ErrNilMarshalizer, 4, vmcommon.ExecutionFailed},
+	{ErrInvalidRcvAddr, 5, vmcommon.UserError},
+	{ErrNegativeValue, 6, vmcommon.UserError},
+	{ErrNilShardCoordinator, 7, vmcommon.ExecutionFailed},
+	{ErrWrongTypeAssertion, 8, vmcommon.ExecutionFailed},
+	{ErrNilSCDestAccount, 9, vmcommon.ContractNotFound},
+	{ErrNotEnoughGas, 10, vmcommon.OutOfGas},
+	{ErrInvalidArguments, 11, vmcommon.FunctionWrongSignature},
+	{ErrOperationNotPermitted, 12, vmcommon.UserError},
+	{ErrInvalidAddressLength, 13, vmcommon.UserError},
+	{ErrNilVmInput, 14, vmcommon.ExecutionFailed},
+	{ErrNilDnsAddresses, 15, vmcommon.ExecutionFailed},
+	{ErrCallerIsNotTheDNSAddress, 16, vmcommon.UserError},
+	{ErrUserNameChangeIsDisabled, 17, vmcommon.UserError},
+	{ErrBuiltInFunctionCalledWithValue, 18, vmcommon.UserError},
+	{ErrAccountNotPayable, 19, vmcommon.UserError},
+	{ErrNilUserAccount, 20, vmcommon.UserError},
+	{ErrAddressIsNotDCTSystemSC, 21, vmcommon.UserError},
+	{ErrOnlySystemAccountAccepted, 22, vmcommon.UserError},
+	{ErrNilGlobalSettingsHandler, 23, vmcommon.ExecutionFailed},
+	{ErrNilRolesHandler, 24, vmcommon.ExecutionFailed},
+	{ErrDCTTokenIsPaused, 25, vmcommon.UserError},
+	{ErrDCTIsFrozenForAccount, 26, vmcommon.UserError},
+	{ErrCannotWipeAccountNotFrozen, 27, vmcommon.UserError},
+	{ErrNilPayableHandler, 28, vmcommon.ExecutionFailed},
+	{ErrActionNotAllowed, 29, vmcommon.UserError},
+	{ErrOnlyFungibleTokensHaveBalanceTransfer, 30, vmcommon.UserError},
+	{ErrNFTTokenDoesNotExist, 31, vmcommon.UserError},
+	{ErrNFTDoesNotHaveMetadata, 32, vmcommon.UserError},
+	{ErrInvalidNFTQuantity, 33, vmcommon.UserError},
+	{ErrNewNFTDataOnSenderAddress, 34, vmcommon.UserError},
+	{ErrNilContainerElement, 35, vmcommon.ExecutionFailed},
+	{ErrInvalidContainerKey, 36, vmcommon.FunctionNotFound},
+	{ErrContainerKeyAlreadyExists, 37, vmcommon.ExecutionFailed},
+	{ErrWrongTypeInContainer, 38, vmcommon.ExecutionFailed},
+	{ErrEmptyFunctionName, 39, vmcommon.FunctionNotFound},
+	{ErrInsufficientQuantityDCT, 40, vmcommon.OutOfFunds},
+	{ErrNilDCTNFTStorageHandler, 41, vmcommon.ExecutionFailed},
+	{ErrNilTransactionHandler, 42, vmcommon.ExecutionFailed},
+	{ErrAddressIsNotAllowed, 43, vmcommon.UserError},
+	{ErrInvalidNumOfArgs, 44, vmcommon.FunctionWrongSignature},
+	{ErrInvalidNonce, 45, vmcommon.UserError},
+	{ErrTokenHasValidMetadata, 46, vmcommon.UserError},
+	{ErrInvalidTokenID, 47, vmcommon.UserError},
+	{ErrNilDCTData, 48, vmcommon.UserError},
+	{ErrInvalidMetadata, 49, vmcommon.UserError},
+	{ErrInvalidLiquidityForDCT, 50, vmcommon.UserError},
+	{ErrTooManyTransferAddresses, 51, vmcommon.UserError},
+	{ErrInvalidMaxNumAddresses, 52, vmcommon.ExecutionFailed},
+	{ErrNilEnableEpochsHandler, 53, vmcommon.ExecutionFailed},
+	{ErrNilActiveHandler, 54, vmcommon.ExecutionFailed},
+	{ErrInvalidNumberOfArguments, 55, vmcommon.FunctionWrongSignature},
+	{ErrInvalidAddress, 56, vmcommon.UserError},
+	{ErrCannotSetOwnAddressAsGuardian, 57, vmcommon.UserError},
+	{ErrOwnerAlreadyHasOneGuardianPending, 58, vmcommon.UserError},
+	{ErrGuardianAlreadyExists, 59, vmcommon.UserError},
+	{ErrNoGuardianEnabled, 60, vmcommon.UserError},
+	{ErrSetGuardAccountFlag, 61, vmcommon.UserError},
+	{ErrSetUnGuardAccount, 62, vmcommon.UserError},
+	{ErrNilAccountHandler, 63, vmcommon.ExecutionFailed},
+	{ErrNilGuardedAccountHandler, 64, vmcommon.ExecutionFailed},
+	{ErrInvalidServiceUID, 65, vmcommon.UserError},
+	{ErrCannotMigrateNilUserName, 66, vmcommon.UserError},
+	{ErrWrongUserNameSplit, 67, vmcommon.UserError},
+	{ErrUserNamePrefixNotEqual, 68, vmcommon.UserError},
+	{ErrOperationNotSupportedInSimulation, 69, vmcommon.SimulateFailed},
+	{ErrNilGasTracer, 70, vmcommon.ExecutionFailed},
+	{ErrInvalidGasSchedule, 71, vmcommon.ExecutionFailed},
+	{ErrNilBuiltInFunctionConstructor, 72, vmcommon.ExecutionFailed},
+	{ErrMissingGasCost, 73, vmcommon.ExecutionFailed},
+	{ErrNoBuiltInFunctionVersion, 74, vmcommon.FunctionNotFound},
+	{ErrDuplicateBuiltInFunctionVersion, 75, vmcommon.ExecutionFailed},
+	{ErrNilEpochNotifier, 76, vmcommon.ExecutionFailed},
+	{ErrAccessSetNotDeclared, 77, vmcommon.ExecutionFailed},
+	{ErrNilBuiltInFunctionContainer, 78, vmcommon.ExecutionFailed},
+	{ErrBuiltInFunctionFailed, 79, vmcommon.ExecutionFailed},
+	{ErrNilLogSubscriber, 80, vmcommon.ExecutionFailed},
+}
+
+var _ vmcommon.ReturnCodeHandler = (*BuiltInFunctionError)(nil)
+
+// BuiltInFunctionError is a built-in function failure carrying a stable code, the return code to report, the
+// called function and the indices of the offending arguments. It wraps the original error, so errors.Is keeps
+// matching the errors defined by this package.
+type BuiltInFunctionError struct {
+	Code            ErrorCode
+	ReturnCode      vmcommon.ReturnCode
+	Function        string
+	ArgumentIndices []int
+	err             error
+}
+
+// NewBuiltInFunctionError wraps the error of the called function. The code and the return code are those of the
+// first error defined by this package found in the chain of err, or ErrorCodeUnknown and ExecutionFailed. If err
+// already is a BuiltInFunctionError, its code is kept and the function and argument indices are only set if
+// missing.
+func NewBuiltInFunctionError(function string, err error, argumentIndices ...int) *BuiltInFunctionError {
+	var builtInFunctionError *BuiltInFunctionError
+	if errors.As(err, &builtInFunctionError) {
+		wrapped := *builtInFunctionError
+		wrapped.err = err
+		if len(wrapped.Function) == 0 {
+			wrapped.Function = function
+		}
+		if len(wrapped.ArgumentIndices) == 0 {
+			wrapped.ArgumentIndices = argumentIndices
+		}
+
+		return &wrapped
+	}
+
+	code, returnCode := codesOf(err)
+
+	return &BuiltInFunctionError{
+		Code:            code,
+		ReturnCode:      returnCode,
+		Function:        function,
+		ArgumentIndices: argumentIndices,
+		err:             err,
+	}
+}
+
+func newArgumentError(function string, err error, argumentIndices ...int) error {
+	return NewBuiltInFunctionError(function, err, argumentIndices...)
+}
+
+// ErrorCodeOf returns the code of the first error defined by this package found in the chain of err
+func ErrorCodeOf(err error) ErrorCode {
+	var builtInFunctionError *BuiltInFunctionError
+	if errors.As(err, &builtInFunctionError) {
+		return builtInFunctionError.Code
+	}
+
+	code, _ := codesOf(err)
+	return code
+}
+
+// codesOf walks the chain of err depth-first, outermost error first, and returns the codes of the first link
+// defined by this package
+func codesOf(err error) (ErrorCode, vmcommon.ReturnCode) {
+	if err == nil {
+		return ErrorCodeUnknown, vmcommon.ExecutionFailed
+	}
+
+	for _, entry := range errorCodes {
+		if err == entry.err {
+			return entry.code, entry.returnCode
+		}
+	}
+
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		return codesOf(wrapper.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			code, returnCode := codesOf(wrapped)
+			if code != ErrorCodeUnknown {
+				return code, returnCode
+			}
+		}
+	}
+
+	return ErrorCodeUnknown, vmcommon.ExecutionFailed
+}
+
+// Error returns the message of the wrapped error
+func (e *BuiltInFunctionError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("built-in function error, code %d", e.Code)
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *BuiltInFunctionError) Unwrap() error {
+	return e.err
+}
+
+// GetReturnCode returns the return code to report for the error
+func (e *BuiltInFunctionError) GetReturnCode() vmcommon.ReturnCode {
+	return e.ReturnCode
+}
+
+// Details returns the message together with the code, the function and the offending arguments
+func (e *BuiltInFunctionError) Details() string {
+	details := fmt.Sprintf("%s (code %d, %s", e.Error(), e.Code, e.ReturnCode)
+	if len(e.Function) > 0 {
+		details += fmt.Sprintf(", function %s", e.Function)
+	}
+	if len(e.ArgumentIndices) > 0 {
+		details += fmt.Sprintf(", arguments %v", e.ArgumentIndices)
+	}
+
+	return details + ")"
+}
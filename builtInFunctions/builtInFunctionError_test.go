@@ -0,0 +1,316 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func TestErrorCodes_ShouldBeUnique(t *testing.T) {
+	t.Parallel()
+
+	codes := make(map[ErrorCode]error)
+	errs := make(map[error]struct{})
+	for _, entry := range errorCodes {
+		assert.NotEqual(t, ErrorCodeUnknown, entry.code, entry.err.Error())
+		previous, found := codes[entry.code]
+		assert.False(t, found, "code %d used by %v and %v", entry.code, previous, entry.err)
+		codes[entry.code] = entry.err
+
+		_, found = errs[entry.err]
+		assert.False(t, found, entry.err.Error())
+		errs[entry.err] = struct{}{}
+	}
+}
+
+func TestNewBuiltInFunctionError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known error should get its codes", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewBuiltInFunctionError(core.BuiltInFunctionDCTTransfer, ErrNotEnoughGas)
+		assert.Equal(t, ErrorCode(10), err.Code)
+		assert.Equal(t, vmcommon.OutOfGas, err.ReturnCode)
+		assert.Equal(t, core.BuiltInFunctionDCTTransfer, err.Function)
+		assert.Empty(t, err.ArgumentIndices)
+		assert.Equal(t, ErrNotEnoughGas.Error(), err.Error())
+		assert.True(t, errors.Is(err, ErrNotEnoughGas))
+	})
+	t.Run("wrapped error should keep the message", func(t *testing.T) {
+		t.Parallel()
+
+		wrappedErr := fmt.Errorf("%w for token %s", ErrInsufficientFunds, "TKN-abcdef")
+		err := NewBuiltInFunctionError(core.BuiltInFunctionMultiDCTNFTTransfer, wrappedErr, 2, 3, 4)
+		assert.Equal(t, ErrorCode(2), err.Code)
+		assert.Equal(t, vmcommon.OutOfFunds, err.ReturnCode)
+		assert.Equal(t, []int{2, 3, 4}, err.ArgumentIndices)
+		assert.Equal(t, wrappedErr.Error(), err.Error())
+		assert.True(t, errors.Is(err, ErrInsufficientFunds))
+		assert.Equal(t, "insufficient funds for token TKN-abcdef (code 2, out of funds, function MultiDCTNFTTransfer, arguments [2 3 4])", err.Details())
+	})
+	t.Run("unknown error should get the unknown code", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		err := NewBuiltInFunctionError("function", expectedErr)
+		assert.Equal(t, ErrorCodeUnknown, err.Code)
+		assert.Equal(t, vmcommon.ExecutionFailed, err.ReturnCode)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+	t.Run("built-in function error should keep its code and arguments", func(t *testing.T) {
+		t.Parallel()
+
+		innerErr := NewBuiltInFunctionError("", ErrNegativeValue, 1)
+		err := NewBuiltInFunctionError("outer", fmt.Errorf("%w and %s", innerErr, ErrInvalidArguments.Error()), 5)
+		assert.Equal(t, ErrorCode(6), err.Code)
+		assert.Equal(t, "outer", err.Function)
+		assert.Equal(t, []int{1}, err.ArgumentIndices)
+		assert.True(t, errors.Is(err, ErrNegativeValue))
+		assert.Equal(t, ErrorCode(6), ErrorCodeOf(err))
+	})
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ErrorCodeUnknown, ErrorCodeOf(nil))
+	assert.Equal(t, ErrorCodeUnknown, ErrorCodeOf(errors.New("unknown")))
+	assert.Equal(t, ErrorCode(40), ErrorCodeOf(fmt.Errorf("%w: wrapped", ErrInsufficientQuantityDCT)))
+	assert.Equal(t, ErrorCode(79), ErrorCodeOf(ErrBuiltInFunctionFailed))
+	assert.Equal(t, ErrorCode(40), ErrorCodeOf(fmt.Errorf("%w", errors.Join(ErrInsufficientQuantityDCT, ErrNilValue))))
+	assert.Equal(t, ErrorCode(3), ErrorCodeOf(fmt.Errorf("%w: %w", ErrNilValue, ErrInsufficientQuantityDCT)))
+}
+
+func TestDCTTransfer_ShouldReturnBuiltInFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	transferFunc, _ := NewDCTTransferFunc(
+		10,
+		&mock.MarshalizerMock{},
+		&mock.GlobalSettingsHandlerStub{},
+		&mock.ShardCoordinatorStub{},
+		&mock.DCTRoleHandlerStub{},
+		&mock.EnableEpochsHandlerStub{},
+	)
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("TKN-abcdef"), big.NewInt(0).Bytes()},
+		},
+	}
+
+	_, err := transferFunc.ProcessBuiltinFunction(nil, nil, input)
+	var builtInFunctionError *BuiltInFunctionError
+	require.True(t, errors.As(err, &builtInFunctionError))
+	assert.True(t, errors.Is(err, ErrNegativeValue))
+	assert.Equal(t, core.BuiltInFunctionDCTTransfer, builtInFunctionError.Function)
+	assert.Equal(t, []int{1}, builtInFunctionError.ArgumentIndices)
+	assert.Equal(t, vmcommon.UserError, builtInFunctionError.ReturnCode)
+}
+
+func TestBuiltInFunctions_ShouldReturnArgumentErrors(t *testing.T) {
+	t.Parallel()
+
+	caller := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+	shortAddress := []byte("short")
+	tokenID := []byte("TKN-abcdef")
+	createInput := func(function string, sender []byte, recipient []byte, arguments ...[]byte) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  sender,
+				GasProvided: 1000,
+				CallValue:   big.NewInt(0),
+				Arguments:   arguments,
+			},
+			RecipientAddr: recipient,
+			Function:      function,
+		}
+	}
+	freeze, _ := NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, &mock.MarshalizerMock{}, true, false)
+
+	tests := []struct {
+		name            string
+		function        vmcommon.BuiltinFunction
+		acntSnd         vmcommon.UserAccountHandler
+		acntDst         vmcommon.UserAccountHandler
+		vmInput         *vmcommon.ContractCallInput
+		expectedErr     error
+		expectedIndices []int
+	}{
+		{
+			name:            "nft transfer to an invalid destination",
+			function:        createNftTransferWithStubArguments(),
+			acntSnd:         mock.NewUserAccount(caller),
+			vmInput:         createInput(core.BuiltInFunctionDCTNFTTransfer, caller, caller, tokenID, []byte{1}, []byte{1}, shortAddress),
+			expectedErr:     ErrInvalidArguments,
+			expectedIndices: []int{3},
+		},
+		{
+			name:            "multi transfer to an invalid destination",
+			function:        createDCTNFTMultiTransferWithStubArguments(),
+			acntSnd:         mock.NewUserAccount(caller),
+			vmInput:         createInput(core.BuiltInFunctionMultiDCTNFTTransfer, caller, caller, shortAddress, []byte{1}, tokenID, []byte{0}, []byte{1}),
+			expectedErr:     ErrInvalidArguments,
+			expectedIndices: []int{0},
+		},
+		{
+			name:            "multi transfer without tokens",
+			function:        createDCTNFTMultiTransferWithStubArguments(),
+			acntDst:         mock.NewUserAccount(receiver),
+			vmInput:         createInput(core.BuiltInFunctionMultiDCTNFTTransfer, caller, receiver, []byte{0}, tokenID, []byte{0}, []byte{1}),
+			expectedErr:     ErrInvalidArguments,
+			expectedIndices: []int{0},
+		},
+		{
+			name:            "nft create with invalid royalties",
+			function:        createNftCreateWithStubArguments(),
+			acntSnd:         mock.NewUserAccount(caller),
+			vmInput:         createInput(core.BuiltInFunctionDCTNFTCreate, caller, caller, tokenID, []byte{1}, []byte("name"), big.NewInt(int64(core.MaxRoyalty)+1).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri")),
+			expectedErr:     ErrInvalidArguments,
+			expectedIndices: []int{3},
+		},
+		{
+			name:        "freeze with too many arguments",
+			function:    freeze,
+			acntDst:     mock.NewUserAccount(receiver),
+			vmInput:     createInput(core.BuiltInFunctionDCTFreeze, core.DCTSCAddress, receiver, tokenID, tokenID),
+			expectedErr: ErrInvalidArguments,
+		},
+		{
+			name:            "nft create role transfer to an invalid address",
+			function:        createDCTNFTCreateRoleTransferComponent(t),
+			acntDst:         mock.NewUserAccount(receiver),
+			vmInput:         createInput(core.BuiltInFunctionDCTNFTCreateRoleTransfer, core.DCTSCAddress, receiver, tokenID, shortAddress),
+			expectedErr:     ErrInvalidArguments,
+			expectedIndices: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.function.ProcessBuiltinFunction(tt.acntSnd, tt.acntDst, tt.vmInput)
+			var builtInFunctionError *BuiltInFunctionError
+			require.True(t, errors.As(err, &builtInFunctionError))
+			assert.True(t, errors.Is(err, tt.expectedErr))
+			assert.Equal(t, tt.vmInput.Function, builtInFunctionError.Function)
+			assert.Equal(t, tt.expectedIndices, builtInFunctionError.ArgumentIndices)
+		})
+	}
+}
+
+func TestNewFailureVMOutput_ShouldPinTheCodeOfEachError(t *testing.T) {
+	t.Parallel()
+
+	// the codes are part of the API: changing one of them breaks the agreement between nodes and simulators
+	expected := []struct {
+		err        error
+		code       ErrorCode
+		returnCode vmcommon.ReturnCode
+	}{
+		{ErrNilAccountsAdapter, 1, vmcommon.ExecutionFailed},
+		{ErrInsufficientFunds, 2, vmcommon.OutOfFunds},
+		{ErrNilValue, 3, vmcommon.UserError},
+		{ErrNilMarshalizer, 4, vmcommon.ExecutionFailed},
+		{ErrInvalidRcvAddr, 5, vmcommon.UserError},
+		{ErrNegativeValue, 6, vmcommon.UserError},
+		{ErrNilShardCoordinator, 7, vmcommon.ExecutionFailed},
+		{ErrWrongTypeAssertion, 8, vmcommon.ExecutionFailed},
+		{ErrNilSCDestAccount, 9, vmcommon.ContractNotFound},
+		{ErrNotEnoughGas, 10, vmcommon.OutOfGas},
+		{ErrInvalidArguments, 11, vmcommon.FunctionWrongSignature},
+		{ErrOperationNotPermitted, 12, vmcommon.UserError},
+		{ErrInvalidAddressLength, 13, vmcommon.UserError},
+		{ErrNilVmInput, 14, vmcommon.ExecutionFailed},
+		{ErrNilDnsAddresses, 15, vmcommon.ExecutionFailed},
+		{ErrCallerIsNotTheDNSAddress, 16, vmcommon.UserError},
+		{ErrUserNameChangeIsDisabled, 17, vmcommon.UserError},
+		{ErrBuiltInFunctionCalledWithValue, 18, vmcommon.UserError},
+		{ErrAccountNotPayable, 19, vmcommon.UserError},
+		{ErrNilUserAccount, 20, vmcommon.UserError},
+		{ErrAddressIsNotDCTSystemSC, 21, vmcommon.UserError},
+		{ErrOnlySystemAccountAccepted, 22, vmcommon.UserError},
+		{ErrNilGlobalSettingsHandler, 23, vmcommon.ExecutionFailed},
+		{ErrNilRolesHandler, 24, vmcommon.ExecutionFailed},
+		{ErrDCTTokenIsPaused, 25, vmcommon.UserError},
+		{ErrDCTIsFrozenForAccount, 26, vmcommon.UserError},
+		{ErrCannotWipeAccountNotFrozen, 27, vmcommon.UserError},
+		{ErrNilPayableHandler, 28, vmcommon.ExecutionFailed},
+		{ErrActionNotAllowed, 29, vmcommon.UserError},
+		{ErrOnlyFungibleTokensHaveBalanceTransfer, 30, vmcommon.UserError},
+		{ErrNFTTokenDoesNotExist, 31, vmcommon.UserError},
+		{ErrNFTDoesNotHaveMetadata, 32, vmcommon.UserError},
+		{ErrInvalidNFTQuantity, 33, vmcommon.UserError},
+		{ErrNewNFTDataOnSenderAddress, 34, vmcommon.UserError},
+		{ErrNilContainerElement, 35, vmcommon.ExecutionFailed},
+		{ErrInvalidContainerKey, 36, vmcommon.FunctionNotFound},
+		{ErrContainerKeyAlreadyExists, 37, vmcommon.ExecutionFailed},
+		{ErrWrongTypeInContainer, 38, vmcommon.ExecutionFailed},
+		{ErrEmptyFunctionName, 39, vmcommon.FunctionNotFound},
+		{ErrInsufficientQuantityDCT, 40, vmcommon.OutOfFunds},
+		{ErrNilDCTNFTStorageHandler, 41, vmcommon.ExecutionFailed},
+		{ErrNilTransactionHandler, 42, vmcommon.ExecutionFailed},
+		{ErrAddressIsNotAllowed, 43, vmcommon.UserError},
+		{ErrInvalidNumOfArgs, 44, vmcommon.FunctionWrongSignature},
+		{ErrInvalidNonce, 45, vmcommon.UserError},
+		{ErrTokenHasValidMetadata, 46, vmcommon.UserError},
+		{ErrInvalidTokenID, 47, vmcommon.UserError},
+		{ErrNilDCTData, 48, vmcommon.UserError},
+		{ErrInvalidMetadata, 49, vmcommon.UserError},
+		{ErrInvalidLiquidityForDCT, 50, vmcommon.UserError},
+		{ErrTooManyTransferAddresses, 51, vmcommon.UserError},
+		{ErrInvalidMaxNumAddresses, 52, vmcommon.ExecutionFailed},
+		{ErrNilEnableEpochsHandler, 53, vmcommon.ExecutionFailed},
+		{ErrNilActiveHandler, 54, vmcommon.ExecutionFailed},
+		{ErrInvalidNumberOfArguments, 55, vmcommon.FunctionWrongSignature},
+		{ErrInvalidAddress, 56, vmcommon.UserError},
+		{ErrCannotSetOwnAddressAsGuardian, 57, vmcommon.UserError},
+		{ErrOwnerAlreadyHasOneGuardianPending, 58, vmcommon.UserError},
+		{ErrGuardianAlreadyExists, 59, vmcommon.UserError},
+		{ErrNoGuardianEnabled, 60, vmcommon.UserError},
+		{ErrSetGuardAccountFlag, 61, vmcommon.UserError},
+		{ErrSetUnGuardAccount, 62, vmcommon.UserError},
+		{ErrNilAccountHandler, 63, vmcommon.ExecutionFailed},
+		{ErrNilGuardedAccountHandler, 64, vmcommon.ExecutionFailed},
+		{ErrInvalidServiceUID, 65, vmcommon.UserError},
+		{ErrCannotMigrateNilUserName, 66, vmcommon.UserError},
+		{ErrWrongUserNameSplit, 67, vmcommon.UserError},
+		{ErrUserNamePrefixNotEqual, 68, vmcommon.UserError},
+		{ErrOperationNotSupportedInSimulation, 69, vmcommon.SimulateFailed},
+		{ErrNilGasTracer, 70, vmcommon.ExecutionFailed},
+		{ErrInvalidGasSchedule, 71, vmcommon.ExecutionFailed},
+		{ErrNilBuiltInFunctionConstructor, 72, vmcommon.ExecutionFailed},
+		{ErrMissingGasCost, 73, vmcommon.ExecutionFailed},
+		{ErrNoBuiltInFunctionVersion, 74, vmcommon.FunctionNotFound},
+		{ErrDuplicateBuiltInFunctionVersion, 75, vmcommon.ExecutionFailed},
+		{ErrNilEpochNotifier, 76, vmcommon.ExecutionFailed},
+		{ErrAccessSetNotDeclared, 77, vmcommon.ExecutionFailed},
+		{ErrNilBuiltInFunctionContainer, 78, vmcommon.ExecutionFailed},
+		{ErrBuiltInFunctionFailed, 79, vmcommon.ExecutionFailed},
+		{ErrNilLogSubscriber, 80, vmcommon.ExecutionFailed},
+	}
+	require.Equal(t, len(expected), len(errorCodes))
+
+	for _, testCase := range expected {
+		err := fmt.Errorf("%w: wrapped", testCase.err)
+		assert.Equal(t, testCase.code, ErrorCodeOf(err), testCase.err.Error())
+
+		vmOutput := vmcommon.NewFailureVMOutput("function", NewBuiltInFunctionError("function", err))
+		assert.Equal(t, testCase.returnCode, vmOutput.ReturnCode, testCase.err.Error())
+		assert.Equal(t, "function: "+err.Error(), vmOutput.ReturnMessage)
+		assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	}
+}
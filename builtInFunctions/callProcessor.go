@@ -0,0 +1,145 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// callProcessor processes one built-in function call the way the host does: it loads the sender and the
+// destination if they are in the self shard, runs the built-in function and saves the accounts on success
+type callProcessor struct {
+	builtInFunctions vmcommon.BuiltInFunctionContainer
+	accounts         vmcommon.AccountsAdapter
+	shardCoordinator vmcommon.Coordinator
+}
+
+func newCallProcessor(
+	builtInFunctions vmcommon.BuiltInFunctionContainer,
+	accounts vmcommon.AccountsAdapter,
+	shardCoordinator vmcommon.Coordinator,
+) (*callProcessor, error) {
+	if check.IfNil(builtInFunctions) {
+		return nil, ErrNilBuiltInFunctionContainer
+	}
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(shardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+
+	return &callProcessor{
+		builtInFunctions: builtInFunctions,
+		accounts:         accounts,
+		shardCoordinator: shardCoordinator,
+	}, nil
+}
+
+func (cp *callProcessor) processCall(vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	function, err := cp.builtInFunctions.Get(vmInput.Function)
+	if err != nil {
+		return nil, NewBuiltInFunctionError(vmInput.Function, err)
+	}
+
+	acntSnd, err := cp.loadAccountIfInShard(vmInput.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+	acntDst := acntSnd
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		acntDst, err = cp.loadAccountIfInShard(vmInput.RecipientAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput, err := cp.processFunction(function, acntSnd, acntDst, vmInput)
+	if err != nil {
+		return nil, NewBuiltInFunctionError(vmInput.Function, err)
+	}
+
+	for _, account := range []vmcommon.UserAccountHandler{acntSnd, acntDst} {
+		if check.IfNil(account) {
+			continue
+		}
+
+		err = cp.accounts.SaveAccount(account)
+		if err != nil {
+			return nil, err
+		}
+		if acntSnd == acntDst {
+			break
+		}
+	}
+
+	return vmOutput, nil
+}
+
+// processFunction runs the function through the container if the container processes the calls itself. The logs
+// are not published, as the call may still be reverted.
+func (cp *callProcessor) processFunction(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	deferredLogsProcessor, ok := cp.builtInFunctions.(vmcommon.DeferredLogsProcessor)
+	if ok {
+		return deferredLogsProcessor.ProcessBuiltinFunctionWithoutLogs(function, acntSnd, acntDst, vmInput)
+	}
+
+	processor, ok := cp.builtInFunctions.(vmcommon.BuiltInFunctionProcessor)
+	if ok {
+		return processor.ProcessBuiltinFunction(function, acntSnd, acntDst, vmInput)
+	}
+
+	return function.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+}
+
+// commit commits the accounts adapter and then sends the logs of the kept calls to the log subscribers of the
+// container, in the order of the calls. A nil output marks a call which was not kept.
+func (cp *callProcessor) commit(vmInputs []*vmcommon.ContractCallInput, vmOutputs []*vmcommon.VMOutput) ([]byte, error) {
+	if len(vmInputs) != len(vmOutputs) {
+		return nil, fmt.Errorf("%w, %d inputs and %d results", ErrInvalidArguments, len(vmInputs), len(vmOutputs))
+	}
+
+	rootHash, err := cp.accounts.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, ok := cp.builtInFunctions.(vmcommon.LogPublisher)
+	if !ok {
+		return rootHash, nil
+	}
+
+	for i, vmOutput := range vmOutputs {
+		if vmOutput == nil || vmOutput.ReturnCode != vmcommon.Ok {
+			continue
+		}
+
+		publisher.PublishLogs(vmInputs[i], vmOutput)
+	}
+
+	return rootHash, nil
+}
+
+func (cp *callProcessor) loadAccountIfInShard(address []byte) (vmcommon.UserAccountHandler, error) {
+	if len(address) == 0 || cp.shardCoordinator.ComputeId(address) != cp.shardCoordinator.SelfId() {
+		return nil, nil
+	}
+
+	account, err := cp.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
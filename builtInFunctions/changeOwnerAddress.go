@@ -12,6 +12,8 @@ import (
 
 type changeOwnerAddress struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	gasCost      uint64
 	mutExecution sync.RWMutex
 
@@ -25,6 +27,9 @@ func NewChangeOwnerAddressFunc(gasCost uint64, enableEpochsHandler vmcommon.Enab
 	}
 
 	return &changeOwnerAddress{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.ChangeOwnerAddress
+		}),
 		gasCost:             gasCost,
 		enableEpochsHandler: enableEpochsHandler,
 	}, nil
@@ -49,6 +54,8 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	c.mutExecution.RLock()
 	defer c.mutExecution.RUnlock()
 
+	funcGasCost := c.activeFuncGasCost(c.gasCost)
+
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
@@ -61,15 +68,17 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	if len(vmInput.Arguments[0]) != len(vmInput.CallerAddr) {
 		return nil, ErrInvalidAddressLength
 	}
-	if vmInput.GasProvided < c.gasCost {
+	if vmInput.GasProvided < funcGasCost {
 		return nil, ErrNotEnoughGas
 	}
-	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, c.gasCost)
+	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, funcGasCost)
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasRemaining}
+	gasCharge := newBuiltInCostCharge("ChangeOwnerAddress", funcGasCost)
 
 	if check.IfNil(acntDst) {
 		c.addOutputTransferToVmOutputForCallThroughSC(acntDst, vmInput, vmOutput)
+		c.traceSenderGas(acntSnd, vmInput, vmOutput, gasCharge)
 		return vmOutput, nil
 	}
 
@@ -89,6 +98,7 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	}
 	vmOutput.Logs = make([]*vmcommon.LogEntry, 0, 1)
 	vmOutput.Logs = append(vmOutput.Logs, logEntry)
+	c.traceSenderGas(acntSnd, vmInput, vmOutput, gasCharge)
 
 	return vmOutput, nil
 }
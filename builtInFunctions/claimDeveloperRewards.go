@@ -12,13 +12,20 @@ import (
 
 type claimDeveloperRewards struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	gasCost      uint64
 	mutExecution sync.RWMutex
 }
 
 // NewClaimDeveloperRewardsFunc returns a new developer rewards implementation
 func NewClaimDeveloperRewardsFunc(gasCost uint64) *claimDeveloperRewards {
-	return &claimDeveloperRewards{gasCost: gasCost}
+	return &claimDeveloperRewards{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.ClaimDeveloperRewards
+		}),
+		gasCost: gasCost,
+	}
 }
 
 // SetNewGasConfig is called whenever gas cost is changed
@@ -40,22 +47,26 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	c.mutExecution.RLock()
 	defer c.mutExecution.RUnlock()
 
+	funcGasCost := c.activeFuncGasCost(c.gasCost)
+
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
 	if vmInput.CallValue.Cmp(zero) != 0 {
 		return nil, ErrBuiltInFunctionCalledWithValue
 	}
-	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, c.gasCost)
+	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, funcGasCost)
 	if check.IfNil(acntDst) {
 		// cross-shard call, in sender shard only the gas is taken out
-		return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasRemaining}, nil
+		vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasRemaining}
+		c.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("ClaimDeveloperRewards", funcGasCost))
+		return vmOutput, nil
 	}
 
 	if !bytes.Equal(vmInput.CallerAddr, acntDst.GetOwnerAddress()) {
 		return nil, ErrOperationNotPermitted
 	}
-	if vmInput.GasProvided < c.gasCost {
+	if vmInput.GasProvided < funcGasCost {
 		return nil, ErrNotEnoughGas
 	}
 
@@ -100,6 +111,7 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
 		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	}
+	c.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("ClaimDeveloperRewards", funcGasCost))
 
 	return vmOutput, nil
 }
@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/subrahamanyam341/andes-core-16/core/check"
 	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
@@ -9,10 +10,19 @@ import (
 )
 
 var _ vmcommon.BuiltInFunctionContainer = (*functionContainer)(nil)
+var _ vmcommon.AcceptLogSubscriber = (*functionContainer)(nil)
+var _ vmcommon.LogPublisher = (*functionContainer)(nil)
+var _ vmcommon.BuiltInFunctionProcessor = (*functionContainer)(nil)
+var _ vmcommon.DeferredLogsProcessor = (*functionContainer)(nil)
 
 // functionContainer is an interceptors holder organized by type
 type functionContainer struct {
 	objects *container.MutexMap
+
+	mutLogSubscribers sync.RWMutex
+	logSubscribers    []vmcommon.LogSubscriber
+
+	recordingLanes *recordingLanes
 }
 
 // NewBuiltInFunctionContainer will create a new instance of a container
@@ -38,6 +48,88 @@ func (f *functionContainer) Get(key string) (vmcommon.BuiltinFunction, error) {
 	return function, nil
 }
 
+// ProcessBuiltinFunction runs the function held by the container and publishes the logs of a successful call to the
+// log subscribers. If the container was created to record the state diff, the call goes through the state recorder
+// and the output carries the state diff.
+func (f *functionContainer) ProcessBuiltinFunction(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := f.ProcessBuiltinFunctionWithoutLogs(function, acntSnd, acntDst, vmInput)
+	if err != nil {
+		return nil, err
+	}
+
+	f.PublishLogs(vmInput, vmOutput)
+
+	return vmOutput, nil
+}
+
+// ProcessBuiltinFunctionWithoutLogs runs the function held by the container the same way ProcessBuiltinFunction
+// does, but does not publish the logs. The caller publishes them through PublishLogs if it keeps the call.
+func (f *functionContainer) ProcessBuiltinFunctionWithoutLogs(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if check.IfNil(function) {
+		return nil, ErrNilContainerElement
+	}
+	if f.recordingLanes == nil {
+		return function.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+	}
+
+	return f.recordingLanes.processBuiltinFunction(function, acntSnd, acntDst, vmInput)
+}
+
+// setRecordingLanes sets the lanes the calls are recorded on. It must be called before the container is used.
+func (f *functionContainer) setRecordingLanes(lanes *recordingLanes) {
+	f.recordingLanes = lanes
+}
+
+// RegisterLogSubscriber adds a subscriber which will receive the logs sent through PublishLogs
+func (f *functionContainer) RegisterLogSubscriber(subscriber vmcommon.LogSubscriber) error {
+	if check.IfNil(subscriber) {
+		return ErrNilLogSubscriber
+	}
+
+	f.mutLogSubscribers.Lock()
+	f.logSubscribers = append(f.logSubscribers, subscriber)
+	f.mutLogSubscribers.Unlock()
+
+	return nil
+}
+
+// PublishLogs sends the logs of a successful call to the registered subscribers. It is called by
+// ProcessBuiltinFunction, or by the host after it kept a call processed through ProcessBuiltinFunctionWithoutLogs.
+func (f *functionContainer) PublishLogs(vmInput *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	if vmInput == nil || vmOutput == nil || vmOutput.ReturnCode != vmcommon.Ok {
+		return
+	}
+
+	f.mutLogSubscribers.RLock()
+	subscribers := f.logSubscribers
+	f.mutLogSubscribers.RUnlock()
+
+	for _, logEntry := range vmOutput.Logs {
+		if logEntry == nil {
+			continue
+		}
+
+		event := &vmcommon.BuiltInLogEvent{
+			OriginalTxHash: vmInput.OriginalTxHash,
+			CurrentTxHash:  vmInput.CurrentTxHash,
+			CallerAddr:     vmInput.CallerAddr,
+			Function:       vmInput.Function,
+			LogEntry:       logEntry,
+		}
+		for _, subscriber := range subscribers {
+			subscriber.ReceiveLog(event)
+		}
+	}
+}
+
 // Add will add an object at a given key. Returns
 // an error if the element already exists
 func (f *functionContainer) Add(key string, function vmcommon.BuiltinFunction) error {
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
 	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
 )
 
@@ -156,3 +158,151 @@ func TestBuiltInFunctionContainer_LenShouldWork(t *testing.T) {
 	c.Remove("key1")
 	assert.Equal(t, 1, c.Len())
 }
+
+//------- RegisterLogSubscriber
+
+func TestBuiltInFunctionContainer_RegisterLogSubscriberNilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+
+	err := c.RegisterLogSubscriber(nil)
+
+	assert.Equal(t, ErrNilLogSubscriber, err)
+}
+
+func TestBuiltInFunctionContainer_GetWithoutLogSubscribersShouldReturnTheFunction(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	val := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", val)
+
+	valRecovered, err := c.Get("key")
+
+	assert.Nil(t, err)
+	assert.True(t, val == valRecovered)
+}
+
+func TestBuiltInFunctionContainer_GetWithLogSubscribersShouldReturnTheFunction(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	val := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", val)
+	_ = c.RegisterLogSubscriber(&mock.LogSubscriberStub{})
+
+	valRecovered, err := c.Get("key")
+
+	assert.Nil(t, err)
+	assert.True(t, val == valRecovered)
+}
+
+//------- PublishLogs
+
+func TestBuiltInFunctionContainer_PublishLogsShouldSendTheLogsOfSuccessfulCalls(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	received := make([]*vmcommon.BuiltInLogEvent, 0)
+	for i := 0; i < 2; i++ {
+		err := c.RegisterLogSubscriber(&mock.LogSubscriberStub{
+			ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+				received = append(received, event)
+			},
+		})
+		require.Nil(t, err)
+	}
+
+	logEntry := &vmcommon.LogEntry{Identifier: []byte("identifier"), Topics: [][]byte{[]byte("topic")}}
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, Logs: []*vmcommon.LogEntry{logEntry, nil}}
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     []byte("caller"),
+			OriginalTxHash: []byte("original"),
+			CurrentTxHash:  []byte("current"),
+		},
+		Function: "key",
+	}
+	c.PublishLogs(vmInput, vmOutput)
+
+	expectedEvent := &vmcommon.BuiltInLogEvent{
+		OriginalTxHash: []byte("original"),
+		CurrentTxHash:  []byte("current"),
+		CallerAddr:     []byte("caller"),
+		Function:       "key",
+		LogEntry:       logEntry,
+	}
+	assert.Equal(t, []*vmcommon.BuiltInLogEvent{expectedEvent, expectedEvent}, received)
+
+	c.PublishLogs(vmInput, &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, Logs: []*vmcommon.LogEntry{logEntry}})
+	c.PublishLogs(vmInput, nil)
+	c.PublishLogs(nil, vmOutput)
+	assert.Equal(t, 2, len(received))
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil function should error", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewBuiltInFunctionContainer()
+		vmOutput, err := c.ProcessBuiltinFunction(nil, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrNilContainerElement, err)
+	})
+	t.Run("failed call should not publish the logs", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, Logs: []*vmcommon.LogEntry{{}}}, expectedErr
+			},
+		}
+
+		c := NewBuiltInFunctionContainer()
+		numReceived := 0
+		_ = c.RegisterLogSubscriber(&mock.LogSubscriberStub{
+			ReceiveLogCalled: func(_ *vmcommon.BuiltInLogEvent) {
+				numReceived++
+			},
+		})
+		vmOutput, err := c.ProcessBuiltinFunction(function, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, numReceived)
+	})
+	t.Run("successful call should publish the logs", func(t *testing.T) {
+		t.Parallel()
+
+		expectedOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, Logs: []*vmcommon.LogEntry{{Identifier: []byte("identifier")}}}
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return expectedOutput, nil
+			},
+		}
+
+		c := NewBuiltInFunctionContainer()
+		received := make([]*vmcommon.BuiltInLogEvent, 0)
+		_ = c.RegisterLogSubscriber(&mock.LogSubscriberStub{
+			ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+				received = append(received, event)
+			},
+		})
+		vmInput := &vmcommon.ContractCallInput{Function: "function"}
+
+		vmOutput, err := c.ProcessBuiltinFunctionWithoutLogs(function, nil, nil, vmInput)
+		assert.Nil(t, err)
+		assert.True(t, vmOutput == expectedOutput)
+		assert.Empty(t, received)
+
+		vmOutput, err = c.ProcessBuiltinFunction(function, nil, nil, vmInput)
+		assert.Nil(t, err)
+		assert.True(t, vmOutput == expectedOutput)
+		require.Equal(t, 1, len(received))
+		assert.Equal(t, "function", received[0].Function)
+		assert.Equal(t, []byte("identifier"), received[0].LogEntry.Identifier)
+	})
+}
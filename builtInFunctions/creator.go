@@ -1,6 +1,9 @@
 package builtInFunctions
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/mitchellh/mapstructure"
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
@@ -27,6 +30,18 @@ type ArgsCreateBuiltInFunctionContainer struct {
 	GuardedAccountHandler            vmcommon.GuardedAccountHandler
 	MaxNumOfAddressesForTransferRole uint32
 	ConfigAddress                    []byte
+	GasTracer                        vmcommon.GasTracer
+	LogSubscriber                    vmcommon.LogSubscriber
+	EpochNotifier                    vmcommon.EpochNotifier
+
+	// Registry holds the custom built-in functions the container includes. It is optional, a creator without it
+	// includes only the core built-in functions.
+	Registry *Registry
+
+	// RecordStateDiff makes the container attach the state diff to the outputs of the calls it processes as a
+	// vmcommon.BuiltInFunctionProcessor. Each recorded call runs on a set of built-in functions of its own, so that
+	// the calls are recorded apart and do not wait for each other.
+	RecordStateDiff bool
 }
 
 type builtInFuncCreator struct {
@@ -35,8 +50,12 @@ type builtInFuncCreator struct {
 	enableUserNameChange             bool
 	marshaller                       vmcommon.Marshalizer
 	accounts                         vmcommon.AccountsAdapter
+	recordStateDiff                  bool
+	recordingLanes                   *recordingLanes
+	simulation                       *simulationFunctions
 	builtInFunctions                 vmcommon.BuiltInFunctionContainer
 	gasConfig                        *vmcommon.GasCost
+	gasSchedule                      *gasScheduleHolder
 	shardCoordinator                 vmcommon.Coordinator
 	dctStorageHandler                vmcommon.DCTNFTStorageHandler
 	dctGlobalSettingsHandler         vmcommon.DCTGlobalSettingsHandler
@@ -44,6 +63,14 @@ type builtInFuncCreator struct {
 	guardedAccountHandler            vmcommon.GuardedAccountHandler
 	maxNumOfAddressesForTransferRole uint32
 	configAddress                    []byte
+	mutPayableChecker                sync.RWMutex
+	payableChecker                   vmcommon.PayableChecker
+	gasTracer                        vmcommon.GasTracer
+	logSubscriber                    vmcommon.LogSubscriber
+	customFunctions                  []CustomBuiltInFunction
+	customGasCostAcceptors           []customGasCostAcceptor
+	epochNotifier                    vmcommon.EpochNotifier
+	versionedFunctions               []*versionedBuiltInFunction
 }
 
 // NewBuiltInFunctionsCreator creates a component which will instantiate the built in functions contracts
@@ -81,6 +108,10 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 		guardedAccountHandler:            args.GuardedAccountHandler,
 		maxNumOfAddressesForTransferRole: args.MaxNumOfAddressesForTransferRole,
 		configAddress:                    args.ConfigAddress,
+		gasTracer:                        args.GasTracer,
+		logSubscriber:                    args.LogSubscriber,
+		epochNotifier:                    args.EpochNotifier,
+		recordStateDiff:                  args.RecordStateDiff,
 	}
 
 	var err error
@@ -88,27 +119,94 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 	if err != nil {
 		return nil, err
 	}
+
+	registry := args.Registry
+	if registry == nil {
+		registry = NewRegistry()
+	}
+	b.customFunctions = registry.Functions()
+	initialCustomGasCosts, err := customGasCosts(b.customFunctions, args.GasMap)
+	if err != nil {
+		return nil, err
+	}
+
+	b.gasSchedule = newGasScheduleHolder(b.gasConfig, initialCustomGasCosts)
 	b.builtInFunctions = NewBuiltInFunctionContainer()
 
 	return b, nil
 }
 
-// GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated
+// GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated.
+// An invalid gas schedule is logged and leaves the active gas schedule unchanged.
 func (b *builtInFuncCreator) GasScheduleChange(gasSchedule map[string]map[string]uint64) {
+	_, err := b.ChangeGasSchedule(gasSchedule)
+	if err != nil {
+		log.Error("builtInFuncCreator.GasScheduleChange", "error", err)
+	}
+}
+
+// ChangeGasSchedule validates the gas schedule and then publishes it as a new snapshot, which the built-in functions
+// created by this component read from their next execution on. Only the functions which do not read the snapshot get
+// the new gas cost through SetNewGasConfig. On error, the active gas schedule is not changed.
+func (b *builtInFuncCreator) ChangeGasSchedule(gasSchedule map[string]map[string]uint64) (*GasScheduleSnapshot, error) {
 	newGasConfig, err := createGasConfig(gasSchedule)
 	if err != nil {
-		return
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGasSchedule, err)
+	}
+	newCustomGasCosts, err := customGasCosts(b.customFunctions, gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	builtInFuncs, err := b.listBuiltInFunctions()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := b.gasSchedule.swap(newGasConfig, newCustomGasCosts)
+	b.setGasSchedule(builtInFuncs, snapshot)
+
+	err = b.forEachDerivedCreator(func(creator *builtInFuncCreator) error {
+		derivedFuncs, errList := creator.listBuiltInFunctions()
+		if errList != nil {
+			return errList
+		}
+
+		creator.setGasSchedule(derivedFuncs, snapshot)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func (b *builtInFuncCreator) setGasSchedule(builtInFuncs []vmcommon.BuiltinFunction, snapshot *GasScheduleSnapshot) {
+	b.gasConfig = snapshot.GasCost
+	for _, builtInFunc := range builtInFuncs {
+		setNewGasConfigIfNotSubscribed(builtInFunc, snapshot.GasCost)
 	}
+	b.setCustomGasCosts(snapshot.CustomGasCosts)
+}
 
-	b.gasConfig = newGasConfig
+func (b *builtInFuncCreator) listBuiltInFunctions() ([]vmcommon.BuiltinFunction, error) {
+	builtInFuncs := make([]vmcommon.BuiltinFunction, 0, b.builtInFunctions.Len())
 	for key := range b.builtInFunctions.Keys() {
-		builtInFunc, errGet := b.builtInFunctions.Get(key)
-		if errGet != nil {
-			return
+		builtInFunc, err := b.builtInFunctions.Get(key)
+		if err != nil {
+			return nil, err
 		}
 
-		builtInFunc.SetNewGasConfig(b.gasConfig)
+		builtInFuncs = append(builtInFuncs, builtInFunc)
 	}
+
+	return builtInFuncs, nil
+}
+
+// ActiveGasSchedule returns the gas schedule the built-in functions currently use
+func (b *builtInFuncCreator) ActiveGasSchedule() *GasScheduleSnapshot {
+	return b.gasSchedule.activeSnapshot()
 }
 
 // NFTStorageHandler will return the dct storage handler from the built in functions factory
@@ -126,9 +224,65 @@ func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunction
 	return b.builtInFunctions
 }
 
-// CreateBuiltInFunctionContainer will create the list of built-in functions
+// CreateBuiltInFunctionContainer will create the list of built-in functions, together with the set of built-in
+// functions the simulations run on
 func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
+	b.mutPayableChecker.Lock()
+	b.payableChecker = nil
+	b.mutPayableChecker.Unlock()
+
+	err := b.createBuiltInFunctions()
+	if err != nil {
+		return err
+	}
+
+	if b.recordStateDiff {
+		err = b.setRecordingLanes()
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.createSimulationFunctions()
+}
+
+// newDerivedCreator returns a creator with the same configuration, whose built-in functions work on the provided
+// accounts adapter
+func (b *builtInFuncCreator) newDerivedCreator(accounts vmcommon.AccountsAdapter) *builtInFuncCreator {
+	return &builtInFuncCreator{
+		mapDNSAddresses:                  b.mapDNSAddresses,
+		mapDNSV2Addresses:                b.mapDNSV2Addresses,
+		enableUserNameChange:             b.enableUserNameChange,
+		marshaller:                       b.marshaller,
+		accounts:                         accounts,
+		gasConfig:                        b.gasSchedule.activeSnapshot().GasCost,
+		gasSchedule:                      b.gasSchedule,
+		shardCoordinator:                 b.shardCoordinator,
+		enableEpochsHandler:              b.enableEpochsHandler,
+		guardedAccountHandler:            b.guardedAccountHandler,
+		maxNumOfAddressesForTransferRole: b.maxNumOfAddressesForTransferRole,
+		configAddress:                    b.configAddress,
+		customFunctions:                  b.customFunctions,
+		epochNotifier:                    b.epochNotifier,
+	}
+}
+
+// forEachDerivedCreator calls the handler for the creators of the simulation set and of the recording lanes
+func (b *builtInFuncCreator) forEachDerivedCreator(handler func(creator *builtInFuncCreator) error) error {
+	if b.simulation != nil {
+		err := handler(b.simulation.creator)
+		if err != nil {
+			return err
+		}
+	}
+	if b.recordingLanes != nil {
+		return b.recordingLanes.forEachCreator(handler)
+	}
 
+	return nil
+}
+
+func (b *builtInFuncCreator) createBuiltInFunctions() error {
 	b.builtInFunctions = NewBuiltInFunctionContainer()
 	var newFunc vmcommon.BuiltinFunction
 	newFunc = NewClaimDeveloperRewardsFunc(b.gasConfig.BuiltInCost.ClaimDeveloperRewards)
@@ -501,9 +655,76 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
+	err = b.createCustomBuiltInFunctions()
+	if err != nil {
+		return err
+	}
+
+	b.subscribeToGasSchedule()
+
+	err = b.setGasTracer()
+	if err != nil {
+		return err
+	}
+
+	return b.registerLogSubscriber()
+}
+
+// subscribeToGasSchedule makes the built-in functions execute under the shared gas schedule holder
+func (b *builtInFuncCreator) subscribeToGasSchedule() {
+	for key := range b.builtInFunctions.Keys() {
+		builtInFunc, err := b.builtInFunctions.Get(key)
+		if err != nil {
+			continue
+		}
+
+		subscriber, ok := builtInFunc.(gasScheduleSubscriber)
+		if ok {
+			subscriber.setGasScheduleHolder(b.gasSchedule)
+		}
+	}
+}
+
+// setGasTracer sets the optional gas tracer to all the functions that charge gas
+func (b *builtInFuncCreator) setGasTracer() error {
+	if check.IfNil(b.gasTracer) {
+		return nil
+	}
+
+	for key := range b.builtInFunctions.Keys() {
+		builtInFunc, err := b.builtInFunctions.Get(key)
+		if err != nil {
+			return err
+		}
+
+		gasTracerAcceptor, ok := builtInFunc.(vmcommon.AcceptGasTracer)
+		if !ok {
+			continue
+		}
+
+		err = gasTracerAcceptor.SetGasTracer(b.gasTracer)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// registerLogSubscriber registers the optional log subscriber on the built-in functions container
+func (b *builtInFuncCreator) registerLogSubscriber() error {
+	if check.IfNil(b.logSubscriber) {
+		return nil
+	}
+
+	logSubscriberAcceptor, ok := b.builtInFunctions.(vmcommon.AcceptLogSubscriber)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return logSubscriberAcceptor.RegisterLogSubscriber(b.logSubscriber)
+}
+
 func (b *builtInFuncCreator) createBaseAccountGuarderArgs(funcGasCost uint64) BaseAccountGuarderArgs {
 	return BaseAccountGuarderArgs{
 		Marshaller:            b.marshaller,
@@ -560,6 +781,28 @@ func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHa
 		return err
 	}
 
+	err = b.setPayableChecker(payableChecker)
+	if err != nil {
+		return err
+	}
+
+	b.mutPayableChecker.Lock()
+	b.payableChecker = payableChecker
+	b.mutPayableChecker.Unlock()
+
+	return b.forEachDerivedCreator(func(creator *builtInFuncCreator) error {
+		return creator.setPayableChecker(payableChecker)
+	})
+}
+
+func (b *builtInFuncCreator) getPayableChecker() vmcommon.PayableChecker {
+	b.mutPayableChecker.RLock()
+	defer b.mutPayableChecker.RUnlock()
+
+	return b.payableChecker
+}
+
+func (b *builtInFuncCreator) setPayableChecker(payableChecker vmcommon.PayableChecker) error {
 	listOfTransferFunc := []string{
 		core.BuiltInFunctionMultiDCTNFTTransfer,
 		core.BuiltInFunctionDCTNFTTransfer,
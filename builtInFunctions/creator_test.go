@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/subrahamanyam341/andes-core-16/core"
 	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
 	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
 )
 
@@ -166,3 +167,37 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 	nftStorageHandler := f.NFTStorageHandler()
 	assert.False(t, check.IfNil(nftStorageHandler))
 }
+
+func TestCreateBuiltInContainer_CreateWithLogSubscriber(t *testing.T) {
+	args := createMockArguments()
+	args.GasTracer = &mock.GasTracerStub{}
+	numReceived := 0
+	args.LogSubscriber = &mock.LogSubscriberStub{
+		ReceiveLogCalled: func(_ *vmcommon.BuiltInLogEvent) {
+			numReceived++
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	function, err := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	require.Nil(t, err)
+	_, isPayableCheckerAcceptor := function.(vmcommon.AcceptPayableChecker)
+	assert.True(t, isPayableCheckerAcceptor)
+	_, isAccessSetProvider := function.(vmcommon.AccessSetProvider)
+	assert.True(t, isAccessSetProvider)
+
+	publisher, ok := f.BuiltInFunctionContainer().(vmcommon.LogPublisher)
+	require.True(t, ok)
+	publisher.PublishLogs(&vmcommon.ContractCallInput{}, &vmcommon.VMOutput{Logs: []*vmcommon.LogEntry{{}}})
+	assert.Equal(t, 1, numReceived)
+
+	err = f.SetPayableHandler(&mock.PayableHandlerStub{})
+	assert.Nil(t, err)
+
+	fillGasMapInternal(args.GasMap, 5)
+	f.GasScheduleChange(args.GasMap)
+	assert.Equal(t, f.gasConfig.BuiltInCost.ClaimDeveloperRewards, uint64(5))
+}
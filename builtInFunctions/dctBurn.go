@@ -12,6 +12,8 @@ import (
 
 type dctBurn struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	funcGasCost           uint64
 	marshaller            vmcommon.Marshalizer
 	keyPrefix             []byte
@@ -37,6 +39,9 @@ func NewDCTBurnFunc(
 	}
 
 	e := &dctBurn{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTBurn
+		}),
 		funcGasCost:           funcGasCost,
 		marshaller:            marshaller,
 		keyPrefix:             []byte(baseDCTKeyPrefix),
@@ -67,6 +72,8 @@ func (e *dctBurn) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
+	funcGasCost := e.activeFuncGasCost(e.funcGasCost)
+
 	err := checkBasicDCTArguments(vmInput)
 	if err != nil {
 		return nil, err
@@ -87,7 +94,7 @@ func (e *dctBurn) ProcessBuiltinFunction(
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 
-	if vmInput.GasProvided < e.funcGasCost {
+	if vmInput.GasProvided < funcGasCost {
 		return nil, ErrNotEnoughGas
 	}
 
@@ -96,7 +103,7 @@ func (e *dctBurn) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, e.funcGasCost)
+	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, funcGasCost)
 	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
 	if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
 		addOutputTransferToVMOutput(
@@ -111,6 +118,7 @@ func (e *dctBurn) ProcessBuiltinFunction(
 	}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTBurn), vmInput.Arguments[0], 0, value, vmInput.CallerAddr)
+	e.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("DCTBurn", funcGasCost))
 
 	return vmOutput, nil
 }
@@ -1,6 +1,7 @@
 package builtInFunctions
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -58,7 +59,7 @@ func TestDCTBurn_ProcessBuiltInFunctionErrors(t *testing.T) {
 		},
 	}
 	_, err = burnFunc.ProcessBuiltinFunction(nil, nil, input)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 
 	input = &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
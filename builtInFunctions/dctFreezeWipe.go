@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
 
 	"github.com/subrahamanyam341/andes-core-16/core"
@@ -49,6 +50,17 @@ func NewDCTFreezeWipeFunc(
 	return e, nil
 }
 
+func (e *dctFreezeWipe) functionName() string {
+	if e.wipe {
+		return core.BuiltInFunctionDCTWipe
+	}
+	if e.freeze {
+		return core.BuiltInFunctionDCTFreeze
+	}
+
+	return core.BuiltInFunctionDCTUnFreeze
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (e *dctFreezeWipe) SetNewGasConfig(_ *vmcommon.GasCost) {
 }
@@ -65,7 +77,7 @@ func (e *dctFreezeWipe) ProcessBuiltinFunction(
 		return nil, ErrBuiltInFunctionCalledWithValue
 	}
 	if len(vmInput.Arguments) != 1 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(e.functionName(), ErrInvalidArguments)
 	}
 	if !bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
 		return nil, ErrAddressIsNotDCTSystemSC
@@ -82,6 +94,9 @@ func (e *dctFreezeWipe) ProcessBuiltinFunction(
 
 	if e.wipe {
 		amount, err = e.wipeIfApplicable(acntDst, dctTokenKey, identifier, nonce)
+		if errors.Is(err, ErrCannotWipeAccountNotFrozen) {
+			return nil, newArgumentError(e.functionName(), err, 0)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -1,6 +1,7 @@
 package builtInFunctions
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -26,7 +27,7 @@ func TestDCTFreezeWipe_ProcessBuiltInFunctionErrors(t *testing.T) {
 		},
 	}
 	_, err = freeze.ProcessBuiltinFunction(nil, nil, input)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 
 	input = &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -42,7 +43,7 @@ func TestDCTFreezeWipe_ProcessBuiltInFunctionErrors(t *testing.T) {
 	value := []byte("value")
 	input.Arguments = [][]byte{key, value}
 	_, err = freeze.ProcessBuiltinFunction(nil, nil, input)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 
 	input.Arguments = [][]byte{key}
 	_, err = freeze.ProcessBuiltinFunction(nil, nil, input)
@@ -118,7 +119,7 @@ func TestDCTFreezeWipe_ProcessBuiltInFunction(t *testing.T) {
 	// cannot wipe if account is not frozen
 	wipe, _ := NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, marshaller, false, true)
 	_, err = wipe.ProcessBuiltinFunction(nil, acnt, input)
-	assert.Equal(t, ErrCannotWipeAccountNotFrozen, err)
+	assert.True(t, errors.Is(err, ErrCannotWipeAccountNotFrozen))
 
 	marshaledData, _, _ = acnt.AccountDataHandler().RetrieveValue(dctKey)
 	assert.NotEqual(t, 0, len(marshaledData))
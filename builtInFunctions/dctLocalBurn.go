@@ -13,6 +13,8 @@ import (
 
 type dctLocalBurn struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
@@ -44,6 +46,9 @@ func NewDCTLocalBurnFunc(
 	}
 
 	e := &dctLocalBurn{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTLocalBurn
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		marshaller:            marshaller,
 		globalSettingsHandler: globalSettingsHandler,
@@ -75,7 +80,9 @@ func (e *dctLocalBurn) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkInputArgumentsForLocalAction(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost := e.activeFuncGasCost(e.funcGasCost)
+
+	err := checkInputArgumentsForLocalAction(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
@@ -99,9 +106,10 @@ func (e *dctLocalBurn) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - funcGasCost}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTLocalBurn), vmInput.Arguments[0], 0, value, vmInput.CallerAddr)
+	e.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("DCTLocalBurn", funcGasCost))
 
 	return vmOutput, nil
 }
@@ -132,7 +140,7 @@ func checkBasicDCTArguments(vmInput *vmcommon.ContractCallInput) error {
 		return ErrBuiltInFunctionCalledWithValue
 	}
 	if len(vmInput.Arguments) < core.MinLenArgumentsDCTTransfer {
-		return ErrInvalidArguments
+		return newArgumentError(vmInput.Function, ErrInvalidArguments)
 	}
 	return nil
 }
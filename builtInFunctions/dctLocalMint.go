@@ -12,6 +12,8 @@ import (
 
 type dctLocalMint struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
@@ -43,6 +45,9 @@ func NewDCTLocalMintFunc(
 	}
 
 	e := &dctLocalMint{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTLocalMint
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		marshaller:            marshaller,
 		globalSettingsHandler: globalSettingsHandler,
@@ -74,7 +79,9 @@ func (e *dctLocalMint) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkInputArgumentsForLocalAction(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost := e.activeFuncGasCost(e.funcGasCost)
+
+	err := checkInputArgumentsForLocalAction(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
@@ -100,9 +107,10 @@ func (e *dctLocalMint) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - funcGasCost}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTLocalMint), vmInput.Arguments[0], 0, value, vmInput.CallerAddr)
+	e.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("DCTLocalMint", funcGasCost))
 
 	return vmOutput, nil
 }
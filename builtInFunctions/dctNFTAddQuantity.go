@@ -14,6 +14,8 @@ const maxLenForAddNFTQuantity = 32
 
 type dctNFTAddQuantity struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
 	rolesHandler          vmcommon.DCTRoleHandler
@@ -45,6 +47,9 @@ func NewDCTNFTAddQuantityFunc(
 	}
 
 	e := &dctNFTAddQuantity{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTAddQuantity
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		globalSettingsHandler: globalSettingsHandler,
 		rolesHandler:          rolesHandler,
@@ -80,12 +85,14 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost := e.activeFuncGasCost(e.funcGasCost)
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
 	if len(vmInput.Arguments) < 3 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTAddQuantity, ErrInvalidArguments)
 	}
 
 	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddQuantity))
@@ -105,7 +112,8 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 
 	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsValueLengthCheckFlagEnabled()
 	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[2]) > maxLenForAddNFTQuantity {
-		return nil, fmt.Errorf("%w max length for add nft quantity is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
+		err = fmt.Errorf("%w max length for add nft quantity is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTAddQuantity, err, 2)
 	}
 
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[2])
@@ -122,10 +130,11 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - e.funcGasCost,
+		GasRemaining: vmInput.GasProvided - funcGasCost,
 	}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTAddQuantity), vmInput.Arguments[0], nonce, value, vmInput.CallerAddr)
+	e.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("DCTNFTAddQuantity", funcGasCost))
 
 	return vmOutput, nil
 }
@@ -123,7 +123,7 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAdd
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 
 	// vm input - invalid number of arguments
 	output, err = eqf.ProcessBuiltinFunction(
@@ -137,7 +137,7 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAdd
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 
 	// vm input - invalid receiver
 	output, err = eqf.ProcessBuiltinFunction(
@@ -209,7 +209,7 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionInvalidNumberOfArguments(t *tes
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 }
 
 func TestDctNFTAddQuantity_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *testing.T) {
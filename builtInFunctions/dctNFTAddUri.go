@@ -11,6 +11,8 @@ import (
 
 type dctNFTAddUri struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	dctStorageHandler     vmcommon.DCTNFTStorageHandler
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
@@ -43,6 +45,9 @@ func NewDCTNFTAddUriFunc(
 	}
 
 	e := &dctNFTAddUri{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTAddURI
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		dctStorageHandler:     dctStorageHandler,
 		funcGasCost:           funcGasCost,
@@ -81,12 +86,14 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost, gasConfig := e.activeGasCost(e.funcGasCost, e.gasConfig)
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
 	if len(vmInput.Arguments) < 3 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTAddURI, ErrInvalidArguments)
 	}
 
 	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddURI))
@@ -94,8 +101,9 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	gasCostForStore := e.getGasCostForURIStore(vmInput)
-	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
+	lenURIs := getURIsLength(vmInput)
+	gasCostForStore := lenURIs * gasConfig.StorePerByte
+	if vmInput.GasProvided < funcGasCost+gasCostForStore {
 		return nil, ErrNotEnoughGas
 	}
 
@@ -118,21 +126,27 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
+		GasRemaining: vmInput.GasProvided - funcGasCost - gasCostForStore,
 	}
 
 	extraTopics := append([][]byte{vmInput.CallerAddr}, vmInput.Arguments[2:]...)
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTAddURI), vmInput.Arguments[0], nonce, big.NewInt(0), extraTopics...)
+	e.traceGas(
+		vmInput,
+		vmOutput,
+		newBuiltInCostCharge("DCTNFTAddURI", funcGasCost),
+		newBaseOperationCostCharge("StorePerByte", lenURIs, gasConfig.StorePerByte),
+	)
 
 	return vmOutput, nil
 }
 
-func (e *dctNFTAddUri) getGasCostForURIStore(vmInput *vmcommon.ContractCallInput) uint64 {
+func getURIsLength(vmInput *vmcommon.ContractCallInput) uint64 {
 	lenURIs := 0
 	for _, uri := range vmInput.Arguments[2:] {
 		lenURIs += len(uri)
 	}
-	return uint64(lenURIs) * e.gasConfig.StorePerByte
+	return uint64(lenURIs)
 }
 
 // IsInterfaceNil returns true if underlying object in nil
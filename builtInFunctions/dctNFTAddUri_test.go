@@ -124,7 +124,7 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionErrorOnCheckInput(t *testing.T) {
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 
 	// vm input - invalid number of arguments
 	output, err = e.ProcessBuiltinFunction(
@@ -138,7 +138,7 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionErrorOnCheckInput(t *testing.T) {
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 
 	// vm input - invalid receiver
 	output, err = e.ProcessBuiltinFunction(
@@ -210,7 +210,7 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionInvalidNumberOfArguments(t *testing.
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 }
 
 func TestDCTNFTAddUri_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *testing.T) {
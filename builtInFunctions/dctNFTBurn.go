@@ -11,6 +11,8 @@ import (
 
 type dctNFTBurn struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	dctStorageHandler     vmcommon.DCTNFTStorageHandler
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
@@ -37,6 +39,9 @@ func NewDCTNFTBurnFunc(
 	}
 
 	e := &dctNFTBurn{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTBurn
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		dctStorageHandler:     dctStorageHandler,
 		globalSettingsHandler: globalSettingsHandler,
@@ -71,12 +76,14 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost := e.activeFuncGasCost(e.funcGasCost)
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
 	if len(vmInput.Arguments) < 3 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTBurn, ErrInvalidArguments)
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
@@ -96,7 +103,7 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 
 	quantityToBurn := big.NewInt(0).SetBytes(vmInput.Arguments[2])
 	if dctData.Value.Cmp(quantityToBurn) < 0 {
-		return nil, ErrInvalidNFTQuantity
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTBurn, ErrInvalidNFTQuantity, 2)
 	}
 
 	dctData.Value.Sub(dctData.Value, quantityToBurn)
@@ -113,10 +120,11 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - e.funcGasCost,
+		GasRemaining: vmInput.GasProvided - funcGasCost,
 	}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTBurn), vmInput.Arguments[0], nonce, quantityToBurn, vmInput.CallerAddr)
+	e.traceSenderGas(acntSnd, vmInput, vmOutput, newBuiltInCostCharge("DCTNFTBurn", funcGasCost))
 
 	return vmOutput, nil
 }
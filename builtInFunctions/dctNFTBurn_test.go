@@ -101,7 +101,7 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAddInp
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 
 	// vm input - invalid number of arguments
 	output, err = ebf.ProcessBuiltinFunction(
@@ -115,7 +115,7 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAddInp
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 
 	// vm input - invalid receiver
 	output, err = ebf.ProcessBuiltinFunction(
@@ -185,7 +185,7 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionInvalidNumberOfArguments(t *testin
 		},
 	)
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidArguments, err)
+	require.True(t, errors.Is(err, ErrInvalidArguments))
 }
 
 func TestDctNFTBurnFunc_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *testing.T) {
@@ -301,7 +301,7 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionInvalidBurnQuantity(t *testing.T)
 	)
 
 	require.Nil(t, output)
-	require.Equal(t, ErrInvalidNFTQuantity, err)
+	require.True(t, errors.Is(err, ErrInvalidNFTQuantity))
 }
 
 func TestDctNFTBurnFunc_ProcessBuiltinFunctionShouldErrOnSaveBecauseTokenIsPaused(t *testing.T) {
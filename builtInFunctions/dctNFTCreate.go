@@ -21,6 +21,8 @@ var (
 
 type dctNFTCreate struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	accounts              vmcommon.AccountsAdapter
 	marshaller            vmcommon.Marshalizer
@@ -64,6 +66,9 @@ func NewDCTNFTCreateFunc(
 	}
 
 	e := &dctNFTCreate{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTCreate
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		marshaller:            marshaller,
 		globalSettingsHandler: globalSettingsHandler,
@@ -107,7 +112,9 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost, gasConfig := e.activeGasCost(e.funcGasCost, e.gasConfig)
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +125,8 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	}
 	lenArgs := len(vmInput.Arguments)
 	if lenArgs < minNumOfArgs {
-		return nil, fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+		err = fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreate, err)
 	}
 
 	accountWithRoles := acntSnd
@@ -128,10 +136,10 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 		uris = vmInput.Arguments[6 : lenArgs-1]
 
 		if len(scAddressWithRoles) != len(vmInput.CallerAddr) {
-			return nil, ErrInvalidAddressLength
+			return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreate, ErrInvalidAddressLength, lenArgs-1)
 		}
 		if bytes.Equal(scAddressWithRoles, vmInput.CallerAddr) {
-			return nil, ErrInvalidRcvAddr
+			return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreate, ErrInvalidRcvAddr, lenArgs-1)
 		}
 
 		accountWithRoles, err = e.getAccount(scAddressWithRoles)
@@ -155,20 +163,22 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	for _, arg := range vmInput.Arguments {
 		totalLength += uint64(len(arg))
 	}
-	gasToUse := totalLength*e.gasConfig.StorePerByte + e.funcGasCost
+	gasToUse := totalLength*gasConfig.StorePerByte + funcGasCost
 	if vmInput.GasProvided < gasToUse {
 		return nil, ErrNotEnoughGas
 	}
 
 	royalties := uint32(big.NewInt(0).SetBytes(vmInput.Arguments[3]).Uint64())
 	if royalties > core.MaxRoyalty {
-		return nil, fmt.Errorf("%w, invalid max royality value", ErrInvalidArguments)
+		err = fmt.Errorf("%w, invalid max royality value", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreate, err, 3)
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 	quantity := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	if quantity.Cmp(zero) <= 0 {
-		return nil, fmt.Errorf("%w, invalid quantity", ErrInvalidArguments)
+		err = fmt.Errorf("%w, invalid quantity", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreate, err, 1)
 	}
 	if quantity.Cmp(big.NewInt(1)) > 0 {
 		err = e.rolesHandler.CheckAllowedToExecute(accountWithRoles, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddQuantity))
@@ -178,7 +188,8 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	}
 	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsValueLengthCheckFlagEnabled()
 	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[1]) > maxLenForAddNFTQuantity {
-		return nil, fmt.Errorf("%w max length for quantity in nft create is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
+		err = fmt.Errorf("%w max length for quantity in nft create is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreate, err, 1)
 	}
 
 	nextNonce := nonce + 1
@@ -229,6 +240,12 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTCreate), vmInput.Arguments[0], nextNonce, quantity, vmInput.CallerAddr, dctDataBytes)
+	e.traceGas(
+		vmInput,
+		vmOutput,
+		newBuiltInCostCharge("DCTNFTCreate", funcGasCost),
+		newBaseOperationCostCharge("StorePerByte", totalLength, gasConfig.StorePerByte),
+	)
 
 	return vmOutput, nil
 }
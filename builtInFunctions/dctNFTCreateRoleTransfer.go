@@ -90,10 +90,10 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 	vmInput *vmcommon.ContractCallInput,
 ) (*vmcommon.OutputAccount, error) {
 	if len(vmInput.Arguments) != 2 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreateRoleTransfer, ErrInvalidArguments)
 	}
 	if len(vmInput.Arguments[1]) != len(vmInput.CallerAddr) {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTCreateRoleTransfer, ErrInvalidArguments, 1)
 	}
 
 	tokenID := vmInput.Arguments[0]
@@ -220,7 +220,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtNextOwner(
 	vmInput *vmcommon.ContractCallInput,
 ) error {
 	if len(vmInput.Arguments) != 2 {
-		return ErrInvalidArguments
+		return newArgumentError(core.BuiltInFunctionDCTNFTCreateRoleTransfer, ErrInvalidArguments)
 	}
 
 	tokenID := vmInput.Arguments[0]
@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -55,7 +56,7 @@ func TestDCTNFTCreateRoleTransfer_ProcessWithErrors(t *testing.T) {
 	assert.Nil(t, vmOutput)
 
 	vmOutput, err = e.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}})
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 	assert.Nil(t, vmOutput)
 
 	vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}}
@@ -71,12 +72,12 @@ func TestDCTNFTCreateRoleTransfer_ProcessWithErrors(t *testing.T) {
 	vmInput.CallerAddr = core.DCTSCAddress
 	vmInput.Arguments = [][]byte{{1}, {2}, {3}}
 	vmOutput, err = e.ProcessBuiltinFunction(nil, &mock.UserAccountStub{}, vmInput)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 	assert.Nil(t, vmOutput)
 
 	vmInput.Arguments = [][]byte{{1}, {2}}
 	vmOutput, err = e.ProcessBuiltinFunction(nil, &mock.UserAccountStub{}, vmInput)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 	assert.Nil(t, vmOutput)
 }
 
@@ -183,7 +184,7 @@ func TestDCTNFTCreateRoleTransfer_ProcessCrossShard(t *testing.T) {
 
 	vmInput.Arguments = append(vmInput.Arguments, []byte{100})
 	vmOutput, err = e.ProcessBuiltinFunction(nil, userAcc, vmInput)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 	assert.Nil(t, vmOutput)
 }
 
@@ -22,6 +22,8 @@ var zeroByteArray = []byte{0}
 
 type dctNFTTransfer struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
@@ -71,6 +73,9 @@ func NewDCTNFTTransferFunc(
 	}
 
 	e := &dctNFTTransfer{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTTransfer
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		marshaller:            marshaller,
 		globalSettingsHandler: globalSettingsHandler,
@@ -88,6 +93,37 @@ func NewDCTNFTTransferFunc(
 	return e, nil
 }
 
+// AccessSet returns the accounts and the system account keys the NFT transfer call touches. On the sender
+// shard, the destination is taken from the arguments, as it is loaded and saved if in the same shard.
+func (e *dctNFTTransfer) AccessSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.AccessSet, error) {
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) < 4 {
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, ErrInvalidArguments)
+	}
+
+	dstAddress := vmInput.RecipientAddr
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		dstAddress = destinationFromArgument(vmInput, 3)
+	}
+
+	accessSet := newAccountsAccessSet(vmInput.CallerAddr, dstAddress)
+	access := &nftTransferAccess{
+		accounts:            e.accounts,
+		marshaller:          e.marshaller,
+		shardCoordinator:    e.shardCoordinator,
+		enableEpochsHandler: e.enableEpochsHandler,
+	}
+	err = access.addTokenAccess(accessSet, vmInput.Arguments[0], nonceFromArgument(vmInput.Arguments[1]), vmInput.CallerAddr, dstAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return accessSet, nil
+}
+
 // SetPayableChecker will set the payableCheck handler to the function
 func (e *dctNFTTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
 	if check.IfNil(payableHandler) {
@@ -124,16 +160,18 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
+	funcGasCost, gasConfig := e.activeGasCost(e.funcGasCost, e.gasConfig)
+
 	err := checkBasicDCTArguments(vmInput)
 	if err != nil {
 		return nil, err
 	}
 	if len(vmInput.Arguments) < 4 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, ErrInvalidArguments)
 	}
 
 	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
-		return e.processNFTTransferOnSenderShard(acntSnd, vmInput)
+		return e.processNFTTransferOnSenderShard(acntSnd, vmInput, funcGasCost, gasConfig)
 	}
 
 	// in cross shard NFT transfer the sender account must be nil
@@ -154,7 +192,7 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 		marshaledNFTTransfer := vmInput.Arguments[3]
 		err = e.marshaller.Unmarshal(dctTransferData, marshaledNFTTransfer)
 		if err != nil {
-			return nil, err
+			return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, err, 3)
 		}
 	} else {
 		dctTransferData.Value = big.NewInt(0).Set(value)
@@ -206,20 +244,24 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
+	funcGasCost uint64,
+	gasConfig vmcommon.BaseOperationCost,
 ) (*vmcommon.VMOutput, error) {
 	dstAddress := vmInput.Arguments[3]
 	if len(dstAddress) != len(vmInput.CallerAddr) {
-		return nil, fmt.Errorf("%w, not a valid destination address", ErrInvalidArguments)
+		err := fmt.Errorf("%w, not a valid destination address", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, err, 3)
 	}
 	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
-		return nil, fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
+		err := fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, err, 3)
 	}
 	isTransferToMetaFlagEnabled := e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
 	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(dstAddress) == core.MetachainShardId && !isTransferToMetaFlagEnabled
 	if isInvalidTransferToMeta {
 		return nil, ErrInvalidRcvAddr
 	}
-	if vmInput.GasProvided < e.funcGasCost {
+	if vmInput.GasProvided < funcGasCost {
 		return nil, ErrNotEnoughGas
 	}
 
@@ -235,15 +277,16 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	}
 
 	if len(vmInput.Arguments[2]) > core.MaxLenForDCTIssueMint && e.enableEpochsHandler.IsConsistentTokensValuesLengthCheckEnabled() {
-		return nil, fmt.Errorf("%w: max length for a transfer value is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+		err = fmt.Errorf("%w: max length for a transfer value is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, err, 2)
 	}
 	quantityToTransfer := big.NewInt(0).SetBytes(vmInput.Arguments[2])
 	if dctData.Value.Cmp(quantityToTransfer) < 0 {
-		return nil, ErrInvalidNFTQuantity
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, ErrInvalidNFTQuantity, 2)
 	}
 	isCheckTransferFlagEnabled := e.enableEpochsHandler.IsCheckTransferFlagEnabled()
 	if isCheckTransferFlagEnabled && quantityToTransfer.Cmp(zero) <= 0 {
-		return nil, ErrInvalidNFTQuantity
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTTransfer, ErrInvalidNFTQuantity, 2)
 	}
 	dctData.Value.Sub(dctData.Value, quantityToTransfer)
 
@@ -299,9 +342,9 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - e.funcGasCost,
+		GasRemaining: vmInput.GasProvided - funcGasCost,
 	}
-	err = e.createNFTOutputTransfers(vmInput, vmOutput, dctData, dstAddress, tickerID, nonce)
+	copiedDataLength, err := e.createNFTOutputTransfers(vmInput, vmOutput, dctData, dstAddress, tickerID, nonce, gasConfig.DataCopyPerByte)
 	if err != nil {
 		return nil, err
 	}
@@ -316,6 +359,12 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 			quantityToTransfer,
 		}},
 	)
+	e.traceGas(
+		vmInput,
+		vmOutput,
+		newBuiltInCostCharge("DCTNFTTransfer", funcGasCost),
+		newBaseOperationCostCharge("DataCopyPerByte", copiedDataLength, gasConfig.DataCopyPerByte),
+	)
 
 	return vmOutput, nil
 }
@@ -327,26 +376,29 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 	dstAddress []byte,
 	tickerID []byte,
 	nonce uint64,
-) error {
+	dataCopyPerByte uint64,
+) (uint64, error) {
 	nftTransferCallArgs := make([][]byte, 0)
 	nftTransferCallArgs = append(nftTransferCallArgs, vmInput.Arguments[:3]...)
+	copiedDataLength := uint64(0)
 
 	wasAlreadySent, err := e.dctStorageHandler.WasAlreadySentToDestinationShardAndUpdateState(tickerID, nonce, dstAddress)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if !wasAlreadySent || dctTransferData.Value.Cmp(oneValue) == 0 {
 		marshaledNFTTransfer, err := e.marshaller.Marshal(dctTransferData)
 		if err != nil {
-			return err
+			return 0, err
 		}
 
-		gasForTransfer := uint64(len(marshaledNFTTransfer)) * e.gasConfig.DataCopyPerByte
+		gasForTransfer := uint64(len(marshaledNFTTransfer)) * dataCopyPerByte
 		if gasForTransfer > vmOutput.GasRemaining {
-			return ErrNotEnoughGas
+			return 0, ErrNotEnoughGas
 		}
 		vmOutput.GasRemaining -= gasForTransfer
+		copiedDataLength = uint64(len(marshaledNFTTransfer))
 		nftTransferCallArgs = append(nftTransferCallArgs, marshaledNFTTransfer)
 	} else {
 		nftTransferCallArgs = append(nftTransferCallArgs, zeroByteArray)
@@ -376,7 +428,7 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 			vmOutput,
 		)
 
-		return nil
+		return copiedDataLength, nil
 	}
 
 	if isSCCallAfter {
@@ -396,7 +448,7 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 			vmOutput)
 	}
 
-	return nil
+	return copiedDataLength, nil
 }
 
 func (e *dctNFTTransfer) addNFTToDestination(
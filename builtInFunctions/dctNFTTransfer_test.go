@@ -3,6 +3,7 @@ package builtInFunctions
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
@@ -339,6 +340,69 @@ func TestDctNFTTransfer_SetNewGasConfig(t *testing.T) {
 	assert.Equal(t, gasCost.BaseOperationCost, nftTransfer.gasConfig)
 }
 
+func TestDctNFTTransfer_AccessSet(t *testing.T) {
+	t.Parallel()
+
+	nftTransfer := createNftTransferWithStubArguments()
+	sender := bytes.Repeat([]byte{1}, 32)
+	destination := bytes.Repeat([]byte{2}, 32)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{[]byte("token"), big.NewInt(5).Bytes(), big.NewInt(1).Bytes()},
+		},
+		RecipientAddr: sender,
+	}
+
+	accessSet, err := nftTransfer.AccessSet(vmInput)
+	assert.Nil(t, accessSet)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	assert.Equal(t, core.BuiltInFunctionDCTNFTTransfer, err.(*BuiltInFunctionError).Function)
+
+	sameShard := true
+	nftTransfer.shardCoordinator = &mock.ShardCoordinatorStub{
+		SameShardCalled: func(_, _ []byte) bool {
+			return sameShard
+		},
+	}
+	systemAccount := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	nftTransfer.accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+			return systemAccount, nil
+		},
+	}
+	tokenKey := []byte(baseDCTKeyPrefix + "token")
+	nftTokenKey := computeDCTNFTTokenKey(tokenKey, 5)
+	vmInput.Arguments = append(vmInput.Arguments, destination)
+
+	accessSet, err = nftTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{
+		{Address: sender},
+		{Address: destination},
+		{Address: vmcommon.SystemAccountAddress, Key: nftTokenKey},
+	}, accessSet.Writes)
+	assert.Equal(t, vmcommon.AccessKey{Address: vmcommon.SystemAccountAddress, Key: tokenKey}, accessSet.Reads[0])
+	assert.Equal(t, vmcommon.AccessKey{Address: vmcommon.SystemAccountAddress, Key: nftTokenKey}, accessSet.Reads[2])
+
+	_ = systemAccount.AccountDataHandler().SaveKeyValue(nftTokenKey, []byte("metadata"))
+	accessSet, err = nftTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{{Address: sender}, {Address: destination}}, accessSet.Writes)
+	assert.Equal(t, vmcommon.AccessKey{Address: vmcommon.SystemAccountAddress, Key: nftTokenKey}, accessSet.Reads[2])
+
+	sameShard = false
+	accessSet, err = nftTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.AccessKey{Address: vmcommon.SystemAccountAddress, Key: nftTokenKey}, accessSet.Writes[2])
+
+	nftTransfer.enableEpochsHandler = &mock.EnableEpochsHandlerStub{}
+	accessSet, err = nftTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{{Address: sender}, {Address: destination}}, accessSet.Writes)
+}
+
 func TestDctNFTTransfer_ProcessBuiltinFunctionInvalidArgumentsShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -355,7 +419,7 @@ func TestDctNFTTransfer_ProcessBuiltinFunctionInvalidArgumentsShouldErr(t *testi
 	}
 	vmOutput, err = nftTransfer.ProcessBuiltinFunction(&mock.UserAccountStub{}, &mock.UserAccountStub{}, vmInput)
 	assert.Nil(t, vmOutput)
-	assert.Equal(t, ErrInvalidArguments, err)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 
 	nftTransfer.shardCoordinator = &mock.ShardCoordinatorStub{ComputeIdCalled: func(address []byte) uint32 {
 		return core.MetachainShardId
@@ -458,7 +522,7 @@ func TestDctNFTTransfer_ProcessWithZeroValue(t *testing.T) {
 	vmInput.Arguments = append(vmInput.Arguments, scCallArgs...)
 
 	_, err = nftTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
-	require.Equal(t, err, ErrInvalidNFTQuantity)
+	require.True(t, errors.Is(err, ErrInvalidNFTQuantity))
 }
 
 func TestDctNFTTransfer_TransferValueLengthChecks(t *testing.T) {
@@ -503,7 +567,7 @@ func TestDctNFTTransfer_TransferValueLengthChecks(t *testing.T) {
 
 	// before flag activation
 	_, err = nftTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
-	require.Equal(t, err, ErrInvalidNFTQuantity)
+	require.True(t, errors.Is(err, ErrInvalidNFTQuantity))
 
 	// after flag activation
 	nftTransfer.enableEpochsHandler = &mock.EnableEpochsHandlerStub{
@@ -18,6 +18,8 @@ var zero = big.NewInt(0)
 
 type dctTransfer struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	funcGasCost           uint64
 	marshaller            vmcommon.Marshalizer
 	keyPrefix             []byte
@@ -56,6 +58,9 @@ func NewDCTTransferFunc(
 	}
 
 	e := &dctTransfer{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTTransfer
+		}),
 		funcGasCost:           funcGasCost,
 		marshaller:            marshaller,
 		keyPrefix:             []byte(baseDCTKeyPrefix),
@@ -88,6 +93,8 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
+	funcGasCost := e.activeFuncGasCost(e.funcGasCost)
+
 	err := checkBasicDCTArguments(vmInput)
 	if err != nil {
 		return nil, err
@@ -99,15 +106,16 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 
 	if e.enableEpochsHandler.IsConsistentTokensValuesLengthCheckEnabled() {
 		if len(vmInput.Arguments[1]) > core.MaxLenForDCTIssueMint {
-			return nil, fmt.Errorf("%w: max length for dct transfer value is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+			err = fmt.Errorf("%w: max length for dct transfer value is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+			return nil, newArgumentError(core.BuiltInFunctionDCTTransfer, err, 1)
 		}
 	}
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	if value.Cmp(zero) <= 0 {
-		return nil, ErrNegativeValue
+		return nil, newArgumentError(core.BuiltInFunctionDCTTransfer, ErrNegativeValue, 1)
 	}
 
-	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, e.funcGasCost)
+	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, funcGasCost)
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 	tokenID := vmInput.Arguments[0]
 
@@ -123,7 +131,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 
 	if !check.IfNil(acntSnd) {
 		// gas is paid only by sender
-		if vmInput.GasProvided < e.funcGasCost {
+		if vmInput.GasProvided < funcGasCost {
 			return nil, ErrNotEnoughGas
 		}
 
@@ -135,6 +143,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 
 	isSCCallAfter := e.payableHandler.DetermineIsSCCallAfter(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsDCTTransfer)
 	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
+	gasCharge := newBuiltInCostCharge("DCTTransfer", funcGasCost)
 	if !check.IfNil(acntDst) {
 		err = e.payableHandler.CheckPayable(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsDCTTransfer)
 		if err != nil {
@@ -147,7 +156,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 		}
 
 		if isSCCallAfter {
-			vmOutput.GasRemaining, _ = vmcommon.SafeSubUint64(vmInput.GasProvided, e.funcGasCost)
+			vmOutput.GasRemaining, _ = vmcommon.SafeSubUint64(vmInput.GasProvided, funcGasCost)
 			var callArgs [][]byte
 			if len(vmInput.Arguments) > core.MinLenArgumentsDCTTransfer+1 {
 				callArgs = vmInput.Arguments[core.MinLenArgumentsDCTTransfer+1:]
@@ -173,6 +182,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 					value,
 				}},
 			)
+			e.traceGas(vmInput, vmOutput, gasCharge)
 			return vmOutput, nil
 		}
 
@@ -190,6 +200,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 				0,
 				value,
 			}})
+		e.traceSenderGas(acntSnd, vmInput, vmOutput, gasCharge)
 		return vmOutput, nil
 	}
 
@@ -215,6 +226,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 			0,
 			value,
 		}})
+	e.traceSenderGas(acntSnd, vmInput, vmOutput, gasCharge)
 	return vmOutput, nil
 }
 
@@ -395,6 +407,19 @@ func checkIfTransferCanHappenWithLimitedTransfer(
 	return errDestination
 }
 
+// AccessSet returns the accounts and the system account keys the DCT transfer call touches
+func (e *dctTransfer) AccessSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.AccessSet, error) {
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+
+	accessSet := newAccountsAccessSet(vmInput.CallerAddr, vmInput.RecipientAddr)
+	addTokenSettingsReads(accessSet, vmInput.Arguments[0])
+
+	return accessSet, nil
+}
+
 // SetPayableChecker will set the payableCheck handler to the function
 func (e *dctTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
 	if check.IfNil(payableHandler) {
@@ -56,6 +56,14 @@ func NewDCTTransferRoleAddressFunc(
 	return e, nil
 }
 
+func (e *dctTransferAddress) functionName() string {
+	if e.set {
+		return vmcommon.BuiltInFunctionDCTTransferRoleAddAddress
+	}
+
+	return vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (e *dctTransferAddress) SetNewGasConfig(_ *vmcommon.GasCost) {
 }
@@ -129,7 +137,7 @@ func (e *dctTransferAddress) addNewAddresses(vmInput *vmcommon.ContractCallInput
 	}
 
 	if uint32(len(addresses.Roles)) > e.maxNumAddresses {
-		return ErrTooManyTransferAddresses
+		return newArgumentError(e.functionName(), ErrTooManyTransferAddresses)
 	}
 
 	return nil
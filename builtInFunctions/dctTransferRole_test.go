@@ -83,7 +83,7 @@ func TestDCTTransferRoleProcessBuiltInFunction_Errors(t *testing.T) {
 	}
 	e.maxNumAddresses = 1
 	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
-	assert.Equal(t, err, ErrTooManyTransferAddresses)
+	assert.True(t, errors.Is(err, ErrTooManyTransferAddresses))
 
 	e.maxNumAddresses = 10
 	marshaller.Fail = true
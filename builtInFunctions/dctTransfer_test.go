@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
@@ -61,6 +62,32 @@ func TestNewDCTTransferFunc(t *testing.T) {
 		assert.False(t, check.IfNil(transferFunc))
 	})
 }
+func TestDCTTransfer_AccessSet(t *testing.T) {
+	t.Parallel()
+
+	transferFunc, _ := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+	accessSet, err := transferFunc.AccessSet(nil)
+	assert.Nil(t, accessSet)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	sender := []byte("sender")
+	destination := []byte("destination")
+	accessSet, err = transferFunc.AccessSet(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{[]byte("token"), big.NewInt(10).Bytes()},
+		},
+		RecipientAddr: destination,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{{Address: sender}, {Address: destination}}, accessSet.Writes)
+	assert.Equal(t, []vmcommon.AccessKey{
+		{Address: vmcommon.SystemAccountAddress, Key: []byte(baseDCTKeyPrefix + "token")},
+		{Address: vmcommon.SystemAccountAddress, Key: append(append([]byte{}, transferAddressesKeyPrefix...), "token"...)},
+	}, accessSet.Reads)
+}
+
 func TestDCTTransfer_ProcessBuiltInFunctionErrors(t *testing.T) {
 	t.Parallel()
 
@@ -79,7 +106,7 @@ func TestDCTTransfer_ProcessBuiltInFunctionErrors(t *testing.T) {
 		},
 	}
 	_, err = transferFunc.ProcessBuiltinFunction(nil, nil, input)
-	assert.Equal(t, err, ErrInvalidArguments)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 
 	input = &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -9,6 +9,8 @@ import (
 
 type deleteUserName struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	gasCost         uint64
 	mapDnsAddresses map[string]struct{}
 	mutExecution    sync.RWMutex
@@ -28,6 +30,9 @@ func NewDeleteUserNameFunc(
 	}
 
 	d := &deleteUserName{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.SaveUserName
+		}),
 		gasCost:         gasCost,
 		mapDnsAddresses: make(map[string]struct{}, len(mapDnsAddresses)),
 	}
@@ -58,13 +63,22 @@ func (d *deleteUserName) ProcessBuiltinFunction(
 	d.mutExecution.RLock()
 	defer d.mutExecution.RUnlock()
 
-	err := inputCheckForUserNameCall(acntSnd, vmInput, d.mapDnsAddresses, d.gasCost, 0)
+	funcGasCost := d.activeFuncGasCost(d.gasCost)
+
+	err := inputCheckForUserNameCall(acntSnd, vmInput, d.mapDnsAddresses, funcGasCost, 0)
 	if err != nil {
 		return nil, err
 	}
 
+	gasCharge := newBuiltInCostCharge("SaveUserName", funcGasCost)
 	if check.IfNil(acntDst) {
-		return createCrossShardUserNameCall(vmInput, vmInput.Function, vmInput.GasProvided-d.gasCost)
+		vmOutput, errCall := createCrossShardUserNameCall(vmInput, vmInput.Function, vmInput.GasProvided-funcGasCost)
+		if errCall != nil {
+			return nil, errCall
+		}
+
+		d.traceSenderGas(acntSnd, vmInput, vmOutput, gasCharge)
+		return vmOutput, nil
 	}
 
 	oldUserName := acntDst.GetUserName()
@@ -72,13 +86,14 @@ func (d *deleteUserName) ProcessBuiltinFunction(
 
 	gasRemaining := vmInput.GasProvided
 	if !check.IfNil(acntSnd) {
-		gasRemaining = vmInput.GasProvided - d.gasCost
+		gasRemaining = vmInput.GasProvided - funcGasCost
 	}
 	vmOutput := &vmcommon.VMOutput{
 		GasRemaining: gasRemaining,
 		ReturnCode:   vmcommon.Ok,
 	}
 	addLogEntryForUserNameChange(vmInput, vmOutput, oldUserName)
+	d.traceSenderGas(acntSnd, vmInput, vmOutput, gasCharge)
 
 	return vmOutput, nil
 }
@@ -208,3 +208,39 @@ var ErrWrongUserNameSplit = errors.New("wrong user name split")
 
 // ErrUserNamePrefixNotEqual signals that user name prefix is not equal
 var ErrUserNamePrefixNotEqual = errors.New("user name prefix is not equal")
+
+// ErrOperationNotSupportedInSimulation signals that the accounts operation can not be used while simulating
+var ErrOperationNotSupportedInSimulation = errors.New("operation not supported in simulation")
+
+// ErrNilGasTracer signals that a nil gas tracer was provided
+var ErrNilGasTracer = errors.New("nil gas tracer")
+
+// ErrInvalidGasSchedule signals that the provided gas schedule is not valid
+var ErrInvalidGasSchedule = errors.New("invalid gas schedule")
+
+// ErrNilBuiltInFunctionConstructor signals that a nil built-in function constructor was provided
+var ErrNilBuiltInFunctionConstructor = errors.New("nil built-in function constructor")
+
+// ErrMissingGasCost signals that a gas cost is missing from the gas schedule
+var ErrMissingGasCost = errors.New("missing gas cost")
+
+// ErrNoBuiltInFunctionVersion signals that no version of a built-in function is available
+var ErrNoBuiltInFunctionVersion = errors.New("no built-in function version")
+
+// ErrDuplicateBuiltInFunctionVersion signals that two versions of a built-in function have the same activation epoch
+var ErrDuplicateBuiltInFunctionVersion = errors.New("duplicate built-in function version")
+
+// ErrNilEpochNotifier signals that a nil epoch notifier was provided
+var ErrNilEpochNotifier = errors.New("nil epoch notifier")
+
+// ErrAccessSetNotDeclared signals that a built-in function does not declare the state its calls touch
+var ErrAccessSetNotDeclared = errors.New("access set not declared")
+
+// ErrNilBuiltInFunctionContainer signals that a nil built-in function container was provided
+var ErrNilBuiltInFunctionContainer = errors.New("nil built-in function container")
+
+// ErrBuiltInFunctionFailed signals that a built-in function returned a failing return code
+var ErrBuiltInFunctionFailed = errors.New("built-in function failed")
+
+// ErrNilLogSubscriber signals that a nil log subscriber was provided
+var ErrNilLogSubscriber = errors.New("nil log subscriber")
@@ -0,0 +1,130 @@
+package builtInFunctions
+
+import (
+	"sync"
+	"sync/atomic"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// GasScheduleSnapshot is the gas cost of one gas schedule version. It must not be changed after it was published.
+type GasScheduleSnapshot struct {
+	// Version is 0 for the gas schedule given at creation and is increased by every applied gas schedule change.
+	Version uint64
+	GasCost *vmcommon.GasCost
+
+	// CustomGasCosts holds the gas costs of the custom built-in functions, by gas cost key.
+	CustomGasCosts map[string]uint64
+}
+
+// gasScheduleHolder holds the active gas schedule snapshot. The built-in functions sharing the holder load the
+// snapshot once per execution, so an execution never sees a mix of two versions and never waits for a swap.
+type gasScheduleHolder struct {
+	mutSwap  sync.Mutex
+	snapshot atomic.Pointer[GasScheduleSnapshot]
+}
+
+func newGasScheduleHolder(gasCost *vmcommon.GasCost, customGasCosts map[string]uint64) *gasScheduleHolder {
+	holder := &gasScheduleHolder{}
+	holder.snapshot.Store(&GasScheduleSnapshot{
+		GasCost:        gasCost,
+		CustomGasCosts: customGasCosts,
+	})
+
+	return holder
+}
+
+func (holder *gasScheduleHolder) activeSnapshot() *GasScheduleSnapshot {
+	return holder.snapshot.Load()
+}
+
+// swap publishes a new snapshot built from an already validated gas schedule and returns it
+func (holder *gasScheduleHolder) swap(gasCost *vmcommon.GasCost, customGasCosts map[string]uint64) *GasScheduleSnapshot {
+	holder.mutSwap.Lock()
+	defer holder.mutSwap.Unlock()
+
+	snapshot := &GasScheduleSnapshot{
+		Version:        holder.snapshot.Load().Version + 1,
+		GasCost:        gasCost,
+		CustomGasCosts: customGasCosts,
+	}
+	holder.snapshot.Store(snapshot)
+
+	return snapshot
+}
+
+// baseGasSchedule makes a built-in function read its gas costs from the shared gas schedule holder, if one was set.
+// A function with a holder charges only the costs of the active snapshot, never the ones set through SetNewGasConfig.
+type baseGasSchedule struct {
+	gasSchedule *gasScheduleHolder
+	costOf      func(builtInCost *vmcommon.BuiltInCost) uint64
+}
+
+// newBaseGasSchedule creates the base of a function whose own cost is selected from the built-in costs by costOf
+func newBaseGasSchedule(costOf func(builtInCost *vmcommon.BuiltInCost) uint64) baseGasSchedule {
+	return baseGasSchedule{
+		costOf: costOf,
+	}
+}
+
+// setGasScheduleHolder sets the shared gas schedule holder. It must be called before the function is used.
+func (b *baseGasSchedule) setGasScheduleHolder(holder *gasScheduleHolder) {
+	b.gasSchedule = holder
+}
+
+func (b *baseGasSchedule) hasGasScheduleHolder() bool {
+	return b.gasSchedule != nil
+}
+
+// loadGasCost returns the gas cost of the active snapshot, or nil if no holder was set
+func (b *baseGasSchedule) loadGasCost() *vmcommon.GasCost {
+	if b.gasSchedule == nil {
+		return nil
+	}
+
+	return b.gasSchedule.activeSnapshot().GasCost
+}
+
+// activeGasCost returns the cost of the function and the base operation cost to charge in one execution: the ones of
+// the active snapshot if a holder was set, otherwise the provided ones, set through SetNewGasConfig. It must be called
+// once per execution, so that an execution never mixes two gas schedules.
+func (b *baseGasSchedule) activeGasCost(
+	funcGasCost uint64,
+	baseOperationCost vmcommon.BaseOperationCost,
+) (uint64, vmcommon.BaseOperationCost) {
+	gasCost := b.loadGasCost()
+	if gasCost == nil {
+		return funcGasCost, baseOperationCost
+	}
+
+	return b.costOf(&gasCost.BuiltInCost), gasCost.BaseOperationCost
+}
+
+// activeFuncGasCost is activeGasCost for the functions which charge only their own cost
+func (b *baseGasSchedule) activeFuncGasCost(funcGasCost uint64) uint64 {
+	activeFuncGasCost, _ := b.activeGasCost(funcGasCost, vmcommon.BaseOperationCost{})
+	return activeFuncGasCost
+}
+
+// gasScheduleSubscriber is implemented by the built-in functions which read the shared gas schedule holder
+type gasScheduleSubscriber interface {
+	setGasScheduleHolder(holder *gasScheduleHolder)
+	hasGasScheduleHolder() bool
+}
+
+// setNewGasConfigIfNotSubscribed sets the gas cost on the functions which do not read the shared gas schedule holder.
+// The versions of a versioned function are handled one by one.
+func setNewGasConfigIfNotSubscribed(builtInFunc vmcommon.BuiltinFunction, gasCost *vmcommon.GasCost) {
+	switch function := builtInFunc.(type) {
+	case *versionedBuiltInFunction:
+		for _, version := range function.versions {
+			setNewGasConfigIfNotSubscribed(version.Function, gasCost)
+		}
+	case gasScheduleSubscriber:
+		if !function.hasGasScheduleHolder() {
+			builtInFunc.SetNewGasConfig(gasCost)
+		}
+	default:
+		builtInFunc.SetNewGasConfig(gasCost)
+	}
+}
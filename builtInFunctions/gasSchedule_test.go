@@ -0,0 +1,158 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func TestGasScheduleHolder_Swap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish a new snapshot without changing the loaded one", func(t *testing.T) {
+		t.Parallel()
+
+		initialGasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{DCTTransfer: 1}}
+		holder := newGasScheduleHolder(initialGasCost, nil)
+		loadedSnapshot := holder.activeSnapshot()
+
+		newGasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{DCTTransfer: 2}}
+		snapshot := holder.swap(newGasCost, map[string]uint64{"custom": 3})
+
+		assert.Equal(t, uint64(1), snapshot.Version)
+		assert.True(t, newGasCost == snapshot.GasCost)
+		assert.Equal(t, map[string]uint64{"custom": 3}, snapshot.CustomGasCosts)
+		assert.True(t, snapshot == holder.activeSnapshot())
+		assert.Equal(t, uint64(0), loadedSnapshot.Version)
+		assert.True(t, initialGasCost == loadedSnapshot.GasCost)
+	})
+	t.Run("concurrent swaps should get distinct versions", func(t *testing.T) {
+		t.Parallel()
+
+		holder := newGasScheduleHolder(&vmcommon.GasCost{}, nil)
+		numSwaps := 20
+		versions := make(chan uint64, numSwaps)
+		wg := sync.WaitGroup{}
+		wg.Add(numSwaps)
+		for i := 0; i < numSwaps; i++ {
+			go func() {
+				versions <- holder.swap(&vmcommon.GasCost{}, nil).Version
+				_ = holder.activeSnapshot().GasCost
+				wg.Done()
+			}()
+		}
+		wg.Wait()
+		close(versions)
+
+		seen := make(map[uint64]struct{})
+		for version := range versions {
+			seen[version] = struct{}{}
+		}
+		assert.Equal(t, numSwaps, len(seen))
+		assert.Equal(t, uint64(numSwaps), holder.activeSnapshot().Version)
+	})
+}
+
+func TestBaseGasSchedule_ShouldChargeTheCostOfTheActiveSnapshot(t *testing.T) {
+	t.Parallel()
+
+	changeOwnerFunc, _ := NewChangeOwnerAddressFunc(10, &mock.EnableEpochsHandlerStub{})
+	caller := []byte("caller")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("newOwn")},
+		},
+	}
+	acntSnd := mock.NewUserAccount(caller)
+
+	vmOutput, err := changeOwnerFunc.ProcessBuiltinFunction(acntSnd, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(40), vmOutput.GasRemaining)
+
+	holder := newGasScheduleHolder(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ChangeOwnerAddress: 20}}, nil)
+	changeOwnerFunc.setGasScheduleHolder(holder)
+	vmOutput, err = changeOwnerFunc.ProcessBuiltinFunction(acntSnd, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(30), vmOutput.GasRemaining)
+
+	_ = holder.swap(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ChangeOwnerAddress: 40}}, nil)
+	vmOutput, err = changeOwnerFunc.ProcessBuiltinFunction(acntSnd, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(10), vmOutput.GasRemaining)
+}
+
+func TestBuiltInFuncCreator_ChangeGasSchedule(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	b := createSimulationCreator(t, adb)
+
+	initialSnapshot := b.ActiveGasSchedule()
+	assert.Equal(t, uint64(0), initialSnapshot.Version)
+
+	t.Run("invalid gas schedule should not change the active one", func(t *testing.T) {
+		gasMap := createMockArguments().GasMap
+		gasMap[core.BuiltInCostString]["DCTTransfer"] = 0
+
+		snapshot, err := b.ChangeGasSchedule(gasMap)
+		assert.Nil(t, snapshot)
+		assert.ErrorIs(t, err, ErrInvalidGasSchedule)
+		assert.True(t, initialSnapshot == b.ActiveGasSchedule())
+	})
+	t.Run("valid gas schedule should be used by all the functions", func(t *testing.T) {
+		gasMap := createMockArguments().GasMap
+		fillGasMapInternal(gasMap, 7)
+
+		snapshot, err := b.ChangeGasSchedule(gasMap)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(1), snapshot.Version)
+		assert.Equal(t, uint64(7), snapshot.GasCost.BuiltInCost.DCTTransfer)
+		assert.True(t, snapshot == b.ActiveGasSchedule())
+
+		function, _ := b.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+		acntSnd := loadRecorderTestAccount(t, adb, alice)
+		acntDst := loadRecorderTestAccount(t, adb, bob)
+		vmOutput, err := function.ProcessBuiltinFunction(acntSnd, acntDst, createSimulationTransferInput(alice, bob, 1))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(100-7), vmOutput.GasRemaining)
+
+		result, err := b.SimulateBuiltInFunction(createSimulationTransferInput(alice, bob, 1))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(100-7), result.VMOutput.GasRemaining)
+	})
+	t.Run("only the functions which do not read the snapshot should get the gas cost set", func(t *testing.T) {
+		var receivedGasCost *vmcommon.GasCost
+		err := b.BuiltInFunctionContainer().Add("stubFunction", &mock.BuiltInFunctionStub{
+			SetNewGasConfigCalled: func(gasCost *vmcommon.GasCost) {
+				receivedGasCost = gasCost
+			},
+		})
+		require.Nil(t, err)
+
+		function, _ := b.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+		transferFunc := function.(*dctTransfer)
+		funcGasCostBefore := transferFunc.funcGasCost
+
+		gasMap := createMockArguments().GasMap
+		fillGasMapInternal(gasMap, 9)
+		snapshot, err := b.ChangeGasSchedule(gasMap)
+		require.Nil(t, err)
+
+		assert.True(t, snapshot.GasCost == receivedGasCost)
+		assert.Equal(t, funcGasCostBefore, transferFunc.funcGasCost)
+		assert.Equal(t, uint64(9), transferFunc.activeFuncGasCost(transferFunc.funcGasCost))
+	})
+}
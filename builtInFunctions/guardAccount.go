@@ -27,7 +27,8 @@ func (fa *guardAccountFunc) ProcessBuiltinFunction(
 	fa.mutExecution.Lock()
 	defer fa.mutExecution.Unlock()
 
-	err := fa.checkGuardAccountArgs(acntSnd, vmInput)
+	funcGasCost := fa.activeFuncGasCost(fa.funcGasCost)
+	err := fa.checkGuardAccountArgs(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
@@ -44,11 +45,14 @@ func (fa *guardAccountFunc) ProcessBuiltinFunction(
 		Identifier: []byte(core.BuiltInFunctionGuardAccount),
 	}
 
-	return &vmcommon.VMOutput{
+	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - fa.funcGasCost,
+		GasRemaining: vmInput.GasProvided - funcGasCost,
 		Logs:         []*vmcommon.LogEntry{entry},
-	}, nil
+	}
+	fa.traceGas(vmInput, vmOutput, newBuiltInCostCharge("GuardAccount", funcGasCost))
+
+	return vmOutput, nil
 }
 
 func guardAccount(account vmcommon.UserAccountHandler) error {
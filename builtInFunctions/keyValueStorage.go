@@ -13,6 +13,8 @@ import (
 
 type saveKeyValueStorage struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	gasConfig           vmcommon.BaseOperationCost
 	funcGasCost         uint64
 	mutExecution        sync.RWMutex
@@ -30,6 +32,9 @@ func NewSaveKeyValueStorageFunc(
 	}
 
 	s := &saveKeyValueStorage{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.SaveKeyValue
+		}),
 		gasConfig:           gasConfig,
 		funcGasCost:         funcGasCost,
 		enableEpochsHandler: enableEpochsHandler,
@@ -58,6 +63,8 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 	k.mutExecution.RLock()
 	defer k.mutExecution.RUnlock()
 
+	funcGasCost, gasConfig := k.activeGasCost(k.funcGasCost, k.gasConfig)
+
 	errCheck := checkArgumentsForSaveKeyValue(acntDest, input)
 	if errCheck != nil {
 		return nil, errCheck
@@ -68,12 +75,15 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 		GasRefund:    big.NewInt(0),
 	}
 
-	useGas := k.funcGasCost
+	useGas := funcGasCost
+	persistedLength := uint64(0)
+	storedLength := uint64(0)
 	for i := 0; i < len(input.Arguments); i += 2 {
 		key := input.Arguments[i]
 		value := input.Arguments[i+1]
 		length := uint64(len(value) + len(key))
-		useGas += length * k.gasConfig.PersistPerByte
+		useGas += length * gasConfig.PersistPerByte
+		persistedLength += length
 
 		if !vmcommon.IsAllowedToSaveUnderKey(key) {
 			return nil, fmt.Errorf("%w it is not allowed to save under key %s", ErrOperationNotPermitted, key)
@@ -94,7 +104,8 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 			lengthChange = lengthNewValue - lengthOldValue
 		}
 
-		useGas += k.gasConfig.StorePerByte * lengthChange
+		useGas += gasConfig.StorePerByte * lengthChange
+		storedLength += lengthChange
 		if input.GasProvided < useGas {
 			return nil, ErrNotEnoughGas
 		}
@@ -107,7 +118,19 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 		}
 	}
 
-	return k.subtractGasFromVMoutput(vmOutput, useGas)
+	vmOutput, err := k.subtractGasFromVMoutput(vmOutput, useGas)
+	if err != nil {
+		return nil, err
+	}
+	k.traceGas(
+		input,
+		vmOutput,
+		newBuiltInCostCharge("SaveKeyValue", funcGasCost),
+		newBaseOperationCostCharge("PersistPerByte", persistedLength, gasConfig.PersistPerByte),
+		newBaseOperationCostCharge("StorePerByte", storedLength, gasConfig.StorePerByte),
+	)
+
+	return vmOutput, nil
 }
 
 func (k *saveKeyValueStorage) subtractGasFromVMoutput(vmOutput *vmcommon.VMOutput, usedGas uint64) (*vmcommon.VMOutput, error) {
@@ -0,0 +1,266 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// LiquidityMismatchKind describes why the balances of a token instance do not match the system account
+type LiquidityMismatchKind string
+
+const (
+	// LiquidityMismatch signals that the liquidity recorded on the system account differs from the sum of balances
+	LiquidityMismatch LiquidityMismatchKind = "liquidity mismatch"
+	// MissingLiquidityRecord signals that a token instance is held by accounts but the system account has no record
+	MissingLiquidityRecord LiquidityMismatchKind = "missing liquidity record"
+	// UntrackedLiquidity signals that the system account record of a held token instance does not track the
+	// liquidity, as for the old tokens whose reserved field was set to nil
+	UntrackedLiquidity LiquidityMismatchKind = "untracked liquidity"
+	// LegacyMetaDataOnAccount signals that a holder keeps the token metadata on its own account, as the tokens last
+	// moved before the metadata was saved on the system account
+	LegacyMetaDataOnAccount LiquidityMismatchKind = "legacy metadata on account"
+)
+
+// ArgsLiquidityChecker defines the arguments needed to create a liquidity checker
+type ArgsLiquidityChecker struct {
+	Accounts    vmcommon.AccountsIterator
+	Marshalizer vmcommon.Marshalizer
+}
+
+// TokenSupply holds the sum of balances of a token instance over all the accounts and its system account record.
+// Liquidity is nil if the system account has no record of the token instance.
+type TokenSupply struct {
+	TokenID            []byte
+	Nonce              uint64
+	Balance            *big.Int
+	NumHolders         int
+	Liquidity          *big.Int
+	IsLiquidityTracked bool
+}
+
+// TokenLiquidityMismatch describes a token instance whose balances do not match the system account record
+type TokenLiquidityMismatch struct {
+	TokenID   []byte
+	Nonce     uint64
+	Kind      LiquidityMismatchKind
+	Balance   *big.Int
+	Liquidity *big.Int
+}
+
+// String returns the mismatch in a human-readable form
+func (m *TokenLiquidityMismatch) String() string {
+	return fmt.Sprintf("%s for %s-%d: balance %s, liquidity %s", m.Kind, m.TokenID, m.Nonce, m.Balance, vmcommon.ZeroValueIfNil(m.Liquidity))
+}
+
+// LiquidityReport holds the supplies of all the token instances and the mismatches found, both sorted by token
+// identifier and nonce. The fungible tokens have no liquidity record, so they are only part of the supplies.
+type LiquidityReport struct {
+	Supplies   []*TokenSupply
+	Mismatches []*TokenLiquidityMismatch
+}
+
+// HasMismatches returns true if any mismatch was found
+func (r *LiquidityReport) HasMismatches() bool {
+	return len(r.Mismatches) > 0
+}
+
+type tokenInstanceKey struct {
+	tokenID string
+	nonce   uint64
+}
+
+type tokenInstanceState struct {
+	supply               *TokenSupply
+	hasLegacyHolderData  bool
+	hasSystemAccountData bool
+}
+
+type liquidityChecker struct {
+	accounts   vmcommon.AccountsIterator
+	marshaller vmcommon.Marshalizer
+}
+
+// NewLiquidityChecker creates a component which compares the token balances of all the accounts against the
+// liquidity records kept on the system account
+func NewLiquidityChecker(args ArgsLiquidityChecker) (*liquidityChecker, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+
+	return &liquidityChecker{
+		accounts:   args.Accounts,
+		marshaller: args.Marshalizer,
+	}, nil
+}
+
+// Check walks all the accounts, sums the balances of every token instance and compares them with the liquidity
+// records of the system account
+func (lc *liquidityChecker) Check() (*LiquidityReport, error) {
+	instances := make(map[tokenInstanceKey]*tokenInstanceState)
+	for _, address := range lc.accounts.Addresses() {
+		err := lc.addAccount(instances, address)
+		if err != nil {
+			return nil, fmt.Errorf("%w for account %x", err, address)
+		}
+	}
+
+	keys := make([]tokenInstanceKey, 0, len(instances))
+	for key := range instances {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tokenID != keys[j].tokenID {
+			return keys[i].tokenID < keys[j].tokenID
+		}
+		return keys[i].nonce < keys[j].nonce
+	})
+
+	report := &LiquidityReport{
+		Supplies:   make([]*TokenSupply, 0, len(keys)),
+		Mismatches: make([]*TokenLiquidityMismatch, 0),
+	}
+	for _, key := range keys {
+		instance := instances[key]
+		report.Supplies = append(report.Supplies, instance.supply)
+		report.Mismatches = append(report.Mismatches, instance.mismatches()...)
+	}
+
+	return report, nil
+}
+
+func (lc *liquidityChecker) addAccount(instances map[tokenInstanceKey]*tokenInstanceState, address []byte) error {
+	state, err := lc.accounts.GetAllState(address)
+	if err != nil {
+		return err
+	}
+
+	isSystemAccount := bytes.Equal(address, vmcommon.SystemAccountAddress)
+	for key, value := range state {
+		tokenID, nonce, ok := splitDCTTokenKey(key)
+		if !ok || len(value) == 0 {
+			continue
+		}
+		if isSystemAccount && nonce == 0 {
+			// the system account keeps the global settings of the tokens under the same keys
+			continue
+		}
+
+		dctData := &dct.DCToken{}
+		err = lc.marshaller.Unmarshal(dctData, value)
+		if err != nil {
+			return fmt.Errorf("%w while decoding token %s-%d", err, tokenID, nonce)
+		}
+
+		instance := getTokenInstanceState(instances, tokenID, nonce)
+		if isSystemAccount {
+			instance.hasSystemAccountData = true
+			instance.supply.Liquidity = vmcommon.ZeroValueIfNil(dctData.Value)
+			instance.supply.IsLiquidityTracked = len(dctData.Reserved) > 0
+			continue
+		}
+
+		instance.supply.Balance.Add(instance.supply.Balance, vmcommon.ZeroValueIfNil(dctData.Value))
+		instance.supply.NumHolders++
+		if nonce > 0 && dctData.TokenMetaData != nil {
+			instance.hasLegacyHolderData = true
+		}
+	}
+
+	return nil
+}
+
+func getTokenInstanceState(instances map[tokenInstanceKey]*tokenInstanceState, tokenID string, nonce uint64) *tokenInstanceState {
+	key := tokenInstanceKey{tokenID: tokenID, nonce: nonce}
+	instance, found := instances[key]
+	if !found {
+		instance = &tokenInstanceState{
+			supply: &TokenSupply{
+				TokenID: []byte(tokenID),
+				Nonce:   nonce,
+				Balance: big.NewInt(0),
+			},
+		}
+		instances[key] = instance
+	}
+
+	return instance
+}
+
+func (instance *tokenInstanceState) mismatches() []*TokenLiquidityMismatch {
+	supply := instance.supply
+	if supply.Nonce == 0 {
+		return nil
+	}
+
+	kinds := make([]LiquidityMismatchKind, 0)
+	isHeld := supply.Balance.Sign() > 0
+	switch {
+	case !instance.hasSystemAccountData:
+		if instance.hasLegacyHolderData {
+			kinds = append(kinds, LegacyMetaDataOnAccount)
+		} else if isHeld {
+			kinds = append(kinds, MissingLiquidityRecord)
+		}
+	case !supply.IsLiquidityTracked:
+		if isHeld || supply.Liquidity.Sign() != 0 {
+			kinds = append(kinds, UntrackedLiquidity)
+		}
+	default:
+		if instance.hasLegacyHolderData {
+			kinds = append(kinds, LegacyMetaDataOnAccount)
+		}
+		if supply.Liquidity.Cmp(supply.Balance) != 0 {
+			kinds = append(kinds, LiquidityMismatch)
+		}
+	}
+
+	mismatches := make([]*TokenLiquidityMismatch, 0, len(kinds))
+	for _, kind := range kinds {
+		mismatches = append(mismatches, &TokenLiquidityMismatch{
+			TokenID:   supply.TokenID,
+			Nonce:     supply.Nonce,
+			Kind:      kind,
+			Balance:   supply.Balance,
+			Liquidity: supply.Liquidity,
+		})
+	}
+
+	return mismatches
+}
+
+// splitDCTTokenKey extracts the token identifier and the nonce from a DCT storage key. The token identifier is
+// the ticker, the separator and the random sequence, the nonce is encoded by the remaining bytes.
+func splitDCTTokenKey(key string) (string, uint64, bool) {
+	if !strings.HasPrefix(key, baseDCTKeyPrefix) {
+		return "", 0, false
+	}
+
+	tokenKey := key[len(baseDCTKeyPrefix):]
+	separatorIndex := strings.Index(tokenKey, dctIdentifierSeparator)
+	tokenIDLength := separatorIndex + len(dctIdentifierSeparator) + dctRandomSequenceLength
+	if separatorIndex <= 0 || len(tokenKey) < tokenIDLength {
+		return "", 0, false
+	}
+
+	nonce := big.NewInt(0).SetBytes([]byte(tokenKey[tokenIDLength:]))
+	if !nonce.IsUint64() {
+		return "", 0, false
+	}
+
+	return tokenKey[:tokenIDLength], nonce.Uint64(), true
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (lc *liquidityChecker) IsInterfaceNil() bool {
+	return lc == nil
+}
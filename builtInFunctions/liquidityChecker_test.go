@@ -0,0 +1,162 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func saveLiquidityCheckerToken(t *testing.T, adb *inMemoryState.AccountsAdapter, address []byte, tokenID string, nonce uint64, dctData *dct.DCToken) {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	marshalledData, err := (&mock.MarshalizerMock{}).Marshal(dctData)
+	require.Nil(t, err)
+
+	key := []byte(baseDCTKeyPrefix + tokenID)
+	if nonce > 0 {
+		key = computeDCTNFTTokenKey(key, nonce)
+	}
+	userAccount := account.(vmcommon.UserAccountHandler)
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue(key, marshalledData))
+	require.Nil(t, adb.SaveAccount(userAccount))
+}
+
+func createLiquidityChecker(t *testing.T, adb *inMemoryState.AccountsAdapter) *liquidityChecker {
+	lc, err := NewLiquidityChecker(ArgsLiquidityChecker{
+		Accounts:    adb,
+		Marshalizer: &mock.MarshalizerMock{},
+	})
+	require.Nil(t, err)
+
+	return lc
+}
+
+func TestNewLiquidityChecker(t *testing.T) {
+	t.Parallel()
+
+	lc, err := NewLiquidityChecker(ArgsLiquidityChecker{Marshalizer: &mock.MarshalizerMock{}})
+	assert.Nil(t, lc)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	lc, err = NewLiquidityChecker(ArgsLiquidityChecker{Accounts: inMemoryState.NewAccountsAdapter()})
+	assert.Nil(t, lc)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	lc, err = NewLiquidityChecker(ArgsLiquidityChecker{
+		Accounts:    inMemoryState.NewAccountsAdapter(),
+		Marshalizer: &mock.MarshalizerMock{},
+	})
+	assert.Nil(t, err)
+	assert.False(t, lc.IsInterfaceNil())
+}
+
+func TestLiquidityChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	metaData := &dct.MetaData{Name: []byte("name")}
+	nftData := func(value int64) *dct.DCToken {
+		return &dct.DCToken{Type: uint32(core.NonFungible), Value: big.NewInt(value)}
+	}
+	liquidityRecord := func(value int64) *dct.DCToken {
+		return &dct.DCToken{Type: uint32(core.NonFungible), Value: big.NewInt(value), TokenMetaData: metaData, Reserved: []byte{1}}
+	}
+
+	t.Run("matching state should not report mismatches", func(t *testing.T) {
+		t.Parallel()
+
+		adb := inMemoryState.NewAccountsAdapter()
+		saveLiquidityCheckerToken(t, adb, alice, "FUNG-aaaaaa", 0, &dct.DCToken{Value: big.NewInt(7)})
+		saveLiquidityCheckerToken(t, adb, bob, "FUNG-aaaaaa", 0, &dct.DCToken{Value: big.NewInt(3)})
+		saveLiquidityCheckerToken(t, adb, vmcommon.SystemAccountAddress, "FUNG-aaaaaa", 0, &dct.DCToken{Value: big.NewInt(100)})
+		saveLiquidityCheckerToken(t, adb, alice, "SEMI-bbbbbb", 45, nftData(10))
+		saveLiquidityCheckerToken(t, adb, bob, "SEMI-bbbbbb", 45, nftData(5))
+		saveLiquidityCheckerToken(t, adb, vmcommon.SystemAccountAddress, "SEMI-bbbbbb", 45, liquidityRecord(15))
+
+		report, err := createLiquidityChecker(t, adb).Check()
+		require.Nil(t, err)
+		assert.False(t, report.HasMismatches())
+		assert.Equal(t, []*TokenSupply{
+			{TokenID: []byte("FUNG-aaaaaa"), Balance: big.NewInt(10), NumHolders: 2},
+			{TokenID: []byte("SEMI-bbbbbb"), Nonce: 45, Balance: big.NewInt(15), NumHolders: 2, Liquidity: big.NewInt(15), IsLiquidityTracked: true},
+		}, report.Supplies)
+	})
+	t.Run("mismatches should be reported", func(t *testing.T) {
+		t.Parallel()
+
+		adb := inMemoryState.NewAccountsAdapter()
+		saveLiquidityCheckerToken(t, adb, alice, "DIFF-aaaaaa", 1, nftData(10))
+		saveLiquidityCheckerToken(t, adb, vmcommon.SystemAccountAddress, "DIFF-aaaaaa", 1, liquidityRecord(12))
+
+		saveLiquidityCheckerToken(t, adb, alice, "MISS-bbbbbb", 1, nftData(1))
+
+		saveLiquidityCheckerToken(t, adb, alice, "OLDT-cccccc", 2, nftData(4))
+		saveLiquidityCheckerToken(t, adb, vmcommon.SystemAccountAddress, "OLDT-cccccc", 2, &dct.DCToken{Value: big.NewInt(0), TokenMetaData: metaData})
+
+		saveLiquidityCheckerToken(t, adb, alice, "LEGA-dddddd", 3, &dct.DCToken{Value: big.NewInt(2), TokenMetaData: metaData})
+		saveLiquidityCheckerToken(t, adb, bob, "LEGA-dddddd", 3, nftData(3))
+		saveLiquidityCheckerToken(t, adb, vmcommon.SystemAccountAddress, "LEGA-dddddd", 3, liquidityRecord(3))
+
+		saveLiquidityCheckerToken(t, adb, vmcommon.SystemAccountAddress, "GONE-eeeeee", 1, liquidityRecord(8))
+
+		report, err := createLiquidityChecker(t, adb).Check()
+		require.Nil(t, err)
+		assert.True(t, report.HasMismatches())
+
+		descriptions := make([]string, 0, len(report.Mismatches))
+		for _, mismatch := range report.Mismatches {
+			descriptions = append(descriptions, mismatch.String())
+		}
+		assert.Equal(t, []string{
+			"liquidity mismatch for DIFF-aaaaaa-1: balance 10, liquidity 12",
+			"liquidity mismatch for GONE-eeeeee-1: balance 0, liquidity 8",
+			"legacy metadata on account for LEGA-dddddd-3: balance 5, liquidity 3",
+			"liquidity mismatch for LEGA-dddddd-3: balance 5, liquidity 3",
+			"missing liquidity record for MISS-bbbbbb-1: balance 1, liquidity 0",
+			"untracked liquidity for OLDT-cccccc-2: balance 4, liquidity 0",
+		}, descriptions)
+	})
+	t.Run("invalid token data should error", func(t *testing.T) {
+		t.Parallel()
+
+		adb := inMemoryState.NewAccountsAdapter()
+		saveLiquidityCheckerToken(t, adb, alice, "FUNG-aaaaaa", 1, nftData(10))
+		account, _ := adb.LoadAccount(bob)
+		userAccount := account.(vmcommon.UserAccountHandler)
+		require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue([]byte(baseDCTKeyPrefix+"FUNG-aaaaaa"), []byte("invalid")))
+		require.Nil(t, adb.SaveAccount(userAccount))
+
+		lc := createLiquidityChecker(t, adb)
+		report, err := lc.Check()
+		assert.Nil(t, report)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSplitDCTTokenKey(t *testing.T) {
+	t.Parallel()
+
+	tokenID, nonce, ok := splitDCTTokenKey(baseDCTKeyPrefix + "FUNG-aaaaaa")
+	assert.True(t, ok)
+	assert.Equal(t, "FUNG-aaaaaa", tokenID)
+	assert.Equal(t, uint64(0), nonce)
+
+	tokenID, nonce, ok = splitDCTTokenKey(string(computeDCTNFTTokenKey([]byte(baseDCTKeyPrefix+"SEMI-bbbbbb"), 0x2d2d)))
+	assert.True(t, ok)
+	assert.Equal(t, "SEMI-bbbbbb", tokenID)
+	assert.Equal(t, uint64(0x2d2d), nonce)
+
+	for _, key := range []string{"", baseDCTKeyPrefix, baseDCTKeyPrefix + "-abcdef", baseDCTKeyPrefix + "FUNG-abc", string(roleKeyPrefix) + "FUNG-aaaaaa"} {
+		_, _, ok = splitDCTTokenKey(key)
+		assert.False(t, ok, key)
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 type migrateDataTrie struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	accounts     vmcommon.AccountsAdapter
 	builtInCost  vmcommon.BuiltInCost
 	mutExecution sync.RWMutex
@@ -83,6 +85,12 @@ func (mdt *migrateDataTrie) ProcessBuiltinFunction(
 		GasRemaining: dtm.GetGasRemaining(),
 		ReturnCode:   vmcommon.Ok,
 	}
+	mdt.traceGas(
+		vmInput,
+		vmOutput,
+		newGasCharge(core.BuiltInCostString, "TrieLoadPerNode", dtm.GetNumLoadedNodes(), dataTrieGasCost.TrieLoadPerNode),
+		newGasCharge(core.BuiltInCostString, "TrieStorePerNode", uint64(len(dtm.GetLeavesToBeMigrated())), dataTrieGasCost.TrieStorePerNode),
+	)
 
 	return vmOutput, nil
 }
@@ -130,6 +138,9 @@ func (mdt *migrateDataTrie) getGasCostForDataTrieLoadAndStore() dataTrieMigrator
 	mdt.mutExecution.RLock()
 	builtInCost := mdt.builtInCost
 	mdt.mutExecution.RUnlock()
+	if gasCost := mdt.loadGasCost(); gasCost != nil {
+		builtInCost = gasCost.BuiltInCost
+	}
 
 	dataTrieGasCost := dataTrieMigrator.DataTrieGasCost{
 		TrieLoadPerNode:  builtInCost.TrieLoadPerNode,
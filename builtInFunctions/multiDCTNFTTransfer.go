@@ -15,6 +15,8 @@ import (
 
 type dctNFTMultiTransfer struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
@@ -66,6 +68,9 @@ func NewDCTNFTMultiTransferFunc(
 	}
 
 	e := &dctNFTMultiTransfer{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTMultiTransfer
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		marshaller:            marshaller,
 		globalSettingsHandler: globalSettingsHandler,
@@ -85,6 +90,54 @@ func NewDCTNFTMultiTransferFunc(
 	return e, nil
 }
 
+// AccessSet returns the accounts and the system account keys the multi transfer call touches. On the sender
+// shard, the destination is taken from the arguments, as it is loaded and saved if in the same shard.
+func (e *dctNFTMultiTransfer) AccessSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.AccessSet, error) {
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) < 4 {
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, ErrInvalidArguments)
+	}
+
+	dstAddress := vmInput.RecipientAddr
+	startIndex := uint64(1)
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		dstAddress = destinationFromArgument(vmInput, 0)
+		startIndex = 2
+	}
+
+	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[startIndex-1]).Uint64()
+	if numOfTransfers == 0 {
+		err = fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, int(startIndex-1))
+	}
+	if (uint64(len(vmInput.Arguments))-startIndex)/argumentsPerTransfer < numOfTransfers {
+		err = fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, int(startIndex-1))
+	}
+
+	accessSet := newAccountsAccessSet(vmInput.CallerAddr, dstAddress)
+	access := &nftTransferAccess{
+		accounts:            e.accounts,
+		marshaller:          e.marshaller,
+		shardCoordinator:    e.shardCoordinator,
+		enableEpochsHandler: e.enableEpochsHandler,
+	}
+	for i := uint64(0); i < numOfTransfers; i++ {
+		tokenStartIndex := startIndex + i*argumentsPerTransfer
+		tokenID := vmInput.Arguments[tokenStartIndex]
+		nonce := nonceFromArgument(vmInput.Arguments[tokenStartIndex+1])
+		err = access.addTokenAccess(accessSet, tokenID, nonce, vmInput.CallerAddr, dstAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return accessSet, nil
+}
+
 // SetPayableChecker will set the payableCheck handler to the function
 func (e *dctNFTMultiTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
 	if check.IfNil(payableHandler) {
@@ -124,16 +177,18 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
+	funcGasCost, gasConfig := e.activeGasCost(e.funcGasCost, e.gasConfig)
+
 	err := checkBasicDCTArguments(vmInput)
 	if err != nil {
 		return nil, err
 	}
 	if len(vmInput.Arguments) < 4 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, ErrInvalidArguments)
 	}
 
 	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
-		return e.processDCTNFTMultiTransferOnSenderShard(acntSnd, vmInput)
+		return e.processDCTNFTMultiTransferOnSenderShard(acntSnd, vmInput, funcGasCost, gasConfig)
 	}
 
 	// in cross shard NFT transfer the sender account must be nil
@@ -146,11 +201,13 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 
 	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[0]).Uint64()
 	if numOfTransfers == 0 {
-		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+		err = fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, 0)
 	}
 	minNumOfArguments := numOfTransfers*argumentsPerTransfer + 1
 	if uint64(len(vmInput.Arguments)) < minNumOfArguments {
-		return nil, fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+		err = fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, 0)
 	}
 
 	vmOutput := &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided}
@@ -177,7 +234,8 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 				marshaledNFTTransfer := vmInput.Arguments[tokenStartIndex+2]
 				err = e.marshaller.Unmarshal(dctTransferData, marshaledNFTTransfer)
 				if err != nil {
-					return nil, fmt.Errorf("%w for token %s", err, string(tokenID))
+					err = fmt.Errorf("%w for token %s", err, string(tokenID))
+					return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, int(tokenStartIndex+2))
 				}
 			} else {
 				dctTransferData.Value = big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+2])
@@ -256,13 +314,17 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
+	funcGasCost uint64,
+	gasConfig vmcommon.BaseOperationCost,
 ) (*vmcommon.VMOutput, error) {
 	dstAddress := vmInput.Arguments[0]
 	if len(dstAddress) != len(vmInput.CallerAddr) {
-		return nil, fmt.Errorf("%w, not a valid destination address", ErrInvalidArguments)
+		err := fmt.Errorf("%w, not a valid destination address", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, 0)
 	}
 	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
-		return nil, fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
+		err := fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, 0)
 	}
 	isTransferToMetaFlagEnabled := e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
 	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(dstAddress) == core.MetachainShardId && !isTransferToMetaFlagEnabled
@@ -271,14 +333,16 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	}
 	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
 	if numOfTransfers == 0 {
-		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+		err := fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, 1)
 	}
 	minNumOfArguments := numOfTransfers*argumentsPerTransfer + 2
 	if uint64(len(vmInput.Arguments)) < minNumOfArguments {
-		return nil, fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+		err := fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+		return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, 1)
 	}
 
-	multiTransferCost := numOfTransfers * e.funcGasCost
+	multiTransferCost := numOfTransfers * funcGasCost
 	if vmInput.GasProvided < multiTransferCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -309,7 +373,8 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	for i := uint64(0); i < numOfTransfers; i++ {
 		tokenStartIndex := startIndex + i*argumentsPerTransfer
 		if len(vmInput.Arguments[tokenStartIndex+2]) > core.MaxLenForDCTIssueMint && e.enableEpochsHandler.IsConsistentTokensValuesLengthCheckEnabled() {
-			return nil, fmt.Errorf("%w: max length for a transfer value is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+			err = fmt.Errorf("%w: max length for a transfer value is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+			return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, int(tokenStartIndex+2))
 		}
 		listTransferData[i] = &vmcommon.DCTTransfer{
 			DCTValue:      big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+2]),
@@ -331,7 +396,8 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 			return nil, err
 		}
 		if err != nil {
-			return nil, fmt.Errorf("%w for token %s", err, string(listTransferData[i].DCTTokenName))
+			err = fmt.Errorf("%w for token %s", err, string(listTransferData[i].DCTTokenName))
+			return nil, newArgumentError(core.BuiltInFunctionMultiDCTNFTTransfer, err, int(tokenStartIndex+2))
 		}
 
 		if e.enableEpochsHandler.IsScToScEventLogEnabled() {
@@ -368,10 +434,16 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 		}
 	}
 
-	err = e.createDCTNFTOutputTransfers(vmInput, vmOutput, listDctData, listTransferData, dstAddress)
+	copiedDataLength, err := e.createDCTNFTOutputTransfers(vmInput, vmOutput, listDctData, listTransferData, dstAddress, gasConfig.DataCopyPerByte)
 	if err != nil {
 		return nil, err
 	}
+	e.traceGas(
+		vmInput,
+		vmOutput,
+		newGasCharge(core.BuiltInCostString, "DCTNFTMultiTransfer", numOfTransfers, funcGasCost),
+		newBaseOperationCostCharge("DataCopyPerByte", copiedDataLength, gasConfig.DataCopyPerByte),
+	)
 
 	return vmOutput, nil
 }
@@ -462,10 +534,12 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 	listDCTData []*dct.DCToken,
 	listDCTTransfers []*vmcommon.DCTTransfer,
 	dstAddress []byte,
-) error {
+	dataCopyPerByte uint64,
+) (uint64, error) {
 	multiTransferCallArgs := make([][]byte, 0, argumentsPerTransfer*uint64(len(listDCTTransfers))+1)
 	numTokenTransfer := big.NewInt(int64(len(listDCTTransfers))).Bytes()
 	multiTransferCallArgs = append(multiTransferCallArgs, numTokenTransfer)
+	copiedDataLength := uint64(0)
 
 	for i, dctTransfer := range listDCTTransfers {
 		multiTransferCallArgs = append(multiTransferCallArgs, dctTransfer.DCTTokenName)
@@ -478,7 +552,7 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 		if dctTransfer.DCTTokenNonce > 0 {
 			wasAlreadySent, err := e.dctStorageHandler.WasAlreadySentToDestinationShardAndUpdateState(dctTransfer.DCTTokenName, dctTransfer.DCTTokenNonce, dstAddress)
 			if err != nil {
-				return err
+				return 0, err
 			}
 
 			sendCrossShardAsMarshalledData := !wasAlreadySent || dctTransfer.DCTValue.Cmp(oneValue) == 0 ||
@@ -486,14 +560,15 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 			if sendCrossShardAsMarshalledData {
 				marshaledNFTTransfer, err := e.marshaller.Marshal(listDCTData[i])
 				if err != nil {
-					return err
+					return 0, err
 				}
 
-				gasForTransfer := uint64(len(marshaledNFTTransfer)) * e.gasConfig.DataCopyPerByte
+				gasForTransfer := uint64(len(marshaledNFTTransfer)) * dataCopyPerByte
 				if gasForTransfer > vmOutput.GasRemaining {
-					return ErrNotEnoughGas
+					return 0, ErrNotEnoughGas
 				}
 				vmOutput.GasRemaining -= gasForTransfer
+				copiedDataLength += uint64(len(marshaledNFTTransfer))
 
 				multiTransferCallArgs = append(multiTransferCallArgs, marshaledNFTTransfer)
 			} else {
@@ -530,7 +605,7 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 			vmOutput,
 		)
 
-		return nil
+		return copiedDataLength, nil
 	}
 
 	if isSCCallAfter {
@@ -550,7 +625,7 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 			vmOutput)
 	}
 
-	return nil
+	return copiedDataLength, nil
 }
 
 func (e *dctNFTMultiTransfer) addNFTToDestination(
@@ -275,6 +275,67 @@ func TestDCTNFTMultiTransfer_SetNewGasConfig(t *testing.T) {
 	assert.Equal(t, gasCost.BaseOperationCost, multiTransfer.gasConfig)
 }
 
+func TestDCTNFTMultiTransfer_AccessSet(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createDCTNFTMultiTransferWithStubArguments()
+	sender := bytes.Repeat([]byte{1}, 32)
+	destination := bytes.Repeat([]byte{2}, 32)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender,
+			CallValue:  big.NewInt(0),
+			Arguments: [][]byte{destination, big.NewInt(2).Bytes(),
+				[]byte("token1"), big.NewInt(0).Bytes(), big.NewInt(1).Bytes(),
+				[]byte("token2"), big.NewInt(3).Bytes()},
+		},
+		RecipientAddr: sender,
+	}
+
+	accessSet, err := multiTransfer.AccessSet(vmInput)
+	assert.Nil(t, accessSet)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	assert.Equal(t, []int{1}, err.(*BuiltInFunctionError).ArgumentIndices)
+
+	multiTransfer.enableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSaveToSystemAccountFlagEnabledField: true,
+	}
+	multiTransfer.shardCoordinator = &mock.ShardCoordinatorStub{
+		SameShardCalled: func(_, _ []byte) bool {
+			return true
+		},
+	}
+	systemAccount := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	multiTransfer.accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+			return systemAccount, nil
+		},
+	}
+	token2Key := []byte(baseDCTKeyPrefix + "token2")
+	nftTokenKey := computeDCTNFTTokenKey(token2Key, 3)
+	vmInput.Arguments = append(vmInput.Arguments, big.NewInt(1).Bytes())
+
+	accessSet, err = multiTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{
+		{Address: sender},
+		{Address: destination},
+		{Address: vmcommon.SystemAccountAddress, Key: nftTokenKey},
+	}, accessSet.Writes)
+	assert.Equal(t, 5, len(accessSet.Reads))
+
+	_ = systemAccount.AccountDataHandler().SaveKeyValue(nftTokenKey, []byte("metadata"))
+	accessSet, err = multiTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{{Address: sender}, {Address: destination}}, accessSet.Writes)
+
+	vmInput.Arguments = vmInput.Arguments[1:]
+	vmInput.RecipientAddr = destination
+	accessSet, err = multiTransfer.AccessSet(vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, []vmcommon.AccessKey{{Address: sender}, {Address: destination}}, accessSet.Writes)
+}
+
 func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionInvalidArgumentsShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -291,7 +352,7 @@ func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionInvalidArgumentsShouldErr(t *
 	}
 	vmOutput, err = multiTransfer.ProcessBuiltinFunction(&mock.UserAccountStub{}, &mock.UserAccountStub{}, vmInput)
 	assert.Nil(t, vmOutput)
-	assert.Equal(t, ErrInvalidArguments, err)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
 
 	multiTransfer.shardCoordinator = &mock.ShardCoordinatorStub{ComputeIdCalled: func(address []byte) uint32 {
 		return core.MetachainShardId
@@ -457,6 +518,9 @@ func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionOnSameShardShouldCheckTokenVa
 	vmOutput, err := multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
 	require.Contains(t, err.Error(), "insufficient quantity")
 	require.Empty(t, vmOutput)
+	var builtInFunctionError *BuiltInFunctionError
+	require.True(t, errors.As(err, &builtInFunctionError))
+	require.Equal(t, []int{7}, builtInFunctionError.ArgumentIndices)
 
 	// after flag activation
 	multiTransfer.enableEpochsHandler = &mock.EnableEpochsHandlerStub{
@@ -0,0 +1,208 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// ArgsParallelScheduler defines the arguments needed to create a parallel scheduler
+type ArgsParallelScheduler struct {
+	BuiltInFunctions vmcommon.BuiltInFunctionContainer
+	Accounts         vmcommon.AccountsAdapter
+	ShardCoordinator vmcommon.Coordinator
+
+	// NumWorkers is the maximum number of calls processed at the same time. 0 means the number of CPUs.
+	NumWorkers int
+}
+
+// ScheduledCallResult holds the outcome of one scheduled built-in function call
+type ScheduledCallResult struct {
+	VMOutput *vmcommon.VMOutput
+	Err      error
+}
+
+type parallelScheduler struct {
+	*callProcessor
+	numWorkers int
+}
+
+// NewParallelScheduler creates a scheduler which processes the built-in function calls that do not conflict
+// concurrently. The accounts adapter must be safe for concurrent use.
+func NewParallelScheduler(args ArgsParallelScheduler) (*parallelScheduler, error) {
+	processor, err := newCallProcessor(args.BuiltInFunctions, args.Accounts, args.ShardCoordinator)
+	if err != nil {
+		return nil, err
+	}
+	if args.NumWorkers < 0 {
+		return nil, fmt.Errorf("%w, number of workers %d", ErrInvalidArguments, args.NumWorkers)
+	}
+
+	numWorkers := args.NumWorkers
+	if numWorkers == 0 {
+		numWorkers = runtime.NumCPU()
+	}
+
+	return &parallelScheduler{
+		callProcessor: processor,
+		numWorkers:    numWorkers,
+	}, nil
+}
+
+// ProcessCalls processes the calls and returns their results in the same order. The results are the same as
+// when processing the calls one after the other and reverting the changes of every call which returns an error or
+// a return code other than Ok: a call runs concurrently only with the calls whose access sets it does not conflict
+// with, and after all the conflicting calls before it. The calls of functions that do not declare an access set run
+// alone. If a call of a wave fails, the whole wave is reverted and processed again one call at a time, reverting
+// only the failed calls. The returned error is not nil only if the inputs are invalid or the accounts adapter can
+// not be reverted. The logs are not published: the host either calls CommitCalls or reverts all the calls.
+func (ps *parallelScheduler) ProcessCalls(vmInputs []*vmcommon.ContractCallInput) ([]*ScheduledCallResult, error) {
+	for i, vmInput := range vmInputs {
+		if vmInput == nil {
+			return nil, fmt.Errorf("%w at index %d", ErrNilVmInput, i)
+		}
+	}
+
+	results := make([]*ScheduledCallResult, len(vmInputs))
+	for _, wave := range ps.computeWaves(vmInputs) {
+		snapshot := ps.accounts.JournalLen()
+		ps.processWave(vmInputs, wave, results)
+		if !hasFailedCall(wave, results) {
+			continue
+		}
+
+		err := ps.accounts.RevertToSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ps.processWaveSerially(vmInputs, wave, results)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// CommitCalls commits the accounts adapter and publishes the logs of the calls which succeeded with the Ok return
+// code to the log subscribers of the container, in the order of the calls. The inputs must be the ones the calls
+// were processed with.
+func (ps *parallelScheduler) CommitCalls(vmInputs []*vmcommon.ContractCallInput, results []*ScheduledCallResult) ([]byte, error) {
+	keptOutputs := make([]*vmcommon.VMOutput, len(results))
+	for i, result := range results {
+		if result != nil && result.Err == nil {
+			keptOutputs[i] = result.VMOutput
+		}
+	}
+
+	return ps.commit(vmInputs, keptOutputs)
+}
+
+// computeWaves groups the calls into waves processed one after the other. Each call is placed in the wave
+// following the last wave holding a call it conflicts with.
+func (ps *parallelScheduler) computeWaves(vmInputs []*vmcommon.ContractCallInput) [][]int {
+	accessSets := make([]*vmcommon.AccessSet, len(vmInputs))
+	callWaves := make([]int, len(vmInputs))
+	waves := make([][]int, 0)
+	for i, vmInput := range vmInputs {
+		accessSets[i] = ps.accessSet(vmInput)
+
+		wave := 0
+		for j := 0; j < i; j++ {
+			if callWaves[j] >= wave && accessSets[i].ConflictsWith(accessSets[j]) {
+				wave = callWaves[j] + 1
+			}
+		}
+
+		callWaves[i] = wave
+		if wave == len(waves) {
+			waves = append(waves, make([]int, 0))
+		}
+		waves[wave] = append(waves[wave], i)
+	}
+
+	return waves
+}
+
+func (ps *parallelScheduler) accessSet(vmInput *vmcommon.ContractCallInput) *vmcommon.AccessSet {
+	function, err := ps.builtInFunctions.Get(vmInput.Function)
+	if err != nil {
+		return nil
+	}
+
+	accessSetProvider, ok := function.(vmcommon.AccessSetProvider)
+	if !ok {
+		return nil
+	}
+
+	accessSet, err := accessSetProvider.AccessSet(vmInput)
+	if err != nil {
+		return nil
+	}
+
+	return accessSet
+}
+
+func (ps *parallelScheduler) processWave(vmInputs []*vmcommon.ContractCallInput, wave []int, results []*ScheduledCallResult) {
+	workers := make(chan struct{}, ps.numWorkers)
+	wg := sync.WaitGroup{}
+	wg.Add(len(wave))
+	for _, index := range wave {
+		workers <- struct{}{}
+		go func(index int) {
+			vmOutput, err := ps.processCall(vmInputs[index])
+			results[index] = &ScheduledCallResult{
+				VMOutput: vmOutput,
+				Err:      err,
+			}
+
+			<-workers
+			wg.Done()
+		}(index)
+	}
+
+	wg.Wait()
+}
+
+func (ps *parallelScheduler) processWaveSerially(vmInputs []*vmcommon.ContractCallInput, wave []int, results []*ScheduledCallResult) error {
+	for _, index := range wave {
+		snapshot := ps.accounts.JournalLen()
+		vmOutput, err := ps.processCall(vmInputs[index])
+		results[index] = &ScheduledCallResult{
+			VMOutput: vmOutput,
+			Err:      err,
+		}
+		if !isFailedCall(results[index]) {
+			continue
+		}
+
+		errRevert := ps.accounts.RevertToSnapshot(snapshot)
+		if errRevert != nil {
+			return fmt.Errorf("%w while reverting call at index %d", errRevert, index)
+		}
+	}
+
+	return nil
+}
+
+func hasFailedCall(wave []int, results []*ScheduledCallResult) bool {
+	for _, index := range wave {
+		if isFailedCall(results[index]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isFailedCall(result *ScheduledCallResult) bool {
+	return result.Err != nil || result.VMOutput == nil || result.VMOutput.ReturnCode != vmcommon.Ok
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (ps *parallelScheduler) IsInterfaceNil() bool {
+	return ps == nil
+}
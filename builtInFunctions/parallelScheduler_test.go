@@ -0,0 +1,239 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func createParallelSchedulerArgs(b *builtInFuncCreator, adb vmcommon.AccountsAdapter) ArgsParallelScheduler {
+	return ArgsParallelScheduler{
+		BuiltInFunctions: b.BuiltInFunctionContainer(),
+		Accounts:         adb,
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+		NumWorkers:       4,
+	}
+}
+
+func getSimulationDCTBalance(t *testing.T, adb *inMemoryState.AccountsAdapter, address []byte) int64 {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	tokenKey := append([]byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier), simulationTokenID...)
+	marshalledToken, _, err := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(tokenKey)
+	require.Nil(t, err)
+	if len(marshalledToken) == 0 {
+		return 0
+	}
+
+	token := &dct.DCToken{}
+	require.Nil(t, (&mock.MarshalizerMock{}).Unmarshal(token, marshalledToken))
+
+	return token.Value.Int64()
+}
+
+func TestNewParallelScheduler(t *testing.T) {
+	t.Parallel()
+
+	adb := inMemoryState.NewAccountsAdapter()
+	b := createSimulationCreator(t, adb)
+
+	args := createParallelSchedulerArgs(b, adb)
+	args.BuiltInFunctions = nil
+	ps, err := NewParallelScheduler(args)
+	assert.Nil(t, ps)
+	assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+	args = createParallelSchedulerArgs(b, nil)
+	ps, err = NewParallelScheduler(args)
+	assert.Nil(t, ps)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	args = createParallelSchedulerArgs(b, adb)
+	args.ShardCoordinator = nil
+	ps, err = NewParallelScheduler(args)
+	assert.Nil(t, ps)
+	assert.Equal(t, ErrNilShardCoordinator, err)
+
+	args = createParallelSchedulerArgs(b, adb)
+	args.NumWorkers = -1
+	ps, err = NewParallelScheduler(args)
+	assert.Nil(t, ps)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	args = createParallelSchedulerArgs(b, adb)
+	args.NumWorkers = 0
+	ps, err = NewParallelScheduler(args)
+	require.Nil(t, err)
+	assert.False(t, ps.IsInterfaceNil())
+	assert.True(t, ps.numWorkers > 0)
+}
+
+func TestParallelScheduler_ComputeWaves(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	carol := createSimulationAddress(3)
+	dave := createSimulationAddress(4)
+
+	adb := inMemoryState.NewAccountsAdapter()
+	b := createSimulationCreator(t, adb)
+	ps, _ := NewParallelScheduler(createParallelSchedulerArgs(b, adb))
+
+	claimRewards := createSimulationTransferInput(dave, dave, 1)
+	claimRewards.Function = core.BuiltInFunctionClaimDeveloperRewards
+
+	waves := ps.computeWaves([]*vmcommon.ContractCallInput{
+		createSimulationTransferInput(alice, bob, 1),
+		createSimulationTransferInput(carol, dave, 1),
+		createSimulationTransferInput(bob, carol, 1),
+		createSimulationTransferInput(alice, dave, 1),
+		claimRewards,
+		createSimulationTransferInput(carol, alice, 1),
+		createSimulationTransferInput(bob, dave, 1),
+	})
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}, {5, 6}}, waves)
+}
+
+func TestParallelScheduler_ProcessCalls(t *testing.T) {
+	t.Parallel()
+
+	adb := inMemoryState.NewAccountsAdapter()
+	b := createSimulationCreator(t, adb)
+	ps, _ := NewParallelScheduler(createParallelSchedulerArgs(b, adb))
+
+	t.Run("nil input should error", func(t *testing.T) {
+		results, err := ps.ProcessCalls([]*vmcommon.ContractCallInput{nil})
+		assert.Nil(t, results)
+		assert.ErrorIs(t, err, ErrNilVmInput)
+	})
+	t.Run("should match the serial processing", func(t *testing.T) {
+		numAccounts := 20
+		addresses := make([][]byte, 0, numAccounts)
+		for i := 0; i < numAccounts; i++ {
+			address := createSimulationAddress(byte(10 + i))
+			saveSimulationDCTBalance(t, adb, address, 100)
+			addresses = append(addresses, address)
+		}
+
+		vmInputs := make([]*vmcommon.ContractCallInput, 0)
+		expectedBalances := make(map[string]int64)
+		for _, address := range addresses {
+			expectedBalances[string(address)] = 100
+		}
+		for round := 0; round < 5; round++ {
+			for i := 0; i < numAccounts; i++ {
+				sender := addresses[i]
+				receiver := addresses[(i+round+1)%numAccounts]
+				value := int64((i%7 + 1) * (round + 1) * 5)
+				vmInputs = append(vmInputs, createSimulationTransferInput(sender, receiver, value))
+
+				if expectedBalances[string(sender)] >= value {
+					expectedBalances[string(sender)] -= value
+					expectedBalances[string(receiver)] += value
+				}
+			}
+		}
+		missingFunction := createSimulationTransferInput(addresses[0], addresses[1], 1)
+		missingFunction.Function = "missing"
+		vmInputs = append(vmInputs, missingFunction)
+
+		results, err := ps.ProcessCalls(vmInputs)
+		require.Nil(t, err)
+		require.Equal(t, len(vmInputs), len(results))
+
+		numFailed := 0
+		for _, result := range results[:len(results)-1] {
+			if result.Err != nil {
+				assert.True(t, errors.Is(result.Err, ErrInsufficientFunds))
+				numFailed++
+				continue
+			}
+			assert.Equal(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+		}
+		assert.True(t, numFailed > 0)
+		assert.ErrorIs(t, results[len(results)-1].Err, ErrInvalidContainerKey)
+
+		for _, address := range addresses {
+			assert.Equal(t, expectedBalances[string(address)], getSimulationDCTBalance(t, adb, address))
+		}
+	})
+}
+
+func TestParallelScheduler_ProcessCallsWithLogSubscriber(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	carol := createSimulationAddress(3)
+	dave := createSimulationAddress(4)
+
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	saveSimulationDCTBalance(t, adb, carol, 100)
+	b := createSimulationCreator(t, adb)
+
+	mutReceived := sync.Mutex{}
+	received := make([]*vmcommon.BuiltInLogEvent, 0)
+	err := b.BuiltInFunctionContainer().(vmcommon.AcceptLogSubscriber).RegisterLogSubscriber(&mock.LogSubscriberStub{
+		ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+			mutReceived.Lock()
+			received = append(received, event)
+			mutReceived.Unlock()
+		},
+	})
+	require.Nil(t, err)
+	failingFunctionName := "failingFunction"
+	err = b.BuiltInFunctionContainer().Add(failingFunctionName, &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, Logs: []*vmcommon.LogEntry{{Identifier: []byte("reverted")}}}, nil
+		},
+	})
+	require.Nil(t, err)
+	failingCall := createSimulationTransferInput(bob, bob, 0)
+	failingCall.Function = failingFunctionName
+	ps, _ := NewParallelScheduler(createParallelSchedulerArgs(b, adb))
+
+	vmInputs := []*vmcommon.ContractCallInput{
+		createSimulationTransferInput(alice, bob, 10),
+		createSimulationTransferInput(carol, dave, 200),
+		createSimulationTransferInput(carol, dave, 20),
+		failingCall,
+	}
+	for i, vmInput := range vmInputs {
+		vmInput.CurrentTxHash = []byte{byte(i)}
+	}
+	assert.Equal(t, [][]int{{0, 1}, {2}, {3}}, ps.computeWaves(vmInputs))
+
+	results, err := ps.ProcessCalls(vmInputs)
+	require.Nil(t, err)
+	assert.Nil(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, ErrInsufficientFunds)
+	assert.Nil(t, results[2].Err)
+	assert.Nil(t, results[3].Err)
+	assert.Equal(t, vmcommon.UserError, results[3].VMOutput.ReturnCode)
+
+	assert.Equal(t, int64(90), getSimulationDCTBalance(t, adb, alice))
+	assert.Equal(t, int64(80), getSimulationDCTBalance(t, adb, carol))
+	bobAccount, _ := adb.LoadAccount(bob)
+	value, _, _ := bobAccount.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Empty(t, value)
+
+	assert.Empty(t, received)
+	_, err = ps.CommitCalls(vmInputs, results)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(received))
+	assert.Equal(t, []byte{0}, received[0].CurrentTxHash)
+	assert.Equal(t, []byte{2}, received[1].CurrentTxHash)
+	assert.Equal(t, core.BuiltInFunctionDCTTransfer, string(received[1].LogEntry.Identifier))
+}
@@ -0,0 +1,269 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// ArgsCustomBuiltInFunction holds the components a custom built-in function can be created with
+type ArgsCustomBuiltInFunction struct {
+	// GasCost is the value of the registered gas cost key, from the BuiltInCost section of the gas schedule.
+	GasCost   uint64
+	GasConfig vmcommon.GasCost
+
+	// ActiveHandler evaluates the registered activation flag. The IsActive method of the function should return it.
+	ActiveHandler func() bool
+
+	Marshaller            vmcommon.Marshalizer
+	Accounts              vmcommon.AccountsAdapter
+	ShardCoordinator      vmcommon.Coordinator
+	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+	DCTStorageHandler     vmcommon.DCTNFTStorageHandler
+	GlobalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+}
+
+// CustomBuiltInFunction describes a built-in function defined outside this package
+type CustomBuiltInFunction struct {
+	// Name is the function name the built-in function is called with.
+	Name string
+
+	// GasCostKey is the key of the function cost in the BuiltInCost section of the gas schedule. It is optional,
+	// but if set, the key is required in every gas schedule and the function must implement vmcommon.AcceptCustomGasCost.
+	GasCostKey string
+
+	// ActivationFlag tells if the function is active. It is optional, a function without it is always active.
+	ActivationFlag func(enableEpochsHandler vmcommon.EnableEpochsHandler) bool
+
+	// ActivationEpoch is the epoch this implementation of the function is used from. Several implementations can
+	// be registered under the same name with different activation epochs, and the creator then dispatches each call
+	// to the one active in the current epoch. A core built-in function gets a new version only by registering a
+	// function with the same name and an activation epoch greater than 0, the core function being the implementation
+	// used until then.
+	ActivationEpoch uint32
+
+	Constructor func(args ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error)
+}
+
+type registryKey struct {
+	name            string
+	activationEpoch uint32
+}
+
+// Registry holds the custom built-in functions the creator adds to the built-in functions container
+type Registry struct {
+	mutFunctions sync.RWMutex
+	functions    map[registryKey]*CustomBuiltInFunction
+}
+
+// NewRegistry creates an empty custom built-in functions registry
+func NewRegistry() *Registry {
+	return &Registry{
+		functions: make(map[registryKey]*CustomBuiltInFunction),
+	}
+}
+
+// Register adds the custom built-in function. A name can be registered only once for each activation epoch.
+func (r *Registry) Register(function CustomBuiltInFunction) error {
+	if len(function.Name) == 0 {
+		return ErrEmptyFunctionName
+	}
+	if function.Constructor == nil {
+		return fmt.Errorf("%w for custom built-in function %s", ErrNilBuiltInFunctionConstructor, function.Name)
+	}
+
+	r.mutFunctions.Lock()
+	defer r.mutFunctions.Unlock()
+
+	key := registryKey{
+		name:            function.Name,
+		activationEpoch: function.ActivationEpoch,
+	}
+	_, found := r.functions[key]
+	if found {
+		return fmt.Errorf("%w: %s, activation epoch %d", ErrContainerKeyAlreadyExists, function.Name, function.ActivationEpoch)
+	}
+
+	r.functions[key] = &function
+
+	return nil
+}
+
+// Functions returns the registered custom built-in functions, sorted by name and then by activation epoch
+func (r *Registry) Functions() []CustomBuiltInFunction {
+	r.mutFunctions.RLock()
+	defer r.mutFunctions.RUnlock()
+
+	functions := make([]CustomBuiltInFunction, 0, len(r.functions))
+	for _, function := range r.functions {
+		functions = append(functions, *function)
+	}
+
+	sort.Slice(functions, func(i, j int) bool {
+		if functions[i].Name != functions[j].Name {
+			return functions[i].Name < functions[j].Name
+		}
+
+		return functions[i].ActivationEpoch < functions[j].ActivationEpoch
+	})
+
+	return functions
+}
+
+// customGasCosts returns the costs of the custom built-in functions from the gas schedule, by gas cost key
+func customGasCosts(functions []CustomBuiltInFunction, gasMap map[string]map[string]uint64) (map[string]uint64, error) {
+	gasCosts := make(map[string]uint64)
+	for _, function := range functions {
+		if len(function.GasCostKey) == 0 {
+			continue
+		}
+
+		gasCost, found := gasMap[core.BuiltInCostString][function.GasCostKey]
+		if !found {
+			return nil, fmt.Errorf("%w %s for custom built-in function %s", ErrMissingGasCost, function.GasCostKey, function.Name)
+		}
+		if gasCost == 0 {
+			return nil, fmt.Errorf("%w: gas cost %s for custom built-in function %s is 0", ErrInvalidGasSchedule, function.GasCostKey, function.Name)
+		}
+
+		gasCosts[function.GasCostKey] = gasCost
+	}
+
+	return gasCosts, nil
+}
+
+type customGasCostAcceptor struct {
+	gasCostKey string
+	acceptor   vmcommon.AcceptCustomGasCost
+}
+
+// createCustomBuiltInFunctions creates the custom built-in functions and adds them to the container. The
+// functions registered with several versions, or with an activation epoch, are wrapped in a versioned function.
+func (b *builtInFuncCreator) createCustomBuiltInFunctions() error {
+	b.customGasCostAcceptors = make([]customGasCostAcceptor, 0)
+	b.versionedFunctions = make([]*versionedBuiltInFunction, 0)
+
+	versionsByName := make(map[string][]BuiltInFunctionVersion)
+	names := make([]string, 0)
+	for _, customFunction := range b.customFunctions {
+		newFunc, err := b.createCustomBuiltInFunction(customFunction)
+		if err != nil {
+			return err
+		}
+
+		_, found := versionsByName[customFunction.Name]
+		if !found {
+			names = append(names, customFunction.Name)
+		}
+		versionsByName[customFunction.Name] = append(versionsByName[customFunction.Name], BuiltInFunctionVersion{
+			ActivationEpoch: customFunction.ActivationEpoch,
+			Function:        newFunc,
+		})
+	}
+
+	for _, name := range names {
+		err := b.addCustomBuiltInFunction(name, versionsByName[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *builtInFuncCreator) createCustomBuiltInFunction(customFunction CustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+	activeGasSchedule := b.gasSchedule.activeSnapshot()
+	activeHandler := trueHandler
+	if customFunction.ActivationFlag != nil {
+		activationFlag := customFunction.ActivationFlag
+		activeHandler = func() bool {
+			return activationFlag(b.enableEpochsHandler)
+		}
+	}
+
+	newFunc, err := customFunction.Constructor(ArgsCustomBuiltInFunction{
+		GasCost:               activeGasSchedule.CustomGasCosts[customFunction.GasCostKey],
+		GasConfig:             *activeGasSchedule.GasCost,
+		ActiveHandler:         activeHandler,
+		Marshaller:            b.marshaller,
+		Accounts:              b.accounts,
+		ShardCoordinator:      b.shardCoordinator,
+		EnableEpochsHandler:   b.enableEpochsHandler,
+		DCTStorageHandler:     b.dctStorageHandler,
+		GlobalSettingsHandler: b.dctGlobalSettingsHandler,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w while creating custom built-in function %s", err, customFunction.Name)
+	}
+	if check.IfNil(newFunc) {
+		return nil, fmt.Errorf("%w: custom built-in function %s", ErrNilContainerElement, customFunction.Name)
+	}
+
+	if len(customFunction.GasCostKey) > 0 {
+		gasCostAcceptor, ok := newFunc.(vmcommon.AcceptCustomGasCost)
+		if !ok {
+			return nil, fmt.Errorf("%w: custom built-in function %s does not accept its gas cost", ErrWrongTypeAssertion, customFunction.Name)
+		}
+
+		b.customGasCostAcceptors = append(b.customGasCostAcceptors, customGasCostAcceptor{
+			gasCostKey: customFunction.GasCostKey,
+			acceptor:   gasCostAcceptor,
+		})
+	}
+
+	return newFunc, nil
+}
+
+func (b *builtInFuncCreator) addCustomBuiltInFunction(name string, versions []BuiltInFunctionVersion) error {
+	isVersioned := len(versions) > 1 || versions[0].ActivationEpoch > 0
+	if !isVersioned {
+		err := b.builtInFunctions.Add(name, versions[0].Function)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+
+		return nil
+	}
+
+	if check.IfNil(b.epochNotifier) {
+		return fmt.Errorf("%w for versioned built-in function %s", ErrNilEpochNotifier, name)
+	}
+
+	coreFunc, err := b.builtInFunctions.Get(name)
+	if err == nil {
+		if versions[0].ActivationEpoch == 0 {
+			return fmt.Errorf("%w: %s, activation epoch 0", ErrContainerKeyAlreadyExists, name)
+		}
+
+		versions = append([]BuiltInFunctionVersion{{Function: coreFunc}}, versions...)
+	}
+
+	versionedFunc, err := NewVersionedBuiltInFunction(name, versions)
+	if err != nil {
+		return err
+	}
+
+	err = b.builtInFunctions.Replace(name, versionedFunc)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, name)
+	}
+
+	b.versionedFunctions = append(b.versionedFunctions, versionedFunc)
+	b.epochNotifier.RegisterNotifyHandler(versionedFunc)
+
+	return nil
+}
+
+// setCustomGasCosts sets the gas costs of the custom built-in functions
+func (b *builtInFuncCreator) setCustomGasCosts(gasCosts map[string]uint64) {
+	for _, gasCostAcceptor := range b.customGasCostAcceptors {
+		gasCost, found := gasCosts[gasCostAcceptor.gasCostKey]
+		if found {
+			gasCostAcceptor.acceptor.SetCustomGasCost(gasCost)
+		}
+	}
+}
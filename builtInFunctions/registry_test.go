@@ -0,0 +1,189 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+const customFunctionName = "CustomFunction"
+const customGasCostKey = "CustomFunctionCost"
+
+type customBuiltInFunctionMock struct {
+	mock.BuiltInFunctionStub
+	gasCost uint64
+}
+
+func (c *customBuiltInFunctionMock) SetCustomGasCost(gasCost uint64) {
+	c.gasCost = gasCost
+}
+
+func createCustomBuiltInFunction(isActive *bool) CustomBuiltInFunction {
+	return CustomBuiltInFunction{
+		Name:       customFunctionName,
+		GasCostKey: customGasCostKey,
+		ActivationFlag: func(_ vmcommon.EnableEpochsHandler) bool {
+			return *isActive
+		},
+		Constructor: func(args ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			function := &customBuiltInFunctionMock{gasCost: args.GasCost}
+			function.IsActiveCalled = args.ActiveHandler
+
+			return function, nil
+		},
+	}
+}
+
+func createCustomRegistryArguments(registry *Registry) ArgsCreateBuiltInFunctionContainer {
+	args := createMockArguments()
+	args.Registry = registry
+	args.GasMap[core.BuiltInCostString][customGasCostKey] = 42
+
+	return args
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	constructor := func(_ ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+		return &mock.BuiltInFunctionStub{}, nil
+	}
+
+	err := registry.Register(CustomBuiltInFunction{Constructor: constructor})
+	assert.Equal(t, ErrEmptyFunctionName, err)
+
+	err = registry.Register(CustomBuiltInFunction{Name: "B"})
+	assert.ErrorIs(t, err, ErrNilBuiltInFunctionConstructor)
+
+	assert.Nil(t, registry.Register(CustomBuiltInFunction{Name: "B", Constructor: constructor}))
+	assert.Nil(t, registry.Register(CustomBuiltInFunction{Name: "A", Constructor: constructor}))
+	err = registry.Register(CustomBuiltInFunction{Name: "A", Constructor: constructor})
+	assert.ErrorIs(t, err, ErrContainerKeyAlreadyExists)
+	assert.Nil(t, registry.Register(CustomBuiltInFunction{Name: "A", ActivationEpoch: 3, Constructor: constructor}))
+
+	functions := registry.Functions()
+	require.Equal(t, 3, len(functions))
+	assert.Equal(t, "A", functions[0].Name)
+	assert.Equal(t, uint32(0), functions[0].ActivationEpoch)
+	assert.Equal(t, "A", functions[1].Name)
+	assert.Equal(t, uint32(3), functions[1].ActivationEpoch)
+	assert.Equal(t, "B", functions[2].Name)
+}
+
+func TestBuiltInFuncCreator_CustomBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing gas cost should error", func(t *testing.T) {
+		t.Parallel()
+
+		isActive := true
+		registry := NewRegistry()
+		_ = registry.Register(createCustomBuiltInFunction(&isActive))
+
+		b, err := NewBuiltInFunctionsCreator(createMockArguments())
+		require.Nil(t, err)
+		assert.Nil(t, b.CreateBuiltInFunctionContainer())
+
+		args := createMockArguments()
+		args.Registry = registry
+		b, err = NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, b)
+		assert.ErrorIs(t, err, ErrMissingGasCost)
+	})
+	t.Run("nil registry should include only the core built-in functions", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArguments()
+		args.Registry = nil
+		b, err := NewBuiltInFunctionsCreator(args)
+		require.Nil(t, err)
+		require.Nil(t, b.CreateBuiltInFunctionContainer())
+		assert.Equal(t, 36, b.BuiltInFunctionContainer().Len())
+		assert.Empty(t, b.customFunctions)
+	})
+	t.Run("name of a protocol built-in function should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry()
+		_ = registry.Register(CustomBuiltInFunction{
+			Name: core.BuiltInFunctionDCTTransfer,
+			Constructor: func(_ ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return &mock.BuiltInFunctionStub{}, nil
+			},
+		})
+
+		b, _ := NewBuiltInFunctionsCreator(createCustomRegistryArguments(registry))
+		err := b.CreateBuiltInFunctionContainer()
+		assert.ErrorIs(t, err, ErrContainerKeyAlreadyExists)
+	})
+	t.Run("constructor error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		registry := NewRegistry()
+		_ = registry.Register(CustomBuiltInFunction{
+			Name: customFunctionName,
+			Constructor: func(_ ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return nil, expectedErr
+			},
+		})
+
+		b, _ := NewBuiltInFunctionsCreator(createCustomRegistryArguments(registry))
+		err := b.CreateBuiltInFunctionContainer()
+		assert.ErrorIs(t, err, expectedErr)
+	})
+	t.Run("function with gas cost key not accepting the gas cost should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry()
+		_ = registry.Register(CustomBuiltInFunction{
+			Name:       customFunctionName,
+			GasCostKey: customGasCostKey,
+			Constructor: func(_ ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return &mock.BuiltInFunctionStub{}, nil
+			},
+		})
+
+		b, _ := NewBuiltInFunctionsCreator(createCustomRegistryArguments(registry))
+		err := b.CreateBuiltInFunctionContainer()
+		assert.ErrorIs(t, err, ErrWrongTypeAssertion)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		isActive := false
+		registry := NewRegistry()
+		_ = registry.Register(createCustomBuiltInFunction(&isActive))
+
+		args := createCustomRegistryArguments(registry)
+		b, err := NewBuiltInFunctionsCreator(args)
+		require.Nil(t, err)
+		require.Nil(t, b.CreateBuiltInFunctionContainer())
+		assert.Equal(t, 37, b.BuiltInFunctionContainer().Len())
+
+		function, err := b.BuiltInFunctionContainer().Get(customFunctionName)
+		require.Nil(t, err)
+		customFunction := function.(*customBuiltInFunctionMock)
+		assert.Equal(t, uint64(42), customFunction.gasCost)
+		assert.False(t, customFunction.IsActive())
+		isActive = true
+		assert.True(t, customFunction.IsActive())
+
+		delete(args.GasMap[core.BuiltInCostString], customGasCostKey)
+		_, err = b.ChangeGasSchedule(args.GasMap)
+		assert.ErrorIs(t, err, ErrMissingGasCost)
+		assert.Equal(t, uint64(0), b.ActiveGasSchedule().Version)
+
+		args.GasMap[core.BuiltInCostString][customGasCostKey] = 43
+		snapshot, err := b.ChangeGasSchedule(args.GasMap)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]uint64{customGasCostKey: 43}, snapshot.CustomGasCosts)
+		assert.Equal(t, uint64(43), customFunction.gasCost)
+	})
+}
@@ -13,6 +13,8 @@ import (
 
 type saveUserName struct {
 	baseAlwaysActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	gasCost           uint64
 	isChangeEnabled   func() bool
 	mapDnsAddresses   map[string]struct{}
@@ -35,6 +37,9 @@ func NewSaveUserNameFunc(
 	}
 
 	s := &saveUserName{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.SaveUserName
+		}),
 		gasCost:         gasCost,
 		isChangeEnabled: enableEpochsHandler.IsChangeUsernameEnabled,
 	}
@@ -124,12 +129,14 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	s.mutExecution.RLock()
 	defer s.mutExecution.RUnlock()
 
+	funcGasCost := s.activeFuncGasCost(s.gasCost)
+
 	addressesToCheck := s.mapDnsV2Addresses
 	if !s.isChangeEnabled() {
 		addressesToCheck = s.mapDnsAddresses
 	}
 
-	err := inputCheckForUserNameCall(acntSnd, vmInput, addressesToCheck, s.gasCost, 1)
+	err := inputCheckForUserNameCall(acntSnd, vmInput, addressesToCheck, funcGasCost, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -137,10 +144,18 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	if check.IfNil(acntDst) {
 		gasLimit := vmInput.GasProvided
 		if s.isChangeEnabled() {
-			gasLimit = vmInput.GasProvided - s.gasCost
+			gasLimit = vmInput.GasProvided - funcGasCost
+		}
+
+		vmOutput, errCall := createCrossShardUserNameCall(vmInput, core.BuiltInFunctionSetUserName, gasLimit)
+		if errCall != nil {
+			return nil, errCall
 		}
 
-		return createCrossShardUserNameCall(vmInput, core.BuiltInFunctionSetUserName, gasLimit)
+		if gasLimit < vmInput.GasProvided {
+			s.traceGas(vmInput, vmOutput, newBuiltInCostCharge("SaveUserName", funcGasCost))
+		}
+		return vmOutput, nil
 	}
 
 	currentUserName := acntDst.GetUserName()
@@ -150,7 +165,7 @@ func (s *saveUserName) ProcessBuiltinFunction(
 
 	acntDst.SetUserName(vmInput.Arguments[0])
 
-	gasRemaining := vmInput.GasProvided - s.gasCost
+	gasRemaining := vmInput.GasProvided - funcGasCost
 	if s.isChangeEnabled() && check.IfNil(acntSnd) {
 		gasRemaining = vmInput.GasProvided
 	}
@@ -160,6 +175,9 @@ func (s *saveUserName) ProcessBuiltinFunction(
 		ReturnCode:   vmcommon.Ok,
 	}
 	addLogEntryForUserNameChange(vmInput, vmOutput, currentUserName)
+	if gasRemaining < vmInput.GasProvided {
+		s.traceGas(vmInput, vmOutput, newBuiltInCostCharge("SaveUserName", funcGasCost))
+	}
 
 	return vmOutput, nil
 }
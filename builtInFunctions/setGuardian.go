@@ -30,6 +30,9 @@ func NewSetGuardianFunc(args SetGuardianArgs) (*setGuardian, error) {
 	if err != nil {
 		return nil, err
 	}
+	base.baseGasSchedule = newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+		return builtInCost.SetGuardian
+	})
 	setGuardianFunc := &setGuardian{
 		baseAccountGuarder: base,
 	}
@@ -61,15 +64,17 @@ func (sg *setGuardian) ProcessBuiltinFunction(
 	sg.mutExecution.RLock()
 	defer sg.mutExecution.RUnlock()
 
+	funcGasCost := sg.activeFuncGasCost(sg.funcGasCost)
 	newGuardian := vmInput.Arguments[0]
 	guardianServiceUID := vmInput.Arguments[1]
 	gasProvidedForCall := vmInput.GasProvided
 
-	err := sg.CheckIsExecutable(
+	err := sg.checkIsExecutable(
 		senderAddr,
 		vmInput.CallValue,
 		vmInput.RecipientAddr,
 		gasProvidedForCall,
+		funcGasCost,
 		vmInput.Arguments,
 	)
 	if err != nil {
@@ -87,11 +92,14 @@ func (sg *setGuardian) ProcessBuiltinFunction(
 		Topics:     [][]byte{newGuardian, guardianServiceUID},
 	}
 
-	return &vmcommon.VMOutput{
+	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - sg.funcGasCost,
+		GasRemaining: vmInput.GasProvided - funcGasCost,
 		Logs:         []*vmcommon.LogEntry{entry},
-	}, nil
+	}
+	sg.traceGas(vmInput, vmOutput, newBuiltInCostCharge("SetGuardian", funcGasCost))
+
+	return vmOutput, nil
 }
 
 // CheckIsExecutable will check if the set guardian built-in function can be executed
@@ -102,12 +110,27 @@ func (sg *setGuardian) CheckIsExecutable(
 	gasProvidedForCall uint64,
 	arguments [][]byte,
 ) error {
+	sg.mutExecution.RLock()
+	funcGasCost := sg.activeFuncGasCost(sg.funcGasCost)
+	sg.mutExecution.RUnlock()
 
+	return sg.checkIsExecutable(senderAddr, value, receiverAddr, gasProvidedForCall, funcGasCost, arguments)
+}
+
+func (sg *setGuardian) checkIsExecutable(
+	senderAddr []byte,
+	value *big.Int,
+	receiverAddr []byte,
+	gasProvidedForCall uint64,
+	funcGasCost uint64,
+	arguments [][]byte,
+) error {
 	err := sg.checkBaseAccountGuarderArgs(
 		senderAddr,
 		receiverAddr,
 		value,
 		gasProvidedForCall,
+		funcGasCost,
 		arguments,
 		noOfArgsSetGuardian,
 	)
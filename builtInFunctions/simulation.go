@@ -0,0 +1,389 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.BuiltInFunctionSimulator = (*builtInFuncCreator)(nil)
+var _ vmcommon.BuiltInFunctionRecorder = (*builtInFuncCreator)(nil)
+var _ vmcommon.AccountsAdapter = (*simulationAccounts)(nil)
+var _ vmcommon.AccountsAdapter = (*simulationRouter)(nil)
+
+// SimulateBuiltInFunction runs the configured built-in function against a copy-on-write view of the accounts and
+// returns the produced output, which carries the state diff. The accounts adapter is never changed. A failing
+// built-in function does not produce an error, but a result with the failure output of the error.
+func (b *builtInFuncCreator) SimulateBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.SimulationResult, error) {
+	run, err := b.runOnSimulationAccounts(input)
+	if err != nil {
+		return nil, err
+	}
+	if run.errProcess != nil {
+		return &vmcommon.SimulationResult{
+			VMOutput: vmcommon.NewFailureVMOutput(input.Function, NewBuiltInFunctionError(input.Function, run.errProcess)),
+		}, nil
+	}
+
+	run.vmOutput.StateDiff, err = run.accounts.computeStateDiff()
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.SimulationResult{
+		VMOutput: run.vmOutput,
+	}, nil
+}
+
+// RecordBuiltInFunction runs the built-in function the same way SimulateBuiltInFunction does and returns the
+// input, the state of the loaded accounts before the call and the produced output or error. The accounts
+// adapter is never changed.
+func (b *builtInFuncCreator) RecordBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.ExecutionRecord, error) {
+	run, err := b.runOnSimulationAccounts(input)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &vmcommon.ExecutionRecord{
+		Input:    input,
+		PreState: run.accounts.computePreState(),
+	}
+	if run.errProcess != nil {
+		record.Error = run.errProcess.Error()
+		return record, nil
+	}
+
+	run.vmOutput.StateDiff, err = run.accounts.computeStateDiff()
+	if err != nil {
+		return nil, err
+	}
+	record.VMOutput = run.vmOutput
+
+	return record, nil
+}
+
+// simulationRun holds the outcome of a built-in function run against a copy-on-write view of the accounts.
+// The error of the built-in function is kept apart from the errors of the simulation itself.
+type simulationRun struct {
+	accounts   *simulationAccounts
+	vmOutput   *vmcommon.VMOutput
+	errProcess error
+}
+
+// runOnSimulationAccounts runs the built-in function of the simulation set against a new copy-on-write view of the
+// accounts. The simulation set works on the view until the run ends, so the simulations are processed one at a time,
+// while the calls processed by the container are not held.
+func (b *builtInFuncCreator) runOnSimulationAccounts(input *vmcommon.ContractCallInput) (*simulationRun, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+	if b.simulation == nil {
+		return nil, fmt.Errorf("%w in simulation functions for key %v", ErrInvalidContainerKey, input.Function)
+	}
+
+	function, err := b.simulation.creator.builtInFunctions.Get(input.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := newSimulationAccounts(b.accounts)
+	b.simulation.start(accounts)
+	defer b.simulation.end()
+
+	acntSnd, err := b.loadSimulationAccount(accounts, input.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+	acntDst, err := b.loadSimulationAccount(accounts, input.RecipientAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, errProcess := function.ProcessBuiltinFunction(acntSnd, acntDst, input)
+	if errProcess != nil {
+		return &simulationRun{accounts: accounts, errProcess: errProcess}, nil
+	}
+
+	for _, account := range []vmcommon.UserAccountHandler{acntSnd, acntDst} {
+		if check.IfNil(account) {
+			continue
+		}
+
+		err = accounts.SaveAccount(account)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &simulationRun{accounts: accounts, vmOutput: vmOutput}, nil
+}
+
+func (b *builtInFuncCreator) loadSimulationAccount(accounts vmcommon.AccountsAdapter, address []byte) (vmcommon.UserAccountHandler, error) {
+	if len(address) == 0 || b.shardCoordinator.ComputeId(address) != b.shardCoordinator.SelfId() {
+		return nil, nil
+	}
+
+	account, err := accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+// createSimulationFunctions creates the set of built-in functions the simulations run on. The set is configured as the
+// built-in functions container, but works on the copy-on-write view of the running simulation instead of the accounts
+// adapter. It does not trace the gas and does not publish logs.
+func (b *builtInFuncCreator) createSimulationFunctions() error {
+	router := &simulationRouter{}
+	simulationCreator := b.newDerivedCreator(router)
+	err := simulationCreator.createBuiltInFunctions()
+	if err != nil {
+		return err
+	}
+
+	b.simulation = &simulationFunctions{
+		router:  router,
+		creator: simulationCreator,
+	}
+
+	return nil
+}
+
+// simulationFunctions is the set of built-in functions the simulations run on, one simulation at a time
+type simulationFunctions struct {
+	mutSimulation sync.Mutex
+	router        *simulationRouter
+	creator       *builtInFuncCreator
+}
+
+// start routes the built-in functions of the set to the provided view until end is called
+func (sf *simulationFunctions) start(accounts *simulationAccounts) {
+	sf.mutSimulation.Lock()
+	sf.router.accounts = accounts
+}
+
+func (sf *simulationFunctions) end() {
+	sf.router.accounts = nil
+	sf.mutSimulation.Unlock()
+}
+
+// simulationRouter is the accounts adapter the simulation set works on. It forwards to the copy-on-write view of the
+// running simulation, which is only changed between simulations.
+type simulationRouter struct {
+	accounts *simulationAccounts
+}
+
+// GetExistingAccount returns the existing account from the view of the running simulation
+func (sr *simulationRouter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return sr.accounts.GetExistingAccount(address)
+}
+
+// LoadAccount returns the existing or new account from the view of the running simulation
+func (sr *simulationRouter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return sr.accounts.LoadAccount(address)
+}
+
+// SaveAccount saves the account in the view of the running simulation
+func (sr *simulationRouter) SaveAccount(account vmcommon.AccountHandler) error {
+	return sr.accounts.SaveAccount(account)
+}
+
+// RemoveAccount removes the account from the view of the running simulation
+func (sr *simulationRouter) RemoveAccount(address []byte) error {
+	return sr.accounts.RemoveAccount(address)
+}
+
+// Commit commits the view of the running simulation
+func (sr *simulationRouter) Commit() ([]byte, error) {
+	return sr.accounts.Commit()
+}
+
+// JournalLen returns the journal length of the view of the running simulation
+func (sr *simulationRouter) JournalLen() int {
+	return sr.accounts.JournalLen()
+}
+
+// RevertToSnapshot reverts the view of the running simulation to the provided snapshot
+func (sr *simulationRouter) RevertToSnapshot(snapshot int) error {
+	return sr.accounts.RevertToSnapshot(snapshot)
+}
+
+// GetCode returns the code from the view of the running simulation
+func (sr *simulationRouter) GetCode(codeHash []byte) []byte {
+	return sr.accounts.GetCode(codeHash)
+}
+
+// RootHash returns the root hash of the view of the running simulation
+func (sr *simulationRouter) RootHash() ([]byte, error) {
+	return sr.accounts.RootHash()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sr *simulationRouter) IsInterfaceNil() bool {
+	return sr == nil
+}
+
+// simulationAccounts is a copy-on-write view over an accounts adapter. Accounts are loaded once from the
+// underlying adapter and then served from memory, while saves do not reach the underlying adapter. The view
+// relies on the underlying adapter handing out account instances that are not shared with its own state.
+type simulationAccounts struct {
+	mutAccounts sync.RWMutex
+	accounts    vmcommon.AccountsAdapter
+	records     map[string]*accountRecord
+}
+
+func newSimulationAccounts(accounts vmcommon.AccountsAdapter) *simulationAccounts {
+	return &simulationAccounts{
+		accounts: accounts,
+		records:  make(map[string]*accountRecord),
+	}
+}
+
+// GetExistingAccount returns the simulated account, loading it from the underlying adapter if needed
+func (sa *simulationAccounts) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return sa.getOrLoad(address, sa.accounts.GetExistingAccount)
+}
+
+// LoadAccount returns the simulated account, loading or creating it through the underlying adapter if needed
+func (sa *simulationAccounts) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return sa.getOrLoad(address, sa.accounts.LoadAccount)
+}
+
+func (sa *simulationAccounts) getOrLoad(
+	address []byte,
+	loadHandler func(address []byte) (vmcommon.AccountHandler, error),
+) (vmcommon.AccountHandler, error) {
+	sa.mutAccounts.Lock()
+	defer sa.mutAccounts.Unlock()
+
+	record, found := sa.records[string(address)]
+	if found {
+		return record.account, nil
+	}
+
+	account, err := loadHandler(address)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(account) {
+		return nil, ErrNilAccountHandler
+	}
+
+	record = newAccountRecord(account)
+	sa.records[string(address)] = record
+
+	return record.account, nil
+}
+
+// SaveAccount only checks that the account is the instance handed out by the view, as the changes are
+// already held in memory. The underlying adapter is not touched.
+func (sa *simulationAccounts) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccountHandler
+	}
+
+	sa.mutAccounts.Lock()
+	defer sa.mutAccounts.Unlock()
+
+	address := string(account.AddressBytes())
+	record, found := sa.records[address]
+	if !found {
+		record = &accountRecord{
+			account: account,
+			originalState: &accountState{
+				balance:         big.NewInt(0),
+				developerReward: big.NewInt(0),
+			},
+			storage: newStorageRecord(),
+		}
+		sa.records[address] = record
+	}
+	if record.account != account {
+		return ErrOperationNotSupportedInSimulation
+	}
+
+	return nil
+}
+
+// RemoveAccount is not supported in simulation
+func (sa *simulationAccounts) RemoveAccount(_ []byte) error {
+	return ErrOperationNotSupportedInSimulation
+}
+
+// Commit is not supported in simulation
+func (sa *simulationAccounts) Commit() ([]byte, error) {
+	return nil, ErrOperationNotSupportedInSimulation
+}
+
+// JournalLen returns 0 as the simulation is not journalized
+func (sa *simulationAccounts) JournalLen() int {
+	return 0
+}
+
+// RevertToSnapshot is not supported in simulation
+func (sa *simulationAccounts) RevertToSnapshot(_ int) error {
+	return ErrOperationNotSupportedInSimulation
+}
+
+// GetCode returns the code from the underlying adapter
+func (sa *simulationAccounts) GetCode(codeHash []byte) []byte {
+	return sa.accounts.GetCode(codeHash)
+}
+
+// RootHash is not supported in simulation
+func (sa *simulationAccounts) RootHash() ([]byte, error) {
+	return nil, ErrOperationNotSupportedInSimulation
+}
+
+// computePreState returns the state of all the loaded accounts before the simulation, sorted by address.
+// Only the accessed storage keys are included.
+func (sa *simulationAccounts) computePreState() []*vmcommon.AccountState {
+	sa.mutAccounts.RLock()
+	defer sa.mutAccounts.RUnlock()
+
+	addresses := make([]string, 0, len(sa.records))
+	for address := range sa.records {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	preState := make([]*vmcommon.AccountState, 0, len(addresses))
+	for _, address := range addresses {
+		record := sa.records[address]
+		preState = append(preState, &vmcommon.AccountState{
+			Address:         []byte(address),
+			Nonce:           record.originalState.nonce,
+			Balance:         big.NewInt(0).Set(record.originalState.balance),
+			DeveloperReward: big.NewInt(0).Set(record.originalState.developerReward),
+			OwnerAddress:    record.originalState.ownerAddress,
+			UserName:        record.originalState.userName,
+			CodeMetadata:    record.originalState.codeMetadata,
+			Storage:         record.storage.originalEntries(),
+		})
+	}
+
+	return preState
+}
+
+// computeStateDiff returns the accesses and changes of all the loaded accounts, sorted by address
+func (sa *simulationAccounts) computeStateDiff() ([]*vmcommon.AccountDiff, error) {
+	sa.mutAccounts.RLock()
+	defer sa.mutAccounts.RUnlock()
+
+	return computeStateDiff(sa.records)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sa *simulationAccounts) IsInterfaceNil() bool {
+	return sa == nil
+}
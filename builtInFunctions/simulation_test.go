@@ -0,0 +1,397 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+var simulationTokenID = []byte("TKN-abcdef")
+
+func createSimulationAddress(firstByte byte) []byte {
+	address := make([]byte, 32)
+	address[0] = firstByte
+
+	return address
+}
+
+func createSimulationCreator(t *testing.T, adb *inMemoryState.AccountsAdapter) *builtInFuncCreator {
+	args := createMockArguments()
+	args.Accounts = adb
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSaveToSystemAccountFlagEnabledField: true,
+	}
+
+	b, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	require.Nil(t, b.CreateBuiltInFunctionContainer())
+	require.Nil(t, b.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	return b
+}
+
+func saveSimulationDCTBalance(t *testing.T, adb *inMemoryState.AccountsAdapter, address []byte, value int64) {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	marshaller := &mock.MarshalizerMock{}
+	tokenKey := append([]byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier), simulationTokenID...)
+	marshalledToken, err := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(value)})
+	require.Nil(t, err)
+
+	userAccount := account.(vmcommon.UserAccountHandler)
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue(tokenKey, marshalledToken))
+	require.Nil(t, adb.SaveAccount(userAccount))
+}
+
+func createSimulationTransferInput(sender []byte, receiver []byte, value int64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   [][]byte{simulationTokenID, big.NewInt(value).Bytes()},
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+		},
+		RecipientAddr: receiver,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+}
+
+func TestBuiltInFuncCreator_SimulateBuiltInFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	b := createSimulationCreator(t, inMemoryState.NewAccountsAdapter())
+
+	result, err := b.SimulateBuiltInFunction(nil)
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createSimulationTransferInput(createSimulationAddress(1), createSimulationAddress(2), 1)
+	input.Function = "missing"
+	result, err = b.SimulateBuiltInFunction(input)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrInvalidContainerKey)
+}
+
+func TestBuiltInFuncCreator_SimulateBuiltInFunctionShouldNotChangeTheAccounts(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	_, _ = adb.Commit()
+	rootHashBefore, _ := adb.RootHash()
+
+	b := createSimulationCreator(t, adb)
+	result, err := b.SimulateBuiltInFunction(createSimulationTransferInput(alice, bob, 30))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+	require.Equal(t, 3, len(result.VMOutput.StateDiff))
+
+	rootHashAfter, _ := adb.RootHash()
+	assert.Equal(t, rootHashBefore, rootHashAfter)
+	assert.Equal(t, 0, adb.JournalLen())
+	_, err = adb.GetExistingAccount(bob)
+	assert.Equal(t, inMemoryState.ErrAccountNotFound, err)
+
+	systemAccountDiff := result.VMOutput.StateDiff[2]
+	assert.Equal(t, vmcommon.SystemAccountAddress, systemAccountDiff.Address)
+	assert.NotEmpty(t, systemAccountDiff.StorageReads)
+	assert.Empty(t, systemAccountDiff.StorageChanges)
+
+	marshaller := &mock.MarshalizerMock{}
+	tokenKey := append([]byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier), simulationTokenID...)
+	expectedValues := map[string][2]int64{
+		string(alice): {100, 70},
+		string(bob):   {0, 30},
+	}
+	for _, accountDiff := range result.VMOutput.StateDiff[:2] {
+		values := expectedValues[string(accountDiff.Address)]
+		require.Equal(t, 1, len(accountDiff.StorageChanges))
+
+		storageChange := accountDiff.StorageChanges[0]
+		assert.Equal(t, tokenKey, storageChange.Key)
+		assert.Equal(t, big.NewInt(0), accountDiff.BalanceDelta)
+
+		newToken := &dct.DCToken{}
+		require.Nil(t, marshaller.Unmarshal(newToken, storageChange.NewValue))
+		assert.Equal(t, big.NewInt(values[1]), newToken.Value)
+
+		if values[0] == 0 {
+			assert.Nil(t, storageChange.OldValue)
+			continue
+		}
+		oldToken := &dct.DCToken{}
+		require.Nil(t, marshaller.Unmarshal(oldToken, storageChange.OldValue))
+		assert.Equal(t, big.NewInt(values[0]), oldToken.Value)
+	}
+}
+
+func TestBuiltInFuncCreator_SimulateBuiltInFunctionFailedCall(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+
+	b := createSimulationCreator(t, adb)
+	result, err := b.SimulateBuiltInFunction(createSimulationTransferInput(alice, createSimulationAddress(2), 1000))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.OutOfFunds, result.VMOutput.ReturnCode)
+	assert.Equal(t, core.BuiltInFunctionDCTTransfer+": "+ErrInsufficientFunds.Error(), result.VMOutput.ReturnMessage)
+	assert.Equal(t, uint64(0), result.VMOutput.GasRemaining)
+	assert.Empty(t, result.VMOutput.StateDiff)
+}
+
+func TestBuiltInFuncCreator_SimulateBuiltInFunctionShouldUseTheConfiguredFunctions(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	registry := NewRegistry()
+	_ = registry.Register(CustomBuiltInFunction{
+		Name: customFunctionName,
+		Constructor: func(args ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			return &mock.BuiltInFunctionStub{
+				ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+					account, err := args.Accounts.LoadAccount(input.RecipientAddr)
+					if err != nil {
+						return nil, err
+					}
+					err = account.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+					if err != nil {
+						return nil, err
+					}
+
+					return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, args.Accounts.SaveAccount(account)
+				},
+			}, nil
+		},
+	})
+	args := createCustomRegistryArguments(registry)
+	args.Accounts = adb
+	b, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, b.CreateBuiltInFunctionContainer())
+
+	input := createSimulationTransferInput(alice, bob, 0)
+	input.Function = customFunctionName
+	result, err := b.SimulateBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+	require.Equal(t, 1, len(result.VMOutput.StateDiff))
+	assert.Equal(t, bob, result.VMOutput.StateDiff[0].Address)
+	assert.Equal(t, []*vmcommon.StorageChange{
+		{Key: []byte("key"), NewValue: []byte("value")},
+	}, result.VMOutput.StateDiff[0].StorageChanges)
+	assert.Empty(t, adb.Addresses())
+
+	b = createSimulationCreator(t, adb)
+	err = b.SetPayableHandler(&mock.PayableHandlerStub{
+		IsPayableCalled: func(_ []byte) (bool, error) {
+			return false, nil
+		},
+	})
+	require.Nil(t, err)
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	result, err = b.SimulateBuiltInFunction(createSimulationTransferInput(alice, bob, 10))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.UserError, result.VMOutput.ReturnCode)
+	assert.Equal(t, core.BuiltInFunctionDCTTransfer+": "+ErrAccountNotPayable.Error(), result.VMOutput.ReturnMessage)
+}
+
+func TestBuiltInFuncCreator_SimulateBuiltInFunctionShouldNotHoldTheProcessedCalls(t *testing.T) {
+	t.Parallel()
+
+	simulationStarted := make(chan struct{})
+	endSimulation := make(chan struct{})
+	registry := NewRegistry()
+	_ = registry.Register(CustomBuiltInFunction{
+		Name: customFunctionName,
+		Constructor: func(_ ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			return &mock.BuiltInFunctionStub{
+				ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+					if len(input.Arguments) > 0 {
+						close(simulationStarted)
+						<-endSimulation
+					}
+
+					return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+				},
+			}, nil
+		},
+	})
+	args := createCustomRegistryArguments(registry)
+	args.Accounts = inMemoryState.NewAccountsAdapter()
+	b, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, b.CreateBuiltInFunctionContainer())
+
+	simulationDone := make(chan struct{})
+	go func() {
+		defer close(simulationDone)
+
+		input := &vmcommon.ContractCallInput{Function: customFunctionName}
+		input.Arguments = [][]byte{[]byte("wait")}
+		result, err := b.SimulateBuiltInFunction(input)
+		assert.Nil(t, err)
+		assert.Equal(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+	}()
+	<-simulationStarted
+
+	container := b.BuiltInFunctionContainer().(*functionContainer)
+	function, _ := container.Get(customFunctionName)
+	vmOutput, err := container.ProcessBuiltinFunction(function, nil, nil, &vmcommon.ContractCallInput{Function: customFunctionName})
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	close(endSimulation)
+	<-simulationDone
+}
+
+func TestBuiltInFuncCreator_SimulateBuiltInFunctionConcurrentlyWithProcessedCalls(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	b := createSimulationCreator(t, adb)
+	be, _ := NewBatchExecutor(createBatchExecutorArgs(b.BuiltInFunctionContainer(), adb))
+
+	numCalls := 20
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < numCalls; i++ {
+			batchResult, err := be.ProcessBatch([]*vmcommon.ContractCallInput{createSimulationTransferInput(alice, bob, 1)})
+			assert.Nil(t, err)
+			assert.Nil(t, batchResult.Results[0].Err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < numCalls; i++ {
+			result, err := b.SimulateBuiltInFunction(createSimulationTransferInput(bob, alice, 1000))
+			assert.Nil(t, err)
+			assert.Equal(t, vmcommon.OutOfFunds, result.VMOutput.ReturnCode)
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, int64(100-numCalls), getSimulationDCTBalance(t, adb, alice))
+	assert.Equal(t, int64(numCalls), getSimulationDCTBalance(t, adb, bob))
+}
+
+func TestBuiltInFuncCreator_RecordBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	b := createSimulationCreator(t, adb)
+
+	t.Run("nil input should error", func(t *testing.T) {
+		record, err := b.RecordBuiltInFunction(nil)
+		assert.Nil(t, record)
+		assert.Equal(t, ErrNilVmInput, err)
+	})
+	t.Run("successful call", func(t *testing.T) {
+		input := createSimulationTransferInput(alice, bob, 30)
+		record, err := b.RecordBuiltInFunction(input)
+		require.Nil(t, err)
+
+		assert.True(t, input == record.Input)
+		assert.Empty(t, record.Error)
+		require.NotNil(t, record.VMOutput)
+		assert.Equal(t, vmcommon.Ok, record.VMOutput.ReturnCode)
+		assert.NotEmpty(t, record.VMOutput.StateDiff)
+
+		require.Equal(t, 3, len(record.PreState))
+		aliceState := record.PreState[0]
+		assert.Equal(t, alice, aliceState.Address)
+		require.Equal(t, 1, len(aliceState.Storage))
+		tokenKey := append([]byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier), simulationTokenID...)
+		assert.Equal(t, tokenKey, aliceState.Storage[0].Key)
+		assert.NotEmpty(t, aliceState.Storage[0].Value)
+
+		bobState := record.PreState[1]
+		assert.Equal(t, bob, bobState.Address)
+		require.Equal(t, 1, len(bobState.Storage))
+		assert.Nil(t, bobState.Storage[0].Value)
+
+		assert.Equal(t, int64(100), getSimulationDCTBalance(t, adb, alice))
+	})
+	t.Run("failed call", func(t *testing.T) {
+		record, err := b.RecordBuiltInFunction(createSimulationTransferInput(alice, bob, 1000))
+		require.Nil(t, err)
+
+		assert.Equal(t, ErrInsufficientFunds.Error(), record.Error)
+		assert.Nil(t, record.VMOutput)
+		assert.NotEmpty(t, record.PreState)
+	})
+}
+
+func TestSimulationAccounts(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+	sa := newSimulationAccounts(adb)
+
+	t.Run("accounts are loaded once", func(t *testing.T) {
+		first, err := sa.LoadAccount(alice)
+		require.Nil(t, err)
+		second, err := sa.GetExistingAccount(alice)
+		require.Nil(t, err)
+		assert.True(t, first == second)
+	})
+	t.Run("saving a different instance of a loaded account should error", func(t *testing.T) {
+		err := sa.SaveAccount(mock.NewUserAccount(alice))
+		assert.Equal(t, ErrOperationNotSupportedInSimulation, err)
+	})
+	t.Run("unsupported operations should error", func(t *testing.T) {
+		assert.Equal(t, ErrOperationNotSupportedInSimulation, sa.RemoveAccount(alice))
+		assert.Equal(t, ErrOperationNotSupportedInSimulation, sa.RevertToSnapshot(0))
+		_, err := sa.Commit()
+		assert.Equal(t, ErrOperationNotSupportedInSimulation, err)
+		_, err = sa.RootHash()
+		assert.Equal(t, ErrOperationNotSupportedInSimulation, err)
+	})
+	t.Run("unchanged saved accounts are not part of the diff", func(t *testing.T) {
+		account, _ := sa.LoadAccount(alice)
+		require.Nil(t, sa.SaveAccount(account))
+
+		stateDiff, err := sa.computeStateDiff()
+		require.Nil(t, err)
+		assert.Empty(t, stateDiff)
+	})
+	t.Run("balance and owner changes are part of the diff", func(t *testing.T) {
+		account, _ := sa.LoadAccount(alice)
+		userAccount := account.(vmcommon.UserAccountHandler)
+		require.Nil(t, userAccount.AddToBalance(big.NewInt(5)))
+		userAccount.SetOwnerAddress(alice)
+		require.Nil(t, sa.SaveAccount(account))
+
+		stateDiff, err := sa.computeStateDiff()
+		require.Nil(t, err)
+		require.Equal(t, 1, len(stateDiff))
+		assert.Equal(t, big.NewInt(5), stateDiff[0].BalanceDelta)
+		assert.Equal(t, &vmcommon.ValueChange{New: alice}, stateDiff[0].OwnerAddress)
+		assert.Nil(t, stateDiff[0].UserName)
+		assert.Empty(t, stateDiff[0].StorageChanges)
+	})
+}
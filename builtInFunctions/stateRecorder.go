@@ -0,0 +1,720 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.AccountsAdapter = (*stateRecorder)(nil)
+var _ vmcommon.UserAccountHandler = (*recordingUserAccount)(nil)
+var _ vmcommon.AccountDataHandler = (*recordingDataHandler)(nil)
+
+// stateRecorder is an accounts adapter wrapper that records every storage key read and written through the
+// accounts it hands out. The built-in functions creator sets one up for each recording lane when RecordStateDiff
+// is enabled: the built-in functions of the lane load their accounts through it, so those accounts are recorded as
+// well, and the container processes the calls of the lane through it. A recorder holds the records of one call.
+type stateRecorder struct {
+	mutRecords sync.RWMutex
+	accounts   vmcommon.AccountsAdapter
+	records    map[string]*accountRecord
+}
+
+func newStateRecorder(accounts vmcommon.AccountsAdapter) (*stateRecorder, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	return &stateRecorder{
+		accounts: accounts,
+		records:  make(map[string]*accountRecord),
+	}, nil
+}
+
+// ProcessBuiltinFunction runs the built-in function on recorded accounts and attaches the state diff to the
+// output. The records of the previous call are dropped, so the calls must not overlap.
+func (sr *stateRecorder) ProcessBuiltinFunction(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if check.IfNil(function) {
+		return nil, ErrNilContainerElement
+	}
+
+	sr.reset()
+	recordedSnd := sr.recordHostAccount(acntSnd)
+	recordedDst := recordedSnd
+	if acntDst != acntSnd {
+		recordedDst = sr.recordHostAccount(acntDst)
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(recordedSnd, recordedDst, vmInput)
+	if err != nil {
+		return nil, err
+	}
+
+	sr.mutRecords.RLock()
+	vmOutput.StateDiff, err = computeStateDiff(sr.records)
+	sr.mutRecords.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return vmOutput, nil
+}
+
+func (sr *stateRecorder) reset() {
+	sr.mutRecords.Lock()
+	sr.records = make(map[string]*accountRecord)
+	sr.mutRecords.Unlock()
+}
+
+// recordHostAccount wraps an account provided by the host. As the host saves its accounts after the execution,
+// their state takes precedence over the other instances loaded for the same address.
+func (sr *stateRecorder) recordHostAccount(account vmcommon.UserAccountHandler) vmcommon.UserAccountHandler {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	recordedAccount := sr.recordAccount(account, true)
+	userAccount, ok := recordedAccount.(vmcommon.UserAccountHandler)
+	if !ok {
+		return account
+	}
+
+	return userAccount
+}
+
+func (sr *stateRecorder) recordAccount(account vmcommon.AccountHandler, isHostAccount bool) vmcommon.AccountHandler {
+	sr.mutRecords.Lock()
+	defer sr.mutRecords.Unlock()
+
+	address := string(account.AddressBytes())
+	record, found := sr.records[address]
+	if !found {
+		record = newAccountRecord(account)
+		record.isHostAccount = isHostAccount
+		sr.records[address] = record
+
+		return record.account
+	}
+
+	recordedAccount := record.wrap(account)
+	if isHostAccount || !record.isHostAccount {
+		record.account = recordedAccount
+		record.isHostAccount = isHostAccount
+	}
+
+	return recordedAccount
+}
+
+// GetExistingAccount returns the recorded existing account
+func (sr *stateRecorder) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := sr.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(account) {
+		return nil, ErrNilAccountHandler
+	}
+
+	return sr.recordAccount(account, false), nil
+}
+
+// LoadAccount returns the recorded existing or new account
+func (sr *stateRecorder) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := sr.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(account) {
+		return nil, ErrNilAccountHandler
+	}
+
+	return sr.recordAccount(account, false), nil
+}
+
+// SaveAccount saves the account in the underlying adapter
+func (sr *stateRecorder) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccountHandler
+	}
+
+	err := sr.accounts.SaveAccount(unwrapRecordedAccount(account))
+	if err != nil {
+		return err
+	}
+
+	sr.mutRecords.Lock()
+	defer sr.mutRecords.Unlock()
+
+	record, found := sr.records[string(account.AddressBytes())]
+	if found && !record.isHostAccount {
+		record.account = account
+	}
+
+	return nil
+}
+
+// RemoveAccount removes the account from the underlying adapter
+func (sr *stateRecorder) RemoveAccount(address []byte) error {
+	return sr.accounts.RemoveAccount(address)
+}
+
+// Commit commits the underlying adapter
+func (sr *stateRecorder) Commit() ([]byte, error) {
+	return sr.accounts.Commit()
+}
+
+// JournalLen returns the journal length of the underlying adapter
+func (sr *stateRecorder) JournalLen() int {
+	return sr.accounts.JournalLen()
+}
+
+// RevertToSnapshot reverts the underlying adapter to the provided snapshot
+func (sr *stateRecorder) RevertToSnapshot(snapshot int) error {
+	return sr.accounts.RevertToSnapshot(snapshot)
+}
+
+// GetCode returns the code from the underlying adapter
+func (sr *stateRecorder) GetCode(codeHash []byte) []byte {
+	return sr.accounts.GetCode(codeHash)
+}
+
+// RootHash returns the root hash of the underlying adapter
+func (sr *stateRecorder) RootHash() ([]byte, error) {
+	return sr.accounts.RootHash()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sr *stateRecorder) IsInterfaceNil() bool {
+	return sr == nil
+}
+
+// recordingLane is a set of built-in functions working on a state recorder of its own. A lane is handed out to one
+// call at a time, so its recorder holds the accesses of that call only.
+type recordingLane struct {
+	recorder *stateRecorder
+	creator  *builtInFuncCreator
+}
+
+// recordingLanes hands out the lanes the recorded calls are processed on. A new lane is created when all the lanes
+// are busy, so the number of lanes follows the number of calls processed at the same time.
+type recordingLanes struct {
+	mutLanes            sync.Mutex
+	lanes               []*recordingLane
+	freeLanes           []*recordingLane
+	configuredFunctions map[string]vmcommon.BuiltinFunction
+	createLane          func() (*recordingLane, error)
+}
+
+func newRecordingLanes(
+	configuredFunctions map[string]vmcommon.BuiltinFunction,
+	createLane func() (*recordingLane, error),
+) *recordingLanes {
+	return &recordingLanes{
+		lanes:               make([]*recordingLane, 0),
+		freeLanes:           make([]*recordingLane, 0),
+		configuredFunctions: configuredFunctions,
+		createLane:          createLane,
+	}
+}
+
+// processBuiltinFunction runs the call on a free lane and returns the output carrying the state diff of the call
+func (rl *recordingLanes) processBuiltinFunction(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	lane, err := rl.acquireLane()
+	if err != nil {
+		return nil, err
+	}
+	defer rl.releaseLane(lane)
+
+	return lane.recorder.ProcessBuiltinFunction(rl.laneFunction(lane, function, vmInput), acntSnd, acntDst, vmInput)
+}
+
+// laneFunction returns the instance of the lane matching a function configured by the creator. Other functions run
+// as they are, so only the accounts handed to them by the host are recorded.
+func (rl *recordingLanes) laneFunction(
+	lane *recordingLane,
+	function vmcommon.BuiltinFunction,
+	vmInput *vmcommon.ContractCallInput,
+) vmcommon.BuiltinFunction {
+	if vmInput == nil {
+		return function
+	}
+
+	configuredFunction, found := rl.configuredFunctions[vmInput.Function]
+	if !found || configuredFunction != function {
+		return function
+	}
+
+	laneFunction, err := lane.creator.builtInFunctions.Get(vmInput.Function)
+	if err != nil {
+		return function
+	}
+
+	return laneFunction
+}
+
+func (rl *recordingLanes) acquireLane() (*recordingLane, error) {
+	rl.mutLanes.Lock()
+	defer rl.mutLanes.Unlock()
+
+	numFreeLanes := len(rl.freeLanes)
+	if numFreeLanes > 0 {
+		lane := rl.freeLanes[numFreeLanes-1]
+		rl.freeLanes = rl.freeLanes[:numFreeLanes-1]
+
+		return lane, nil
+	}
+
+	lane, err := rl.createLane()
+	if err != nil {
+		return nil, err
+	}
+	rl.lanes = append(rl.lanes, lane)
+
+	return lane, nil
+}
+
+func (rl *recordingLanes) releaseLane(lane *recordingLane) {
+	rl.mutLanes.Lock()
+	rl.freeLanes = append(rl.freeLanes, lane)
+	rl.mutLanes.Unlock()
+}
+
+// forEachCreator calls the handler for the creators of all the lanes, busy or not
+func (rl *recordingLanes) forEachCreator(handler func(creator *builtInFuncCreator) error) error {
+	rl.mutLanes.Lock()
+	defer rl.mutLanes.Unlock()
+
+	for _, lane := range rl.lanes {
+		err := handler(lane.creator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setRecordingLanes makes the container process the calls on recording lanes. The first lane is created right away,
+// so that the configuration errors are returned by the creation of the container.
+func (b *builtInFuncCreator) setRecordingLanes() error {
+	functionContainer, ok := b.builtInFunctions.(*functionContainer)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	configuredFunctions := make(map[string]vmcommon.BuiltinFunction)
+	for key := range b.builtInFunctions.Keys() {
+		function, err := b.builtInFunctions.Get(key)
+		if err != nil {
+			return err
+		}
+
+		configuredFunctions[key] = function
+	}
+
+	lanes := newRecordingLanes(configuredFunctions, b.createRecordingLane)
+	lane, err := lanes.acquireLane()
+	if err != nil {
+		return err
+	}
+	lanes.releaseLane(lane)
+
+	b.recordingLanes = lanes
+	functionContainer.setRecordingLanes(lanes)
+
+	return nil
+}
+
+// createRecordingLane creates a new set of the configured built-in functions, working on a new state recorder
+func (b *builtInFuncCreator) createRecordingLane() (*recordingLane, error) {
+	recorder, err := newStateRecorder(b.accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	laneCreator := b.newDerivedCreator(recorder)
+	laneCreator.gasTracer = b.gasTracer
+	err = laneCreator.createBuiltInFunctions()
+	if err != nil {
+		return nil, err
+	}
+
+	payableChecker := b.getPayableChecker()
+	if !check.IfNil(payableChecker) {
+		err = laneCreator.setPayableChecker(payableChecker)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &recordingLane{
+		recorder: recorder,
+		creator:  laneCreator,
+	}, nil
+}
+
+// accountState holds the account fields as they were when the account was first recorded
+type accountState struct {
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	ownerAddress    []byte
+	userName        []byte
+	codeMetadata    []byte
+}
+
+// accountRecord holds the original state and the storage accesses of one address. The account field
+// is the instance whose state is reported as the final one.
+type accountRecord struct {
+	account       vmcommon.AccountHandler
+	originalState *accountState
+	storage       *storageRecord
+	isHostAccount bool
+}
+
+func newAccountRecord(account vmcommon.AccountHandler) *accountRecord {
+	record := &accountRecord{
+		originalState: createAccountState(account),
+		storage:       newStorageRecord(),
+	}
+	record.account = record.wrap(account)
+
+	return record
+}
+
+// wrap returns an account instance whose storage accesses are added to the record
+func (record *accountRecord) wrap(account vmcommon.AccountHandler) vmcommon.AccountHandler {
+	userAccount, ok := unwrapRecordedAccount(account).(vmcommon.UserAccountHandler)
+	if !ok || check.IfNil(userAccount.AccountDataHandler()) {
+		return account
+	}
+
+	return &recordingUserAccount{
+		UserAccountHandler: userAccount,
+		dataHandler: &recordingDataHandler{
+			AccountDataHandler: userAccount.AccountDataHandler(),
+			storage:            record.storage,
+		},
+	}
+}
+
+func unwrapRecordedAccount(account vmcommon.AccountHandler) vmcommon.AccountHandler {
+	recordedAccount, ok := account.(*recordingUserAccount)
+	if !ok {
+		return account
+	}
+
+	return recordedAccount.UserAccountHandler
+}
+
+// storageRecord holds the accessed keys of one address. The original value of a key is the one seen at its
+// first access, as long as that access was not a write.
+type storageRecord struct {
+	mutStorage     sync.Mutex
+	originalValues map[string][]byte
+	readValues     map[string][]byte
+	readKeys       [][]byte
+	writtenKeys    map[string]struct{}
+	writeOrder     [][]byte
+}
+
+func newStorageRecord() *storageRecord {
+	return &storageRecord{
+		originalValues: make(map[string][]byte),
+		readValues:     make(map[string][]byte),
+		readKeys:       make([][]byte, 0),
+		writtenKeys:    make(map[string]struct{}),
+		writeOrder:     make([][]byte, 0),
+	}
+}
+
+func (sr *storageRecord) addRead(key []byte, value []byte) {
+	sr.mutStorage.Lock()
+	defer sr.mutStorage.Unlock()
+
+	_, found := sr.originalValues[string(key)]
+	if !found {
+		sr.originalValues[string(key)] = copyBytes(value)
+	}
+
+	_, found = sr.readValues[string(key)]
+	if !found {
+		sr.readValues[string(key)] = copyBytes(value)
+		sr.readKeys = append(sr.readKeys, copyBytes(key))
+	}
+}
+
+func (sr *storageRecord) hasOriginalValue(key []byte) bool {
+	sr.mutStorage.Lock()
+	defer sr.mutStorage.Unlock()
+
+	_, found := sr.originalValues[string(key)]
+	return found
+}
+
+func (sr *storageRecord) addWrite(key []byte, originalValue []byte, isOriginalValueKnown bool) {
+	sr.mutStorage.Lock()
+	defer sr.mutStorage.Unlock()
+
+	if isOriginalValueKnown {
+		_, found := sr.originalValues[string(key)]
+		if !found {
+			sr.originalValues[string(key)] = copyBytes(originalValue)
+		}
+	}
+
+	_, found := sr.writtenKeys[string(key)]
+	if !found {
+		sr.writtenKeys[string(key)] = struct{}{}
+		sr.writeOrder = append(sr.writeOrder, copyBytes(key))
+	}
+}
+
+// originalEntries returns the values the accessed keys had before the first access, sorted by key
+func (sr *storageRecord) originalEntries() []*vmcommon.StorageEntry {
+	sr.mutStorage.Lock()
+	defer sr.mutStorage.Unlock()
+
+	keys := make([]string, 0, len(sr.originalValues))
+	for key := range sr.originalValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*vmcommon.StorageEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, &vmcommon.StorageEntry{
+			Key:   []byte(key),
+			Value: sr.originalValues[key],
+		})
+	}
+
+	return entries
+}
+
+func (sr *storageRecord) storageReads() []*vmcommon.StorageRead {
+	sr.mutStorage.Lock()
+	defer sr.mutStorage.Unlock()
+
+	reads := make([]*vmcommon.StorageRead, 0, len(sr.readKeys))
+	for _, key := range sr.readKeys {
+		reads = append(reads, &vmcommon.StorageRead{
+			Key:   key,
+			Value: sr.readValues[string(key)],
+		})
+	}
+
+	return reads
+}
+
+func (sr *storageRecord) storageChanges(dataHandler vmcommon.AccountDataHandler) ([]*vmcommon.StorageChange, error) {
+	sr.mutStorage.Lock()
+	defer sr.mutStorage.Unlock()
+
+	changes := make([]*vmcommon.StorageChange, 0, len(sr.writeOrder))
+	if check.IfNil(dataHandler) {
+		return changes, nil
+	}
+
+	for _, key := range sr.writeOrder {
+		newValue, _, err := dataHandler.RetrieveValue(key)
+		if err != nil {
+			return nil, err
+		}
+
+		oldValue := sr.originalValues[string(key)]
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, &vmcommon.StorageChange{
+			Key:      key,
+			OldValue: oldValue,
+			NewValue: copyBytes(newValue),
+		})
+	}
+
+	return changes, nil
+}
+
+// recordingUserAccount is a user account whose data handler records all the storage accesses
+type recordingUserAccount struct {
+	vmcommon.UserAccountHandler
+	dataHandler *recordingDataHandler
+}
+
+// AccountDataHandler returns the recording data handler
+func (rua *recordingUserAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return rua.dataHandler
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rua *recordingUserAccount) IsInterfaceNil() bool {
+	return rua == nil
+}
+
+// recordingDataHandler records every read and write done through it
+type recordingDataHandler struct {
+	vmcommon.AccountDataHandler
+	storage *storageRecord
+}
+
+// RetrieveValue returns the value of the key and records the read
+func (rdh *recordingDataHandler) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, depth, err := rdh.AccountDataHandler.RetrieveValue(key)
+	if err != nil {
+		return nil, depth, err
+	}
+
+	rdh.storage.addRead(key, value)
+
+	return value, depth, nil
+}
+
+// SaveKeyValue records the write, then saves the value
+func (rdh *recordingDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	var originalValue []byte
+	isOriginalValueKnown := rdh.storage.hasOriginalValue(key)
+	if !isOriginalValueKnown {
+		var err error
+		originalValue, _, err = rdh.AccountDataHandler.RetrieveValue(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	rdh.storage.addWrite(key, originalValue, !isOriginalValueKnown)
+
+	return rdh.AccountDataHandler.SaveKeyValue(key, value)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rdh *recordingDataHandler) IsInterfaceNil() bool {
+	return rdh == nil
+}
+
+// computeStateDiff returns the non-empty account diffs of the provided records, sorted by address
+func computeStateDiff(records map[string]*accountRecord) ([]*vmcommon.AccountDiff, error) {
+	addresses := make([]string, 0, len(records))
+	for address := range records {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	stateDiff := make([]*vmcommon.AccountDiff, 0, len(addresses))
+	for _, address := range addresses {
+		accountDiff, err := computeAccountDiff(records[address])
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyAccountDiff(accountDiff) {
+			continue
+		}
+
+		stateDiff = append(stateDiff, accountDiff)
+	}
+
+	return stateDiff, nil
+}
+
+func computeAccountDiff(record *accountRecord) (*vmcommon.AccountDiff, error) {
+	currentState := createAccountState(record.account)
+	originalState := record.originalState
+
+	var dataHandler vmcommon.AccountDataHandler
+	userAccount, ok := unwrapRecordedAccount(record.account).(vmcommon.UserAccountHandler)
+	if ok {
+		dataHandler = userAccount.AccountDataHandler()
+	}
+
+	storageChanges, err := record.storage.storageChanges(dataHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.AccountDiff{
+		Address:              record.account.AddressBytes(),
+		NonceDelta:           currentState.nonce - originalState.nonce,
+		BalanceDelta:         big.NewInt(0).Sub(currentState.balance, originalState.balance),
+		DeveloperRewardDelta: big.NewInt(0).Sub(currentState.developerReward, originalState.developerReward),
+		OwnerAddress:         createValueChange(originalState.ownerAddress, currentState.ownerAddress),
+		UserName:             createValueChange(originalState.userName, currentState.userName),
+		CodeMetadata:         createValueChange(originalState.codeMetadata, currentState.codeMetadata),
+		StorageReads:         record.storage.storageReads(),
+		StorageChanges:       storageChanges,
+	}, nil
+}
+
+func isEmptyAccountDiff(accountDiff *vmcommon.AccountDiff) bool {
+	return accountDiff.NonceDelta == 0 &&
+		accountDiff.BalanceDelta.Sign() == 0 &&
+		accountDiff.DeveloperRewardDelta.Sign() == 0 &&
+		accountDiff.OwnerAddress == nil &&
+		accountDiff.UserName == nil &&
+		accountDiff.CodeMetadata == nil &&
+		len(accountDiff.StorageReads) == 0 &&
+		len(accountDiff.StorageChanges) == 0
+}
+
+func createAccountState(account vmcommon.AccountHandler) *accountState {
+	state := &accountState{
+		nonce:           account.GetNonce(),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return state
+	}
+
+	state.balance = bigIntOrZero(userAccount.GetBalance())
+	state.developerReward = bigIntOrZero(userAccount.GetDeveloperReward())
+	state.ownerAddress = copyBytes(userAccount.GetOwnerAddress())
+	state.userName = copyBytes(userAccount.GetUserName())
+	state.codeMetadata = copyBytes(userAccount.GetCodeMetadata())
+
+	return state
+}
+
+func createValueChange(oldValue []byte, newValue []byte) *vmcommon.ValueChange {
+	if bytes.Equal(oldValue, newValue) {
+		return nil
+	}
+
+	return &vmcommon.ValueChange{
+		Old: oldValue,
+		New: newValue,
+	}
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(value)
+}
+
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	result := make([]byte, len(data))
+	copy(result, data)
+
+	return result
+}
@@ -0,0 +1,339 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func loadRecorderTestAccount(t *testing.T, adb vmcommon.AccountsAdapter, address []byte) vmcommon.UserAccountHandler {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(vmcommon.UserAccountHandler)
+}
+
+func TestNewStateRecorder(t *testing.T) {
+	t.Parallel()
+
+	sr, err := newStateRecorder(nil)
+	assert.Nil(t, sr)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	sr, err = newStateRecorder(inMemoryState.NewAccountsAdapter())
+	assert.Nil(t, err)
+	assert.False(t, sr.IsInterfaceNil())
+}
+
+func TestStateRecorder_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	sr, _ := newStateRecorder(inMemoryState.NewAccountsAdapter())
+	vmOutput, err := sr.ProcessBuiltinFunction(nil, nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, ErrNilContainerElement, err)
+
+	expectedErr := errors.New("expected error")
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return nil, expectedErr
+		},
+	}
+	vmOutput, err = sr.ProcessBuiltinFunction(function, nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestStateRecorder_ProcessBuiltinFunctionRecordsReadsAndWrites(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	other := createSimulationAddress(3)
+	adb := inMemoryState.NewAccountsAdapter()
+	account := loadRecorderTestAccount(t, adb, alice)
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("read"), []byte("r"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("updated"), []byte("old"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("deleted"), []byte("d"))
+	_ = adb.SaveAccount(account)
+
+	sr, _ := newStateRecorder(adb)
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			dataHandler := acntSnd.AccountDataHandler()
+			_, _, _ = dataHandler.RetrieveValue([]byte("read"))
+			_, _, _ = dataHandler.RetrieveValue([]byte("missing"))
+			_ = dataHandler.SaveKeyValue([]byte("updated"), []byte("temporary"))
+			_ = dataHandler.SaveKeyValue([]byte("updated"), []byte("new"))
+			_ = dataHandler.SaveKeyValue([]byte("deleted"), nil)
+			_ = dataHandler.SaveKeyValue([]byte("created"), []byte("c"))
+			_ = dataHandler.SaveKeyValue([]byte("unchanged"), nil)
+			_ = acntSnd.AddToBalance(big.NewInt(10))
+
+			otherAccount, _ := sr.LoadAccount(other)
+			otherUserAccount := otherAccount.(vmcommon.UserAccountHandler)
+			_ = otherUserAccount.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+			_ = sr.SaveAccount(otherUserAccount)
+
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+
+	account = loadRecorderTestAccount(t, adb, alice)
+	vmOutput, err := sr.ProcessBuiltinFunction(function, account, nil, &vmcommon.ContractCallInput{})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(vmOutput.StateDiff))
+
+	aliceDiff := vmOutput.StateDiff[0]
+	assert.Equal(t, alice, aliceDiff.Address)
+	assert.Equal(t, big.NewInt(10), aliceDiff.BalanceDelta)
+	assert.Equal(t, []*vmcommon.StorageRead{
+		{Key: []byte("read"), Value: []byte("r")},
+		{Key: []byte("missing"), Value: nil},
+	}, aliceDiff.StorageReads)
+	assert.Equal(t, []*vmcommon.StorageChange{
+		{Key: []byte("updated"), OldValue: []byte("old"), NewValue: []byte("new")},
+		{Key: []byte("deleted"), OldValue: []byte("d"), NewValue: nil},
+		{Key: []byte("created"), OldValue: nil, NewValue: []byte("c")},
+	}, aliceDiff.StorageChanges)
+
+	otherDiff := vmOutput.StateDiff[1]
+	assert.Equal(t, other, otherDiff.Address)
+	assert.Equal(t, []*vmcommon.StorageChange{
+		{Key: []byte("key"), OldValue: nil, NewValue: []byte("value")},
+	}, otherDiff.StorageChanges)
+
+	savedValues, err := adb.GetAllState(other)
+	require.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("value")}, savedValues)
+}
+
+func TestStateRecorder_ProcessBuiltinFunctionWithCreatedContainer(t *testing.T) {
+	t.Parallel()
+
+	alice := createSimulationAddress(1)
+	bob := createSimulationAddress(2)
+	adb := inMemoryState.NewAccountsAdapter()
+	saveSimulationDCTBalance(t, adb, alice, 100)
+
+	sr, _ := newStateRecorder(adb)
+	args := createMockArguments()
+	args.Accounts = sr
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSaveToSystemAccountFlagEnabledField: true,
+	}
+	b, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, b.CreateBuiltInFunctionContainer())
+	require.Nil(t, b.SetPayableHandler(&mock.PayableHandlerStub{}))
+	function, _ := b.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+
+	acntSnd := loadRecorderTestAccount(t, adb, alice)
+	acntDst := loadRecorderTestAccount(t, adb, bob)
+	vmOutput, err := sr.ProcessBuiltinFunction(function, acntSnd, acntDst, createSimulationTransferInput(alice, bob, 40))
+	require.Nil(t, err)
+	require.Equal(t, 3, len(vmOutput.StateDiff))
+	assert.Equal(t, vmcommon.SystemAccountAddress, vmOutput.StateDiff[2].Address)
+
+	marshaller := &mock.MarshalizerMock{}
+	bobChanges := vmOutput.StateDiff[1].StorageChanges
+	require.Equal(t, 1, len(bobChanges))
+	token := &dct.DCToken{}
+	require.Nil(t, marshaller.Unmarshal(token, bobChanges[0].NewValue))
+	assert.Equal(t, big.NewInt(40), token.Value)
+
+	require.Nil(t, adb.SaveAccount(acntSnd))
+	require.Nil(t, adb.SaveAccount(acntDst))
+	bobState, err := adb.GetAllState(bob)
+	require.Nil(t, err)
+	assert.Equal(t, bobChanges[0].NewValue, bobState[string(bobChanges[0].Key)])
+}
+
+func TestStateRecorder_RecordStateDiffOnNormalCalls(t *testing.T) {
+	t.Parallel()
+
+	createRecordingCreator := func(t *testing.T, adb *inMemoryState.AccountsAdapter) *builtInFuncCreator {
+		args := createMockArguments()
+		args.Accounts = adb
+		args.RecordStateDiff = true
+		args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+			IsSaveToSystemAccountFlagEnabledField: true,
+		}
+		b, err := NewBuiltInFunctionsCreator(args)
+		require.Nil(t, err)
+		require.Nil(t, b.CreateBuiltInFunctionContainer())
+		require.Nil(t, b.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+		return b
+	}
+	checkTransferDiff := func(t *testing.T, vmOutput *vmcommon.VMOutput, alice []byte, bob []byte) {
+		require.Equal(t, 3, len(vmOutput.StateDiff))
+		assert.Equal(t, alice, vmOutput.StateDiff[0].Address)
+		assert.Equal(t, bob, vmOutput.StateDiff[1].Address)
+		assert.Equal(t, vmcommon.SystemAccountAddress, vmOutput.StateDiff[2].Address)
+
+		marshaller := &mock.MarshalizerMock{}
+		for i, expectedValue := range []int64{60, 40} {
+			changes := vmOutput.StateDiff[i].StorageChanges
+			require.Equal(t, 1, len(changes))
+			token := &dct.DCToken{}
+			require.Nil(t, marshaller.Unmarshal(token, changes[0].NewValue))
+			assert.Equal(t, big.NewInt(expectedValue), token.Value)
+		}
+	}
+
+	t.Run("the container should hand out the functions themselves", func(t *testing.T) {
+		t.Parallel()
+
+		b := createRecordingCreator(t, inMemoryState.NewAccountsAdapter())
+		function, err := b.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+		require.Nil(t, err)
+		_, ok := function.(*dctTransfer)
+		assert.True(t, ok)
+		_, ok = function.(vmcommon.AccessSetProvider)
+		assert.True(t, ok)
+		_, ok = b.BuiltInFunctionContainer().(vmcommon.BuiltInFunctionProcessor)
+		assert.True(t, ok)
+	})
+	t.Run("batch executor calls should carry the state diff", func(t *testing.T) {
+		t.Parallel()
+
+		alice := createSimulationAddress(1)
+		bob := createSimulationAddress(2)
+		adb := inMemoryState.NewAccountsAdapter()
+		saveSimulationDCTBalance(t, adb, alice, 100)
+		b := createRecordingCreator(t, adb)
+
+		be, _ := NewBatchExecutor(createBatchExecutorArgs(b.BuiltInFunctionContainer(), adb))
+		batchResult, err := be.ProcessBatch([]*vmcommon.ContractCallInput{createSimulationTransferInput(alice, bob, 40)})
+		require.Nil(t, err)
+		require.Nil(t, batchResult.Results[0].Err)
+
+		checkTransferDiff(t, batchResult.Results[0].VMOutput, alice, bob)
+		assert.Equal(t, int64(60), getSimulationDCTBalance(t, adb, alice))
+		assert.Equal(t, int64(40), getSimulationDCTBalance(t, adb, bob))
+	})
+	t.Run("blockchain hook calls should carry the state diff", func(t *testing.T) {
+		t.Parallel()
+
+		alice := createSimulationAddress(1)
+		bob := createSimulationAddress(2)
+		adb := inMemoryState.NewAccountsAdapter()
+		saveSimulationDCTBalance(t, adb, alice, 100)
+		b := createRecordingCreator(t, adb)
+
+		blockchainHook, err := inMemoryState.NewBlockchainHook(inMemoryState.ArgsBlockchainHook{
+			Accounts:              adb,
+			BuiltInFunctions:      b.BuiltInFunctionContainer(),
+			ShardCoordinator:      mock.NewMultiShardsCoordinatorMock(1),
+			NFTStorageHandler:     b.NFTStorageHandler(),
+			GlobalSettingsHandler: b.DCTGlobalSettingsHandler(),
+		})
+		require.Nil(t, err)
+
+		vmOutput, err := blockchainHook.ProcessBuiltInFunction(createSimulationTransferInput(alice, bob, 40))
+		require.Nil(t, err)
+
+		checkTransferDiff(t, vmOutput, alice, bob)
+		assert.Equal(t, int64(60), getSimulationDCTBalance(t, adb, alice))
+		assert.Equal(t, int64(40), getSimulationDCTBalance(t, adb, bob))
+	})
+	t.Run("concurrent calls should carry their own state diff", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 10
+		adb := inMemoryState.NewAccountsAdapter()
+		for i := 0; i < numCalls; i++ {
+			saveSimulationDCTBalance(t, adb, createSimulationAddress(byte(i+1)), 100)
+		}
+		b := createRecordingCreator(t, adb)
+		container := b.BuiltInFunctionContainer().(*functionContainer)
+		function, err := container.Get(core.BuiltInFunctionDCTTransfer)
+		require.Nil(t, err)
+
+		vmOutputs := make([]*vmcommon.VMOutput, numCalls)
+		wg := sync.WaitGroup{}
+		wg.Add(numCalls)
+		for i := 0; i < numCalls; i++ {
+			acntSnd := loadRecorderTestAccount(t, adb, createSimulationAddress(byte(i+1)))
+			acntDst := loadRecorderTestAccount(t, adb, createSimulationAddress(byte(i+101)))
+			go func(index int) {
+				defer wg.Done()
+
+				input := createSimulationTransferInput(acntSnd.AddressBytes(), acntDst.AddressBytes(), 40)
+				vmOutput, errProcess := container.ProcessBuiltinFunction(function, acntSnd, acntDst, input)
+				assert.Nil(t, errProcess)
+				vmOutputs[index] = vmOutput
+			}(i)
+		}
+		wg.Wait()
+
+		for i, vmOutput := range vmOutputs {
+			require.NotNil(t, vmOutput)
+			checkTransferDiff(t, vmOutput, createSimulationAddress(byte(i+1)), createSimulationAddress(byte(i+101)))
+		}
+	})
+	t.Run("a call should not wait for the other recorded calls", func(t *testing.T) {
+		t.Parallel()
+
+		alice := createSimulationAddress(1)
+		bob := createSimulationAddress(2)
+		adb := inMemoryState.NewAccountsAdapter()
+		saveSimulationDCTBalance(t, adb, alice, 100)
+		b := createRecordingCreator(t, adb)
+		container := b.BuiltInFunctionContainer().(*functionContainer)
+
+		blockingCallStarted := make(chan struct{})
+		endBlockingCall := make(chan struct{})
+		blockingCallDone := make(chan struct{})
+		blockingFunction := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				close(blockingCallStarted)
+				<-endBlockingCall
+
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+			},
+		}
+		go func() {
+			defer close(blockingCallDone)
+
+			vmOutput, err := container.ProcessBuiltinFunction(blockingFunction, nil, nil, &vmcommon.ContractCallInput{Function: "blocking"})
+			assert.Nil(t, err)
+			assert.Empty(t, vmOutput.StateDiff)
+		}()
+		<-blockingCallStarted
+
+		function, err := container.Get(core.BuiltInFunctionDCTTransfer)
+		require.Nil(t, err)
+		acntSnd := loadRecorderTestAccount(t, adb, alice)
+		acntDst := loadRecorderTestAccount(t, adb, bob)
+		vmOutput, err := container.ProcessBuiltinFunction(function, acntSnd, acntDst, createSimulationTransferInput(alice, bob, 40))
+		require.Nil(t, err)
+		checkTransferDiff(t, vmOutput, alice, bob)
+
+		close(endBlockingCall)
+		<-blockingCallDone
+	})
+	t.Run("without the option the state diff should not be recorded", func(t *testing.T) {
+		t.Parallel()
+
+		alice := createSimulationAddress(1)
+		bob := createSimulationAddress(2)
+		adb := inMemoryState.NewAccountsAdapter()
+		saveSimulationDCTBalance(t, adb, alice, 100)
+		b := createSimulationCreator(t, adb)
+
+		be, _ := NewBatchExecutor(createBatchExecutorArgs(b.BuiltInFunctionContainer(), adb))
+		batchResult, err := be.ProcessBatch([]*vmcommon.ContractCallInput{createSimulationTransferInput(alice, bob, 40)})
+		require.Nil(t, err)
+		require.Nil(t, batchResult.Results[0].Err)
+		assert.Empty(t, batchResult.Results[0].VMOutput.StateDiff)
+	})
+}
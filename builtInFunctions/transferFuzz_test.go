@@ -0,0 +1,441 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+// The fuzz targets below run one transfer per input against a fresh in-memory state in shard 0 of a two shards
+// setup, through the in-memory blockchain hook which reverts the failed calls. The senders are always in shard 0,
+// so every transfer is processed on the sender shard. After each call the harness checks that:
+//   - no token is created or lost: the balances held in shard 0 plus the amounts logged towards shard 1 equal the
+//     balances held before the call
+//   - the liquidity kept on the system account for every token instance equals the sum of its balances in shard 0
+//   - a failed call leaves the state unchanged
+//
+// The transfers of a single token are also processed through the multi transfer function, which must reach the
+// same state.
+
+const fuzzGasProvided = 1000
+
+type fuzzTokenInstance struct {
+	tokenID string
+	nonce   uint64
+}
+
+var fuzzFungibleTokens = []fuzzTokenInstance{
+	{"FUNG-aaaaaa", 0},
+	{"WRAP-bbbbbb", 0},
+}
+
+var fuzzNFTTokens = []fuzzTokenInstance{
+	{"SEMI-cccccc", 1},
+	{"SEMI-cccccc", 2},
+	{"NONF-dddddd", 1},
+}
+
+var (
+	fuzzUserInShard0          = createFuzzAddress(false, 0)
+	fuzzOtherUserInShard0     = createFuzzAddress(false, 2)
+	fuzzThirdUserInShard0     = createFuzzAddress(false, 4)
+	fuzzUserInShard1          = createFuzzAddress(false, 1)
+	fuzzOtherUserInShard1     = createFuzzAddress(false, 3)
+	fuzzPayableSCInShard0     = createFuzzAddress(true, 6)
+	fuzzNonPayableSCInShard0  = createFuzzAddress(true, 8)
+	fuzzSCInShard1            = createFuzzAddress(true, 5)
+	fuzzSenders               = [][]byte{fuzzUserInShard0, fuzzOtherUserInShard0, fuzzThirdUserInShard0, fuzzPayableSCInShard0, fuzzNonPayableSCInShard0}
+	fuzzReceivers             = append(append([][]byte{}, fuzzSenders...), fuzzUserInShard1, fuzzOtherUserInShard1, fuzzSCInShard1)
+	fuzzTransferLogIdentifier = map[string]struct{}{
+		core.BuiltInFunctionDCTTransfer:         {},
+		core.BuiltInFunctionDCTNFTTransfer:      {},
+		core.BuiltInFunctionMultiDCTNFTTransfer: {},
+	}
+)
+
+// createFuzzAddress returns an address whose shard is given by the parity of its last byte
+func createFuzzAddress(isSmartContract bool, lastByte byte) []byte {
+	address := make([]byte, 32)
+	if isSmartContract {
+		address[10] = 1
+	} else {
+		address[0] = 1
+	}
+	address[31] = lastByte
+
+	return address
+}
+
+func fuzzShardOf(address []byte) uint32 {
+	if len(address) == 0 {
+		return 0
+	}
+
+	return uint32(address[len(address)-1] % 2)
+}
+
+func pickFuzzAddress(addresses [][]byte, index uint8) []byte {
+	return addresses[int(index)%len(addresses)]
+}
+
+type transferFuzzEnvironment struct {
+	accounts       *inMemoryState.AccountsAdapter
+	creator        *builtInFuncCreator
+	blockchainHook *inMemoryState.BlockchainHook
+	marshaller     vmcommon.Marshalizer
+}
+
+func createTransferFuzzEnvironment(t *testing.T) *transferFuzzEnvironment {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.ComputeIdCalled = fuzzShardOf
+
+	accounts := inMemoryState.NewAccountsAdapter()
+	args := createMockArguments()
+	args.Accounts = accounts
+	args.ShardCoordinator = shardCoordinator
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSaveToSystemAccountFlagEnabledField:                true,
+		IsSendAlwaysFlagEnabledField:                         true,
+		IsCheckTransferFlagEnabledField:                      true,
+		IsCheckFrozenCollectionFlagEnabledField:              true,
+		IsValueLengthCheckFlagEnabledField:                   true,
+		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+		IsFixOldTokenLiquidityEnabledField:                   true,
+		IsScToScEventLogEnabledField:                         true,
+		IsConsistentTokensValuesLengthCheckEnabledField:      true,
+	}
+
+	creator, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+
+	blockchainHook, err := inMemoryState.NewBlockchainHook(inMemoryState.ArgsBlockchainHook{
+		Accounts:              accounts,
+		BuiltInFunctions:      creator.BuiltInFunctionContainer(),
+		ShardCoordinator:      shardCoordinator,
+		NFTStorageHandler:     creator.NFTStorageHandler(),
+		GlobalSettingsHandler: creator.DCTGlobalSettingsHandler(),
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.SetPayableHandler(blockchainHook))
+
+	env := &transferFuzzEnvironment{
+		accounts:       accounts,
+		creator:        creator,
+		blockchainHook: blockchainHook,
+		marshaller:     args.Marshalizer,
+	}
+	env.setInitialState(t)
+
+	return env
+}
+
+func (env *transferFuzzEnvironment) setInitialState(t *testing.T) {
+	for i, address := range fuzzSenders {
+		account, err := env.accounts.LoadAccount(address)
+		require.Nil(t, err)
+		userAccount := account.(vmcommon.UserAccountHandler)
+
+		if bytes.Equal(address, fuzzPayableSCInShard0) {
+			userAccount.SetCodeMetadata((&vmcommon.CodeMetadata{Payable: true}).ToBytes())
+		}
+		if bytes.Equal(address, fuzzNonPayableSCInShard0) {
+			userAccount.SetCodeMetadata((&vmcommon.CodeMetadata{Upgradeable: true}).ToBytes())
+		}
+
+		env.saveFungibleBalance(t, userAccount, fuzzFungibleTokens[0], 1000)
+		if i == 0 {
+			env.saveFungibleBalance(t, userAccount, fuzzFungibleTokens[1], 1)
+		}
+		require.Nil(t, env.accounts.SaveAccount(userAccount))
+
+		env.saveNFTBalance(t, address, fuzzNFTTokens[0], int64(10*(i+1)))
+		if i%2 == 0 {
+			env.saveNFTBalance(t, address, fuzzNFTTokens[1], 3)
+		}
+		if i == 0 {
+			env.saveNFTBalance(t, address, fuzzNFTTokens[2], 1)
+		}
+	}
+}
+
+func (env *transferFuzzEnvironment) saveFungibleBalance(t *testing.T, account vmcommon.UserAccountHandler, token fuzzTokenInstance, value int64) {
+	marshalledToken, err := env.marshaller.Marshal(&dct.DCToken{
+		Type:  uint32(core.Fungible),
+		Value: big.NewInt(value),
+	})
+	require.Nil(t, err)
+	require.Nil(t, account.AccountDataHandler().SaveKeyValue(token.key(), marshalledToken))
+}
+
+func (env *transferFuzzEnvironment) saveNFTBalance(t *testing.T, address []byte, token fuzzTokenInstance, value int64) {
+	account, err := env.accounts.LoadAccount(address)
+	require.Nil(t, err)
+	userAccount := account.(vmcommon.UserAccountHandler)
+
+	dctData := &dct.DCToken{
+		Type:          uint32(core.NonFungible),
+		Value:         big.NewInt(value),
+		TokenMetaData: &dct.MetaData{Nonce: token.nonce, Name: []byte(token.tokenID)},
+	}
+	dctTokenKey := []byte(baseDCTKeyPrefix + token.tokenID)
+	_, err = env.creator.dctStorageHandler.SaveDCTNFTToken(address, userAccount, dctTokenKey, token.nonce, dctData, false, false)
+	require.Nil(t, err)
+	require.Nil(t, env.accounts.SaveAccount(userAccount))
+	require.Nil(t, env.creator.dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, token.nonce, big.NewInt(value)))
+}
+
+func (token fuzzTokenInstance) key() []byte {
+	dctTokenKey := []byte(baseDCTKeyPrefix + token.tokenID)
+	if token.nonce == 0 {
+		return dctTokenKey
+	}
+
+	return computeDCTNFTTokenKey(dctTokenKey, token.nonce)
+}
+
+func (env *transferFuzzEnvironment) readValue(t *testing.T, address []byte, token fuzzTokenInstance) *big.Int {
+	account, err := env.accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	marshalledToken, _, err := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(token.key())
+	require.Nil(t, err)
+	if len(marshalledToken) == 0 {
+		return big.NewInt(0)
+	}
+
+	dctData := &dct.DCToken{}
+	require.Nil(t, env.marshaller.Unmarshal(dctData, marshalledToken))
+	require.True(t, dctData.Value.Sign() >= 0, "negative value for %s-%d", token.tokenID, token.nonce)
+
+	return dctData.Value
+}
+
+// balancesInShard returns, for every known token instance, the sum of the balances of the shard 0 accounts
+func (env *transferFuzzEnvironment) balancesInShard(t *testing.T) map[fuzzTokenInstance]*big.Int {
+	balances := make(map[fuzzTokenInstance]*big.Int)
+	for _, token := range append(append([]fuzzTokenInstance{}, fuzzFungibleTokens...), fuzzNFTTokens...) {
+		balances[token] = big.NewInt(0)
+		for _, address := range env.accounts.Addresses() {
+			if bytes.Equal(address, vmcommon.SystemAccountAddress) || fuzzShardOf(address) != 0 {
+				continue
+			}
+
+			balances[token].Add(balances[token], env.readValue(t, address, token))
+		}
+	}
+
+	return balances
+}
+
+func (env *transferFuzzEnvironment) rootHash(t *testing.T) []byte {
+	rootHash, err := env.accounts.RootHash()
+	require.Nil(t, err)
+
+	return rootHash
+}
+
+// processAndCheck processes the call and checks the invariants of the transfer functions
+func (env *transferFuzzEnvironment) processAndCheck(t *testing.T, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	rootHashBefore := env.rootHash(t)
+	balancesBefore := env.balancesInShard(t)
+
+	vmOutput, err := env.blockchainHook.ProcessBuiltInFunction(vmInput)
+	if err != nil {
+		require.Equal(t, rootHashBefore, env.rootHash(t), "failed call changed the state: %v", err)
+		return nil, err
+	}
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	balancesAfter := env.balancesInShard(t)
+	sentToOtherShard := sentToOtherShardFromLogs(vmOutput.Logs)
+	for token, before := range balancesBefore {
+		after := big.NewInt(0).Add(balancesAfter[token], valueOrZero(sentToOtherShard[token]))
+		require.Equal(t, before.String(), after.String(), "tokens created or lost for %s-%d", token.tokenID, token.nonce)
+	}
+	for token, sent := range sentToOtherShard {
+		_, isKnown := balancesBefore[token]
+		require.True(t, isKnown || sent.Sign() == 0, "unknown token %s-%d sent", token.tokenID, token.nonce)
+	}
+
+	// the state holds only the shard 0 accounts, so the liquidity checker sums the same balances
+	liquidityChecker, err := NewLiquidityChecker(ArgsLiquidityChecker{
+		Accounts:    env.accounts,
+		Marshalizer: env.marshaller,
+	})
+	require.Nil(t, err)
+	report, err := liquidityChecker.Check()
+	require.Nil(t, err)
+	require.False(t, report.HasMismatches(), "liquidity mismatches: %v", report.Mismatches)
+
+	return vmOutput, nil
+}
+
+// sentToOtherShardFromLogs sums the values of the transfer logs whose destination is not in shard 0. A transfer log
+// holds the token identifier, nonce and value of each transferred token, followed by the destination.
+func sentToOtherShardFromLogs(logs []*vmcommon.LogEntry) map[fuzzTokenInstance]*big.Int {
+	sent := make(map[fuzzTokenInstance]*big.Int)
+	for _, logEntry := range logs {
+		_, isTransferLog := fuzzTransferLogIdentifier[string(logEntry.Identifier)]
+		if !isTransferLog || len(logEntry.Topics) == 0 || len(logEntry.Topics)%3 != 1 {
+			continue
+		}
+
+		destination := logEntry.Topics[len(logEntry.Topics)-1]
+		if fuzzShardOf(destination) == 0 {
+			continue
+		}
+
+		for i := 0; i+2 < len(logEntry.Topics); i += 3 {
+			token := fuzzTokenInstance{
+				tokenID: string(logEntry.Topics[i]),
+				nonce:   big.NewInt(0).SetBytes(logEntry.Topics[i+1]).Uint64(),
+			}
+			sent[token] = big.NewInt(0).Add(valueOrZero(sent[token]), big.NewInt(0).SetBytes(logEntry.Topics[i+2]))
+		}
+	}
+
+	return sent
+}
+
+func valueOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+
+	return value
+}
+
+func createFuzzInput(function string, sender []byte, recipient []byte, arguments [][]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   arguments,
+			CallValue:   big.NewInt(0),
+			GasProvided: fuzzGasProvided,
+		},
+		RecipientAddr: recipient,
+		Function:      function,
+	}
+}
+
+func appendFuzzFunctionCall(arguments [][]byte, function []byte) [][]byte {
+	if len(function) == 0 {
+		return arguments
+	}
+
+	return append(arguments, function)
+}
+
+// checkSameAsMultiTransfer processes the single token transfer through the multi transfer function on a fresh
+// environment and checks that both calls have the same outcome
+func checkSameAsMultiTransfer(
+	t *testing.T,
+	sender []byte,
+	receiver []byte,
+	token fuzzTokenInstance,
+	value []byte,
+	function []byte,
+	errSingle error,
+	rootHashSingle []byte,
+) {
+	if bytes.Equal(sender, receiver) {
+		// only the multi transfer rejects the transfers to self
+		return
+	}
+
+	env := createTransferFuzzEnvironment(t)
+	nonce := big.NewInt(0).SetUint64(token.nonce).Bytes()
+	arguments := [][]byte{receiver, big.NewInt(1).Bytes(), []byte(token.tokenID), nonce, value}
+	_, errMulti := env.processAndCheck(t, createFuzzInput(core.BuiltInFunctionMultiDCTNFTTransfer, sender, sender, appendFuzzFunctionCall(arguments, function)))
+
+	require.Equal(t, errSingle == nil, errMulti == nil, "single transfer error: %v, multi transfer error: %v", errSingle, errMulti)
+	require.Equal(t, rootHashSingle, env.rootHash(t))
+}
+
+func FuzzDCTTransfer(f *testing.F) {
+	f.Add(uint8(0), uint8(1), uint8(0), []byte{10}, []byte(nil))
+	f.Add(uint8(1), uint8(5), uint8(0), []byte{0, 100}, []byte(nil))
+	f.Add(uint8(2), uint8(7), uint8(0), []byte{3, 232}, []byte("call"))
+	f.Add(uint8(0), uint8(3), uint8(1), []byte{1}, []byte(nil))
+	f.Add(uint8(1), uint8(0), uint8(1), []byte{1}, []byte(nil))
+	f.Add(uint8(3), uint8(4), uint8(0), []byte{3, 233}, []byte(nil))
+	f.Add(uint8(4), uint8(4), uint8(0), []byte{0}, []byte("call"))
+
+	f.Fuzz(func(t *testing.T, senderIndex uint8, receiverIndex uint8, tokenIndex uint8, value []byte, function []byte) {
+		sender := pickFuzzAddress(fuzzSenders, senderIndex)
+		receiver := pickFuzzAddress(fuzzReceivers, receiverIndex)
+		token := fuzzFungibleTokens[int(tokenIndex)%len(fuzzFungibleTokens)]
+
+		env := createTransferFuzzEnvironment(t)
+		arguments := appendFuzzFunctionCall([][]byte{[]byte(token.tokenID), value}, function)
+		_, err := env.processAndCheck(t, createFuzzInput(core.BuiltInFunctionDCTTransfer, sender, receiver, arguments))
+
+		checkSameAsMultiTransfer(t, sender, receiver, token, value, function, err, env.rootHash(t))
+	})
+}
+
+func FuzzDCTNFTTransfer(f *testing.F) {
+	f.Add(uint8(0), uint8(1), uint8(0), []byte{5}, []byte(nil))
+	f.Add(uint8(1), uint8(3), uint8(0), []byte{20}, []byte(nil))
+	f.Add(uint8(0), uint8(6), uint8(2), []byte{1}, []byte(nil))
+	f.Add(uint8(2), uint8(5), uint8(1), []byte{3}, []byte("call"))
+	f.Add(uint8(1), uint8(1), uint8(0), []byte{1}, []byte(nil))
+	f.Add(uint8(4), uint8(7), uint8(0), []byte{50}, []byte(nil))
+
+	f.Fuzz(func(t *testing.T, senderIndex uint8, receiverIndex uint8, tokenIndex uint8, value []byte, function []byte) {
+		sender := pickFuzzAddress(fuzzSenders, senderIndex)
+		receiver := pickFuzzAddress(fuzzReceivers, receiverIndex)
+		token := fuzzNFTTokens[int(tokenIndex)%len(fuzzNFTTokens)]
+
+		env := createTransferFuzzEnvironment(t)
+		nonce := big.NewInt(0).SetUint64(token.nonce).Bytes()
+		arguments := appendFuzzFunctionCall([][]byte{[]byte(token.tokenID), nonce, value, receiver}, function)
+		_, err := env.processAndCheck(t, createFuzzInput(core.BuiltInFunctionDCTNFTTransfer, sender, sender, arguments))
+
+		checkSameAsMultiTransfer(t, sender, receiver, token, value, function, err, env.rootHash(t))
+	})
+}
+
+// FuzzMultiDCTNFTTransfer builds the transfers from the raw transfers data: each transfer takes a token selector
+// byte, a value length byte and the value. The declared number of transfers is passed as is, so it may not match.
+func FuzzMultiDCTNFTTransfer(f *testing.F) {
+	f.Add(uint8(0), uint8(1), []byte{1}, []byte{0, 1, 10})
+	f.Add(uint8(0), uint8(3), []byte{2}, []byte{0, 1, 10, 2, 1, 1})
+	f.Add(uint8(1), uint8(7), []byte{3}, []byte{1, 1, 1, 3, 1, 2, 0, 2, 3, 232})
+	f.Add(uint8(0), uint8(5), []byte{2}, []byte{2, 1, 1, 4, 1, 1})
+	f.Add(uint8(2), uint8(6), []byte{2}, []byte{0, 1, 5, 3, 1, 250})
+	f.Add(uint8(0), uint8(1), []byte{3}, []byte{0, 1, 10})
+	f.Add(uint8(3), uint8(4), []byte{0}, []byte{})
+
+	allTokens := append(append([]fuzzTokenInstance{}, fuzzFungibleTokens...), fuzzNFTTokens...)
+	f.Fuzz(func(t *testing.T, senderIndex uint8, receiverIndex uint8, numTransfers []byte, transfersData []byte) {
+		sender := pickFuzzAddress(fuzzSenders, senderIndex)
+		receiver := pickFuzzAddress(fuzzReceivers, receiverIndex)
+
+		arguments := [][]byte{receiver, numTransfers}
+		for len(transfersData) >= 2 {
+			token := allTokens[int(transfersData[0])%len(allTokens)]
+			valueLength := int(transfersData[1])
+			transfersData = transfersData[2:]
+			if valueLength > len(transfersData) {
+				valueLength = len(transfersData)
+			}
+
+			nonce := big.NewInt(0).SetUint64(token.nonce).Bytes()
+			arguments = append(arguments, []byte(token.tokenID), nonce, transfersData[:valueLength])
+			transfersData = transfersData[valueLength:]
+		}
+
+		env := createTransferFuzzEnvironment(t)
+		_, _ = env.processAndCheck(t, createFuzzInput(core.BuiltInFunctionMultiDCTNFTTransfer, sender, sender, arguments))
+	})
+}
@@ -27,7 +27,8 @@ func (ua *unGuardAccountFunc) ProcessBuiltinFunction(
 	ua.mutExecution.Lock()
 	defer ua.mutExecution.Unlock()
 
-	err := ua.checkGuardAccountArgs(acntSnd, vmInput)
+	funcGasCost := ua.activeFuncGasCost(ua.funcGasCost)
+	err := ua.checkGuardAccountArgs(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
@@ -42,11 +43,14 @@ func (ua *unGuardAccountFunc) ProcessBuiltinFunction(
 		Identifier: []byte(core.BuiltInFunctionUnGuardAccount),
 	}
 
-	return &vmcommon.VMOutput{
+	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - ua.funcGasCost,
+		GasRemaining: vmInput.GasProvided - funcGasCost,
 		Logs:         []*vmcommon.LogEntry{entry},
-	}, nil
+	}
+	ua.traceGas(vmInput, vmOutput, newBuiltInCostCharge("GuardAccount", funcGasCost))
+
+	return vmOutput, nil
 }
 
 func unGuardAccount(account vmcommon.UserAccountHandler) error {
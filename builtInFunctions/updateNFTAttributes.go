@@ -11,6 +11,8 @@ import (
 
 type dctNFTupdate struct {
 	baseActiveHandler
+	baseGasTracer
+	baseGasSchedule
 	keyPrefix             []byte
 	dctStorageHandler     vmcommon.DCTNFTStorageHandler
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
@@ -43,6 +45,9 @@ func NewDCTNFTUpdateAttributesFunc(
 	}
 
 	e := &dctNFTupdate{
+		baseGasSchedule: newBaseGasSchedule(func(builtInCost *vmcommon.BuiltInCost) uint64 {
+			return builtInCost.DCTNFTUpdateAttributes
+		}),
 		keyPrefix:             []byte(baseDCTKeyPrefix),
 		dctStorageHandler:     dctStorageHandler,
 		funcGasCost:           funcGasCost,
@@ -81,12 +86,14 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	funcGasCost, gasConfig := e.activeGasCost(e.funcGasCost, e.gasConfig)
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, funcGasCost)
 	if err != nil {
 		return nil, err
 	}
 	if len(vmInput.Arguments) != 3 {
-		return nil, ErrInvalidArguments
+		return nil, newArgumentError(core.BuiltInFunctionDCTNFTUpdateAttributes, ErrInvalidArguments)
 	}
 
 	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(core.DCTRoleNFTUpdateAttributes))
@@ -94,8 +101,9 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	gasCostForStore := uint64(len(vmInput.Arguments[2])) * e.gasConfig.StorePerByte
-	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
+	lenAttributes := uint64(len(vmInput.Arguments[2]))
+	gasCostForStore := lenAttributes * gasConfig.StorePerByte
+	if vmInput.GasProvided < funcGasCost+gasCostForStore {
 		return nil, ErrNotEnoughGas
 	}
 
@@ -118,10 +126,16 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
+		GasRemaining: vmInput.GasProvided - funcGasCost - gasCostForStore,
 	}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTUpdateAttributes), vmInput.Arguments[0], nonce, big.NewInt(0), vmInput.CallerAddr, vmInput.Arguments[2])
+	e.traceGas(
+		vmInput,
+		vmOutput,
+		newBuiltInCostCharge("DCTNFTUpdateAttributes", funcGasCost),
+		newBaseOperationCostCharge("StorePerByte", lenAttributes, gasConfig.StorePerByte),
+	)
 
 	return vmOutput, nil
 }
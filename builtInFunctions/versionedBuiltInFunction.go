@@ -0,0 +1,207 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.BuiltinFunction = (*versionedBuiltInFunction)(nil)
+var _ vmcommon.EpochSubscriberHandler = (*versionedBuiltInFunction)(nil)
+
+// BuiltInFunctionVersion is one implementation of a built-in function, used starting with the activation epoch
+type BuiltInFunctionVersion struct {
+	ActivationEpoch uint32
+	Function        vmcommon.BuiltinFunction
+}
+
+// versionedBuiltInFunction dispatches the calls to the version active in the current epoch
+type versionedBuiltInFunction struct {
+	name     string
+	versions []BuiltInFunctionVersion
+
+	mutEpoch      sync.RWMutex
+	currentEpoch  uint32
+	activeVersion vmcommon.BuiltinFunction
+}
+
+// NewVersionedBuiltInFunction creates a built-in function which dispatches to the latest version activated
+// until the current epoch. The current epoch is 0 until the first EpochConfirmed call.
+func NewVersionedBuiltInFunction(name string, versions []BuiltInFunctionVersion) (*versionedBuiltInFunction, error) {
+	if len(name) == 0 {
+		return nil, ErrEmptyFunctionName
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w for built-in function %s", ErrNoBuiltInFunctionVersion, name)
+	}
+
+	sortedVersions := make([]BuiltInFunctionVersion, len(versions))
+	copy(sortedVersions, versions)
+	sort.SliceStable(sortedVersions, func(i, j int) bool {
+		return sortedVersions[i].ActivationEpoch < sortedVersions[j].ActivationEpoch
+	})
+
+	for i, version := range sortedVersions {
+		if check.IfNil(version.Function) {
+			return nil, fmt.Errorf("%w for built-in function %s, activation epoch %d", ErrNilContainerElement, name, version.ActivationEpoch)
+		}
+		if i > 0 && sortedVersions[i-1].ActivationEpoch == version.ActivationEpoch {
+			return nil, fmt.Errorf("%w for built-in function %s, activation epoch %d", ErrDuplicateBuiltInFunctionVersion, name, version.ActivationEpoch)
+		}
+	}
+
+	vbf := &versionedBuiltInFunction{
+		name:     name,
+		versions: sortedVersions,
+	}
+	vbf.activeVersion = vbf.versionForEpoch(0)
+
+	return vbf, nil
+}
+
+// EpochConfirmed selects the version active in the new epoch
+func (vbf *versionedBuiltInFunction) EpochConfirmed(epoch uint32, _ uint64) {
+	vbf.mutEpoch.Lock()
+	vbf.currentEpoch = epoch
+	vbf.activeVersion = vbf.versionForEpoch(epoch)
+	vbf.mutEpoch.Unlock()
+}
+
+func (vbf *versionedBuiltInFunction) versionForEpoch(epoch uint32) vmcommon.BuiltinFunction {
+	var function vmcommon.BuiltinFunction
+	for _, version := range vbf.versions {
+		if version.ActivationEpoch > epoch {
+			break
+		}
+		function = version.Function
+	}
+
+	return function
+}
+
+// ActiveVersion returns the version used in the current epoch, or nil if no version is activated yet
+func (vbf *versionedBuiltInFunction) ActiveVersion() vmcommon.BuiltinFunction {
+	vbf.mutEpoch.RLock()
+	defer vbf.mutEpoch.RUnlock()
+
+	return vbf.activeVersion
+}
+
+// CurrentEpoch returns the last confirmed epoch
+func (vbf *versionedBuiltInFunction) CurrentEpoch() uint32 {
+	vbf.mutEpoch.RLock()
+	defer vbf.mutEpoch.RUnlock()
+
+	return vbf.currentEpoch
+}
+
+// Versions returns all the versions, sorted by activation epoch
+func (vbf *versionedBuiltInFunction) Versions() []BuiltInFunctionVersion {
+	versions := make([]BuiltInFunctionVersion, len(vbf.versions))
+	copy(versions, vbf.versions)
+
+	return versions
+}
+
+// ProcessBuiltinFunction processes the call with the version active in the current epoch
+func (vbf *versionedBuiltInFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vbf.mutEpoch.RLock()
+	activeVersion := vbf.activeVersion
+	currentEpoch := vbf.currentEpoch
+	vbf.mutEpoch.RUnlock()
+
+	if check.IfNil(activeVersion) {
+		return nil, fmt.Errorf("%w: %s in epoch %d", ErrNoBuiltInFunctionVersion, vbf.name, currentEpoch)
+	}
+
+	return activeVersion.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+}
+
+// AccessSet returns the access set declared by the version active in the current epoch
+func (vbf *versionedBuiltInFunction) AccessSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.AccessSet, error) {
+	accessSetProvider, ok := vbf.ActiveVersion().(vmcommon.AccessSetProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w for built-in function %s", ErrAccessSetNotDeclared, vbf.name)
+	}
+
+	return accessSetProvider.AccessSet(vmInput)
+}
+
+// SetNewGasConfig sets the gas config on all the versions
+func (vbf *versionedBuiltInFunction) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	for _, version := range vbf.versions {
+		version.Function.SetNewGasConfig(gasCost)
+	}
+}
+
+// IsActive returns true if a version is active in the current epoch and that version is active
+func (vbf *versionedBuiltInFunction) IsActive() bool {
+	activeVersion := vbf.ActiveVersion()
+
+	return !check.IfNil(activeVersion) && activeVersion.IsActive()
+}
+
+// SetPayableChecker sets the payable checker on all the versions accepting one
+func (vbf *versionedBuiltInFunction) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
+	for _, version := range vbf.versions {
+		payableCheckerAcceptor, ok := version.Function.(vmcommon.AcceptPayableChecker)
+		if !ok {
+			continue
+		}
+
+		err := payableCheckerAcceptor.SetPayableChecker(payableHandler)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetGasTracer sets the gas tracer on all the versions accepting one
+func (vbf *versionedBuiltInFunction) SetGasTracer(gasTracer vmcommon.GasTracer) error {
+	for _, version := range vbf.versions {
+		gasTracerAcceptor, ok := version.Function.(vmcommon.AcceptGasTracer)
+		if !ok {
+			continue
+		}
+
+		err := gasTracerAcceptor.SetGasTracer(gasTracer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vbf *versionedBuiltInFunction) setGasScheduleHolder(holder *gasScheduleHolder) {
+	for _, version := range vbf.versions {
+		subscriber, ok := version.Function.(gasScheduleSubscriber)
+		if ok {
+			subscriber.setGasScheduleHolder(holder)
+		}
+	}
+}
+
+func (vbf *versionedBuiltInFunction) hasGasScheduleHolder() bool {
+	for _, version := range vbf.versions {
+		subscriber, ok := version.Function.(gasScheduleSubscriber)
+		if ok && subscriber.hasGasScheduleHolder() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (vbf *versionedBuiltInFunction) IsInterfaceNil() bool {
+	return vbf == nil
+}
@@ -0,0 +1,226 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func createVersionStub(returnData string) *mock.BuiltInFunctionStub {
+	return &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{ReturnData: [][]byte{[]byte(returnData)}}, nil
+		},
+	}
+}
+
+func TestNewVersionedBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty name should error", func(t *testing.T) {
+		t.Parallel()
+
+		vbf, err := NewVersionedBuiltInFunction("", []BuiltInFunctionVersion{{Function: createVersionStub("v0")}})
+		assert.Nil(t, vbf)
+		assert.Equal(t, ErrEmptyFunctionName, err)
+	})
+	t.Run("no versions should error", func(t *testing.T) {
+		t.Parallel()
+
+		vbf, err := NewVersionedBuiltInFunction(customFunctionName, nil)
+		assert.Nil(t, vbf)
+		assert.ErrorIs(t, err, ErrNoBuiltInFunctionVersion)
+	})
+	t.Run("nil function should error", func(t *testing.T) {
+		t.Parallel()
+
+		vbf, err := NewVersionedBuiltInFunction(customFunctionName, []BuiltInFunctionVersion{{ActivationEpoch: 2}})
+		assert.Nil(t, vbf)
+		assert.ErrorIs(t, err, ErrNilContainerElement)
+	})
+	t.Run("duplicate activation epoch should error", func(t *testing.T) {
+		t.Parallel()
+
+		vbf, err := NewVersionedBuiltInFunction(customFunctionName, []BuiltInFunctionVersion{
+			{ActivationEpoch: 2, Function: createVersionStub("v2")},
+			{ActivationEpoch: 2, Function: createVersionStub("v2")},
+		})
+		assert.Nil(t, vbf)
+		assert.ErrorIs(t, err, ErrDuplicateBuiltInFunctionVersion)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		vbf, err := NewVersionedBuiltInFunction(customFunctionName, []BuiltInFunctionVersion{
+			{ActivationEpoch: 5, Function: createVersionStub("v5")},
+			{ActivationEpoch: 0, Function: createVersionStub("v0")},
+		})
+		require.Nil(t, err)
+		assert.False(t, vbf.IsInterfaceNil())
+
+		versions := vbf.Versions()
+		require.Equal(t, 2, len(versions))
+		assert.Equal(t, uint32(0), versions[0].ActivationEpoch)
+		assert.Equal(t, uint32(5), versions[1].ActivationEpoch)
+	})
+}
+
+func TestVersionedBuiltInFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	vbf, _ := NewVersionedBuiltInFunction(customFunctionName, []BuiltInFunctionVersion{
+		{ActivationEpoch: 3, Function: createVersionStub("v3")},
+		{ActivationEpoch: 7, Function: createVersionStub("v7")},
+	})
+
+	vmOutput, err := vbf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.ErrorIs(t, err, ErrNoBuiltInFunctionVersion)
+	assert.False(t, vbf.IsActive())
+
+	checkVersion := func(epoch uint32, expectedReturnData string) {
+		vbf.EpochConfirmed(epoch, 0)
+		vmOutput, err = vbf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte(expectedReturnData)}, vmOutput.ReturnData)
+		assert.True(t, vbf.IsActive())
+		assert.Equal(t, epoch, vbf.CurrentEpoch())
+	}
+	checkVersion(3, "v3")
+	checkVersion(6, "v3")
+	checkVersion(7, "v7")
+	checkVersion(100, "v7")
+	checkVersion(4, "v3")
+}
+
+func TestVersionedBuiltInFunction_ForwardsToAllVersions(t *testing.T) {
+	t.Parallel()
+
+	numGasConfigs := 0
+	setNewGasConfig := func(_ *vmcommon.GasCost) {
+		numGasConfigs++
+	}
+	version0 := createVersionStub("v0")
+	version0.SetNewGasConfigCalled = setNewGasConfig
+	version1 := createVersionStub("v1")
+	version1.SetNewGasConfigCalled = setNewGasConfig
+	version2, _ := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+
+	vbf, _ := NewVersionedBuiltInFunction(customFunctionName, []BuiltInFunctionVersion{
+		{ActivationEpoch: 0, Function: version0},
+		{ActivationEpoch: 1, Function: version1},
+		{ActivationEpoch: 2, Function: version2},
+	})
+
+	gasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{DCTTransfer: 42}}
+	vbf.SetNewGasConfig(gasCost)
+	assert.Equal(t, 2, numGasConfigs)
+	assert.Equal(t, uint64(42), version2.funcGasCost)
+
+	assert.Nil(t, vbf.SetPayableChecker(&mock.PayableHandlerStub{}))
+	assert.Equal(t, &mock.PayableHandlerStub{}, version2.payableHandler)
+
+	assert.ErrorIs(t, vbf.SetGasTracer(nil), ErrNilGasTracer)
+	gasTracer := &mock.GasTracerStub{}
+	assert.Nil(t, vbf.SetGasTracer(gasTracer))
+	assert.True(t, version2.gasTracer == gasTracer)
+
+	holder := newGasScheduleHolder(gasCost, nil)
+	vbf.setGasScheduleHolder(holder)
+	assert.True(t, version2.gasSchedule == holder)
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{CallValue: big.NewInt(0), Arguments: [][]byte{[]byte("token"), {1}}},
+	}
+	_, err := vbf.AccessSet(vmInput)
+	assert.ErrorIs(t, err, ErrAccessSetNotDeclared)
+	vbf.EpochConfirmed(2, 0)
+	accessSet, err := vbf.AccessSet(vmInput)
+	assert.Nil(t, err)
+	assert.NotNil(t, accessSet)
+}
+
+func TestBuiltInFuncCreator_VersionedBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	createVersion := func(name string, activationEpoch uint32, returnData string) CustomBuiltInFunction {
+		return CustomBuiltInFunction{
+			Name:            name,
+			ActivationEpoch: activationEpoch,
+			Constructor: func(_ ArgsCustomBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return createVersionStub(returnData), nil
+			},
+		}
+	}
+
+	t.Run("activation epoch without epoch notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry()
+		_ = registry.Register(createVersion(customFunctionName, 2, "v2"))
+
+		b, _ := NewBuiltInFunctionsCreator(createCustomRegistryArguments(registry))
+		err := b.CreateBuiltInFunctionContainer()
+		assert.ErrorIs(t, err, ErrNilEpochNotifier)
+	})
+	t.Run("epoch 0 version of a protocol built-in function should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry()
+		_ = registry.Register(createVersion(core.BuiltInFunctionClaimDeveloperRewards, 0, "v0"))
+		_ = registry.Register(createVersion(core.BuiltInFunctionClaimDeveloperRewards, 2, "v2"))
+
+		args := createCustomRegistryArguments(registry)
+		args.EpochNotifier = &mock.EpochNotifierStub{}
+		b, _ := NewBuiltInFunctionsCreator(args)
+		err := b.CreateBuiltInFunctionContainer()
+		assert.ErrorIs(t, err, ErrContainerKeyAlreadyExists)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry()
+		_ = registry.Register(createVersion(customFunctionName, 0, "v0"))
+		_ = registry.Register(createVersion(customFunctionName, 4, "v4"))
+		_ = registry.Register(createVersion(core.BuiltInFunctionClaimDeveloperRewards, 2, "v2"))
+
+		handlers := make([]vmcommon.EpochSubscriberHandler, 0)
+		args := createCustomRegistryArguments(registry)
+		args.EpochNotifier = &mock.EpochNotifierStub{
+			RegisterNotifyHandlerCalled: func(handler vmcommon.EpochSubscriberHandler) {
+				handlers = append(handlers, handler)
+			},
+		}
+		b, _ := NewBuiltInFunctionsCreator(args)
+		require.Nil(t, b.CreateBuiltInFunctionContainer())
+		assert.Equal(t, 37, b.BuiltInFunctionContainer().Len())
+		require.Equal(t, 4, len(handlers))
+
+		function, _ := b.BuiltInFunctionContainer().Get(core.BuiltInFunctionClaimDeveloperRewards)
+		claimRewards := function.(*versionedBuiltInFunction)
+		_, ok := claimRewards.ActiveVersion().(*claimDeveloperRewards)
+		assert.True(t, ok)
+
+		function, _ = b.BuiltInFunctionContainer().Get(customFunctionName)
+		customFunction := function.(*versionedBuiltInFunction)
+		for _, handler := range handlers {
+			handler.EpochConfirmed(4, 0)
+		}
+		vmOutput, err := customFunction.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("v4")}, vmOutput.ReturnData)
+		vmOutput, err = claimRewards.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("v2")}, vmOutput.ReturnData)
+
+		result, err := b.SimulateBuiltInFunction(&vmcommon.ContractCallInput{Function: customFunctionName})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("v4")}, result.VMOutput.ReturnData)
+		assert.Equal(t, 4, len(handlers))
+	})
+}
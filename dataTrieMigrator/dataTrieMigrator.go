@@ -21,6 +21,7 @@ type dataTrieMigrator struct {
 	trieLoadCost       uint64
 	trieMigrateCost    uint64
 	leavesToBeMigrated []core.TrieData
+	numLoadedNodes     uint64
 }
 
 // NewDataTrieMigrator creates a new dataTrieMigrator component
@@ -41,6 +42,7 @@ func (dtm *dataTrieMigrator) ConsumeStorageLoadGas() bool {
 	}
 
 	dtm.gasRemaining -= dtm.trieLoadCost
+	dtm.numLoadedNodes++
 
 	return dtm.gasRemaining > dtm.trieLoadCost
 }
@@ -74,6 +76,11 @@ func (dtm *dataTrieMigrator) GetLeavesToBeMigrated() []core.TrieData {
 	return dtm.leavesToBeMigrated
 }
 
+// GetNumLoadedNodes returns the number of trie nodes the load gas was consumed for
+func (dtm *dataTrieMigrator) GetNumLoadedNodes() uint64 {
+	return dtm.numLoadedNodes
+}
+
 // GetGasRemaining returns the remaining gas
 func (dtm *dataTrieMigrator) GetGasRemaining() uint64 {
 	return dtm.gasRemaining
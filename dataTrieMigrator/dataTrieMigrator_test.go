@@ -36,6 +36,7 @@ func TestConsumeStorageLoadGas(t *testing.T) {
 	assert.Equal(t, uint64(3), dtm.gasRemaining)
 	assert.False(t, dtm.ConsumeStorageLoadGas())
 	assert.Equal(t, uint64(3), dtm.gasRemaining)
+	assert.Equal(t, uint64(2), dtm.GetNumLoadedNodes())
 }
 
 func TestAddLeafToMigrationQueue(t *testing.T) {
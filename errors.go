@@ -16,3 +16,12 @@ var ErrTransfersNotIndexed = errors.New("unindexed transfers found")
 
 // ErrNilTransferIndexer signals that the provided transfer indexer is nil
 var ErrNilTransferIndexer = errors.New("nil NextOutputTransferIndexProvider")
+
+// ErrUnknownReturnCode signals that the provided text is not a known return code
+var ErrUnknownReturnCode = errors.New("unknown return code")
+
+// ErrNilVMOutput signals that a nil VM output was provided
+var ErrNilVMOutput = errors.New("nil VM output")
+
+// ErrInvalidVMOutput signals that the VM output breaks its structural invariants
+var ErrInvalidVMOutput = errors.New("invalid VM output")
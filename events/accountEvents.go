@@ -0,0 +1,145 @@
+package events
+
+import (
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// DeleteUserNameIdentifier is the identifier of the log entry emitted when a user name is deleted
+const DeleteUserNameIdentifier = "DeleteUserName"
+
+var _ Event = (*ChangeOwnerAddressEvent)(nil)
+var _ Event = (*UserNameEvent)(nil)
+var _ Event = (*SetGuardianEvent)(nil)
+var _ Event = (*GuardAccountEvent)(nil)
+
+// ChangeOwnerAddressEvent is emitted by ChangeOwnerAddress
+type ChangeOwnerAddressEvent struct {
+	Contract []byte
+	NewOwner []byte
+}
+
+// DecodeChangeOwnerAddressEvent decodes a ChangeOwnerAddress log entry
+func DecodeChangeOwnerAddressEvent(entry *vmcommon.LogEntry) (*ChangeOwnerAddressEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionChangeOwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeOwnerAddressEvent{
+		Contract: entry.Address,
+		NewOwner: entry.Topics[0],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *ChangeOwnerAddressEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionChangeOwnerAddress),
+		Address:    event.Contract,
+		Topics:     [][]byte{event.NewOwner},
+	}
+}
+
+// UserNameEvent is emitted by SetUserName and DeleteUserName. It holds the user name the account had before.
+type UserNameEvent struct {
+	Identifier       string
+	Address          []byte
+	PreviousUserName []byte
+}
+
+// DecodeUserNameEvent decodes a SetUserName or DeleteUserName log entry
+func DecodeUserNameEvent(entry *vmcommon.LogEntry) (*UserNameEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionSetUserName, DeleteUserNameIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserNameEvent{
+		Identifier:       string(entry.Identifier),
+		Address:          entry.Address,
+		PreviousUserName: entry.Topics[0],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *UserNameEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Address,
+		Topics:     [][]byte{event.PreviousUserName},
+	}
+}
+
+// SetGuardianEvent is emitted by SetGuardian
+type SetGuardianEvent struct {
+	Account    []byte
+	Guardian   []byte
+	ServiceUID []byte
+}
+
+// DecodeSetGuardianEvent decodes a SetGuardian log entry
+func DecodeSetGuardianEvent(entry *vmcommon.LogEntry) (*SetGuardianEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionSetGuardian)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetGuardianEvent{
+		Account:    entry.Address,
+		Guardian:   entry.Topics[0],
+		ServiceUID: entry.Topics[1],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *SetGuardianEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionSetGuardian),
+		Address:    event.Account,
+		Topics:     [][]byte{event.Guardian, event.ServiceUID},
+	}
+}
+
+// GuardAccountEvent is emitted by GuardAccount and UnGuardAccount
+type GuardAccountEvent struct {
+	Identifier string
+	Account    []byte
+}
+
+// DecodeGuardAccountEvent decodes a GuardAccount or UnGuardAccount log entry
+func DecodeGuardAccountEvent(entry *vmcommon.LogEntry) (*GuardAccountEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionGuardAccount, core.BuiltInFunctionUnGuardAccount)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GuardAccountEvent{
+		Identifier: string(entry.Identifier),
+		Account:    entry.Address,
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *GuardAccountEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Account,
+	}
+}
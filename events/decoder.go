@@ -0,0 +1,94 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// Event is a typed log entry emitted by a built-in function
+type Event interface {
+	ToLogEntry() *vmcommon.LogEntry
+}
+
+type decodeHandler func(entry *vmcommon.LogEntry) (Event, error)
+
+func wrapDecoder[T Event](decoder func(entry *vmcommon.LogEntry) (T, error)) decodeHandler {
+	return func(entry *vmcommon.LogEntry) (Event, error) {
+		event, err := decoder(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		return event, nil
+	}
+}
+
+var decoders = map[string]decodeHandler{
+	core.BuiltInFunctionDCTTransfer:                      wrapDecoder(DecodeTransferEvent),
+	core.BuiltInFunctionDCTNFTTransfer:                   wrapDecoder(DecodeTransferEvent),
+	core.BuiltInFunctionMultiDCTNFTTransfer:              wrapDecoder(DecodeTransferEvent),
+	core.BuiltInFunctionDCTBurn:                          wrapDecoder(DecodeTokenAmountEvent),
+	core.BuiltInFunctionDCTLocalBurn:                     wrapDecoder(DecodeTokenAmountEvent),
+	core.BuiltInFunctionDCTLocalMint:                     wrapDecoder(DecodeTokenAmountEvent),
+	core.BuiltInFunctionDCTNFTAddQuantity:                wrapDecoder(DecodeTokenAmountEvent),
+	core.BuiltInFunctionDCTNFTBurn:                       wrapDecoder(DecodeTokenAmountEvent),
+	core.BuiltInFunctionDCTFreeze:                        wrapDecoder(DecodeFreezeWipeEvent),
+	core.BuiltInFunctionDCTUnFreeze:                      wrapDecoder(DecodeFreezeWipeEvent),
+	core.BuiltInFunctionDCTWipe:                          wrapDecoder(DecodeFreezeWipeEvent),
+	core.BuiltInFunctionDCTNFTCreate:                     wrapDecoder(DecodeNFTCreateEvent),
+	core.BuiltInFunctionDCTNFTAddURI:                     wrapDecoder(DecodeNFTAddURIEvent),
+	core.BuiltInFunctionDCTNFTUpdateAttributes:           wrapDecoder(DecodeNFTUpdateAttributesEvent),
+	core.BuiltInFunctionSetDCTRole:                       wrapDecoder(DecodeRolesEvent),
+	core.BuiltInFunctionUnSetDCTRole:                     wrapDecoder(DecodeRolesEvent),
+	core.BuiltInFunctionDCTNFTCreateRoleTransfer:         wrapDecoder(DecodeCreateRoleTransferEvent),
+	vmcommon.BuiltInFunctionDCTTransferRoleAddAddress:    wrapDecoder(DecodeTransferRoleAddressEvent),
+	vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress: wrapDecoder(DecodeTransferRoleAddressEvent),
+	core.BuiltInFunctionChangeOwnerAddress:               wrapDecoder(DecodeChangeOwnerAddressEvent),
+	core.BuiltInFunctionSetUserName:                      wrapDecoder(DecodeUserNameEvent),
+	DeleteUserNameIdentifier:                             wrapDecoder(DecodeUserNameEvent),
+	core.BuiltInFunctionSetGuardian:                      wrapDecoder(DecodeSetGuardianEvent),
+	core.BuiltInFunctionGuardAccount:                     wrapDecoder(DecodeGuardAccountEvent),
+	core.BuiltInFunctionUnGuardAccount:                   wrapDecoder(DecodeGuardAccountEvent),
+}
+
+// IsBuiltInFunctionIdentifier returns true if the identifier belongs to a log entry emitted by a built-in function
+func IsBuiltInFunctionIdentifier(identifier []byte) bool {
+	_, found := decoders[string(identifier)]
+	return found
+}
+
+// Decode returns the typed event for the provided log entry, based on its identifier
+func Decode(entry *vmcommon.LogEntry) (Event, error) {
+	if entry == nil {
+		return nil, ErrNilLogEntry
+	}
+
+	decoder, found := decoders[string(entry.Identifier)]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIdentifier, entry.Identifier)
+	}
+
+	return decoder(entry)
+}
+
+// DecodeLogs returns the typed events of the provided log entries, in order. Entries not emitted
+// by built-in functions, such as smart contract events, are skipped.
+func DecodeLogs(logs []*vmcommon.LogEntry) ([]Event, error) {
+	decodedEvents := make([]Event, 0, len(logs))
+	for i, entry := range logs {
+		if entry == nil || !IsBuiltInFunctionIdentifier(entry.Identifier) {
+			continue
+		}
+
+		event, err := Decode(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w for log entry %d", err, i)
+		}
+
+		decodedEvents = append(decodedEvents, event)
+	}
+
+	return decodedEvents, nil
+}
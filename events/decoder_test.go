@@ -0,0 +1,156 @@
+package events
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var (
+	testTokenID = []byte("TKN-abcdef")
+	testAlice   = []byte("alice___________________________")
+	testBob     = []byte("bob_____________________________")
+)
+
+func TestDecode_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testEvents := []Event{
+		&TransferEvent{
+			Identifier: core.BuiltInFunctionDCTTransfer,
+			Sender:     testAlice,
+			Receiver:   testBob,
+			Tokens:     []*TokenTransfer{{TokenID: testTokenID, Value: big.NewInt(100)}},
+			Data:       [][]byte{[]byte("DirectCall"), []byte("DCTTransfer")},
+		},
+		&TransferEvent{
+			Identifier: core.BuiltInFunctionMultiDCTNFTTransfer,
+			Sender:     testAlice,
+			Receiver:   testBob,
+			Tokens: []*TokenTransfer{
+				{TokenID: testTokenID, Value: big.NewInt(1)},
+				{TokenID: []byte("NFT-123456"), Nonce: 300, Value: big.NewInt(1)},
+			},
+		},
+		&TokenAmountEvent{Identifier: core.BuiltInFunctionDCTLocalMint, Caller: testAlice, TokenID: testTokenID, Value: big.NewInt(5)},
+		&TokenAmountEvent{Identifier: core.BuiltInFunctionDCTNFTBurn, Caller: testAlice, TokenID: testTokenID, Nonce: 2, Value: big.NewInt(1)},
+		&FreezeWipeEvent{Identifier: core.BuiltInFunctionDCTWipe, Caller: core.DCTSCAddress, TokenID: testTokenID, Nonce: 7, Value: big.NewInt(3), Account: testBob},
+		&NFTCreateEvent{Creator: testAlice, TokenID: testTokenID, Nonce: 1, Quantity: big.NewInt(1), TokenData: []byte("token data")},
+		&NFTAddURIEvent{Caller: testAlice, TokenID: testTokenID, Nonce: 1, URIs: [][]byte{[]byte("uri1"), []byte("uri2")}},
+		&NFTUpdateAttributesEvent{Caller: testAlice, TokenID: testTokenID, Nonce: 1, Attributes: []byte("attributes")},
+		&RolesEvent{Identifier: core.BuiltInFunctionSetDCTRole, Address: testBob, TokenID: testTokenID, Roles: [][]byte{[]byte(core.DCTRoleLocalMint)}},
+		&CreateRoleTransferEvent{Address: testBob, TokenID: testTokenID, HasRole: true},
+		&TransferRoleAddressEvent{Identifier: vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, Address: vmcommon.SystemAccountAddress, TokenID: testTokenID, Addresses: [][]byte{testBob}},
+		&ChangeOwnerAddressEvent{Contract: testAlice, NewOwner: testBob},
+		&UserNameEvent{Identifier: DeleteUserNameIdentifier, Address: testAlice, PreviousUserName: []byte("alice.dharitri")},
+		&SetGuardianEvent{Account: testAlice, Guardian: testBob, ServiceUID: []byte("service")},
+		&GuardAccountEvent{Identifier: core.BuiltInFunctionUnGuardAccount, Account: testAlice},
+	}
+
+	for _, event := range testEvents {
+		entry := event.ToLogEntry()
+		decodedEvent, err := Decode(entry)
+		require.Nil(t, err, string(entry.Identifier))
+		assert.Equal(t, event, decodedEvent)
+		assert.Equal(t, entry, decodedEvent.ToLogEntry())
+	}
+}
+
+func TestDecode_BuiltInFunctionLayout(t *testing.T) {
+	t.Parallel()
+
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTTransfer),
+		Address:    testAlice,
+		Topics:     [][]byte{testTokenID, {0x01, 0x2c}, {0x05}, testBob},
+	}
+
+	event, err := DecodeTransferEvent(entry)
+	require.Nil(t, err)
+	assert.Equal(t, testAlice, event.Sender)
+	assert.Equal(t, testBob, event.Receiver)
+	assert.Equal(t, []*TokenTransfer{{TokenID: testTokenID, Nonce: 300, Value: big.NewInt(5)}}, event.Tokens)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil entry", func(t *testing.T) {
+		_, err := Decode(nil)
+		assert.Equal(t, ErrNilLogEntry, err)
+		_, err = DecodeNFTCreateEvent(nil)
+		assert.Equal(t, ErrNilLogEntry, err)
+	})
+	t.Run("unknown identifier", func(t *testing.T) {
+		_, err := Decode(&vmcommon.LogEntry{Identifier: []byte("scEvent")})
+		assert.True(t, errors.Is(err, ErrUnknownIdentifier))
+	})
+	t.Run("wrong identifier for the event type", func(t *testing.T) {
+		_, err := DecodeSetGuardianEvent(&vmcommon.LogEntry{Identifier: []byte(core.BuiltInFunctionGuardAccount)})
+		assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+	})
+	t.Run("invalid number of topics", func(t *testing.T) {
+		entries := []*vmcommon.LogEntry{
+			{Identifier: []byte(core.BuiltInFunctionDCTTransfer), Topics: [][]byte{testTokenID, nil, {1}}},
+			{Identifier: []byte(core.BuiltInFunctionDCTTransfer), Topics: [][]byte{testTokenID, nil, {1}, testTokenID, nil, {1}, testBob}},
+			{Identifier: []byte(core.BuiltInFunctionMultiDCTNFTTransfer), Topics: [][]byte{testTokenID, nil, {1}, testTokenID, testBob}},
+			{Identifier: []byte(core.BuiltInFunctionDCTBurn), Topics: [][]byte{testTokenID, nil}},
+			{Identifier: []byte(core.BuiltInFunctionDCTFreeze), Topics: [][]byte{testTokenID, nil, nil}},
+			{Identifier: []byte(core.BuiltInFunctionDCTNFTAddURI), Topics: [][]byte{testTokenID, nil, nil}},
+			{Identifier: []byte(core.BuiltInFunctionChangeOwnerAddress)},
+			{Identifier: []byte(core.BuiltInFunctionSetGuardian), Topics: [][]byte{testBob}},
+			{Identifier: []byte(core.BuiltInFunctionGuardAccount), Topics: [][]byte{testBob}},
+		}
+		for _, entry := range entries {
+			_, err := Decode(entry)
+			assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics), string(entry.Identifier))
+		}
+	})
+	t.Run("malformed topics", func(t *testing.T) {
+		_, err := Decode(&vmcommon.LogEntry{
+			Identifier: []byte(core.BuiltInFunctionDCTNFTBurn),
+			Topics:     [][]byte{testTokenID, make([]byte, 9), {1}},
+		})
+		assert.True(t, errors.Is(err, ErrNonceTooLarge))
+
+		_, err = Decode(&vmcommon.LogEntry{
+			Identifier: []byte(core.BuiltInFunctionDCTLocalMint),
+			Topics:     [][]byte{nil, nil, {1}},
+		})
+		assert.True(t, errors.Is(err, ErrEmptyTopic))
+
+		_, err = Decode(&vmcommon.LogEntry{
+			Identifier: []byte(core.BuiltInFunctionDCTNFTCreateRoleTransfer),
+			Topics:     [][]byte{testTokenID, nil, nil, []byte("yes")},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidBoolTopic))
+	})
+}
+
+func TestDecodeLogs(t *testing.T) {
+	t.Parallel()
+
+	guardEvent := &GuardAccountEvent{Identifier: core.BuiltInFunctionGuardAccount, Account: testAlice}
+	ownerEvent := &ChangeOwnerAddressEvent{Contract: testAlice, NewOwner: testBob}
+	logs := []*vmcommon.LogEntry{
+		guardEvent.ToLogEntry(),
+		{Identifier: []byte("scEvent"), Topics: [][]byte{[]byte("anything")}},
+		nil,
+		ownerEvent.ToLogEntry(),
+	}
+
+	decodedEvents, err := DecodeLogs(logs)
+	require.Nil(t, err)
+	assert.Equal(t, []Event{guardEvent, ownerEvent}, decodedEvents)
+
+	logs = append(logs, &vmcommon.LogEntry{Identifier: []byte(core.BuiltInFunctionSetGuardian)})
+	decodedEvents, err = DecodeLogs(logs)
+	assert.Nil(t, decodedEvents)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+	assert.Contains(t, err.Error(), "log entry 4")
+}
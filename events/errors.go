@@ -0,0 +1,27 @@
+package events
+
+import "errors"
+
+// ErrNilLogEntry signals that a nil log entry was provided
+var ErrNilLogEntry = errors.New("nil log entry")
+
+// ErrUnknownIdentifier signals that the log entry identifier is not emitted by any built-in function
+var ErrUnknownIdentifier = errors.New("unknown log entry identifier")
+
+// ErrInvalidIdentifier signals that the log entry identifier does not match the event type
+var ErrInvalidIdentifier = errors.New("invalid log entry identifier")
+
+// ErrInvalidNumberOfTopics signals that the log entry does not have the expected number of topics
+var ErrInvalidNumberOfTopics = errors.New("invalid number of topics")
+
+// ErrNonceTooLarge signals that a nonce topic does not fit in an uint64
+var ErrNonceTooLarge = errors.New("nonce topic is too large")
+
+// ErrInvalidBoolTopic signals that a topic holds neither "true" nor "false"
+var ErrInvalidBoolTopic = errors.New("invalid bool topic")
+
+// ErrEmptyTopic signals that a mandatory topic is empty
+var ErrEmptyTopic = errors.New("empty topic")
+
+// ErrNilValue signals that a nil value was provided for an event field
+var ErrNilValue = errors.New("nil value")
@@ -0,0 +1,365 @@
+package events
+
+import (
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+const numTokenTopics = 3
+
+var _ Event = (*TokenAmountEvent)(nil)
+var _ Event = (*FreezeWipeEvent)(nil)
+var _ Event = (*NFTCreateEvent)(nil)
+var _ Event = (*NFTAddURIEvent)(nil)
+var _ Event = (*NFTUpdateAttributesEvent)(nil)
+var _ Event = (*RolesEvent)(nil)
+var _ Event = (*CreateRoleTransferEvent)(nil)
+var _ Event = (*TransferRoleAddressEvent)(nil)
+
+// TokenAmountEvent is emitted by DCTBurn, DCTLocalBurn, DCTLocalMint, DCTNFTAddQuantity and DCTNFTBurn
+type TokenAmountEvent struct {
+	Identifier string
+	Caller     []byte
+	TokenID    []byte
+	Nonce      uint64
+	Value      *big.Int
+}
+
+// DecodeTokenAmountEvent decodes a burn, mint or add quantity log entry
+func DecodeTokenAmountEvent(entry *vmcommon.LogEntry) (*TokenAmountEvent, error) {
+	err := checkIdentifier(entry,
+		core.BuiltInFunctionDCTBurn,
+		core.BuiltInFunctionDCTLocalBurn,
+		core.BuiltInFunctionDCTLocalMint,
+		core.BuiltInFunctionDCTNFTAddQuantity,
+		core.BuiltInFunctionDCTNFTBurn,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, numTokenTopics)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenAmountEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		TokenID:    topics.tokenID,
+		Nonce:      topics.nonce,
+		Value:      topics.value,
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *TokenAmountEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Caller,
+		Topics:     encodeTokenTopics(event.TokenID, event.Nonce, event.Value),
+	}
+}
+
+// FreezeWipeEvent is emitted by DCTFreeze, DCTUnFreeze and DCTWipe. Value is the balance of the account.
+type FreezeWipeEvent struct {
+	Identifier string
+	Caller     []byte
+	TokenID    []byte
+	Nonce      uint64
+	Value      *big.Int
+	Account    []byte
+}
+
+// DecodeFreezeWipeEvent decodes a freeze, unfreeze or wipe log entry
+func DecodeFreezeWipeEvent(entry *vmcommon.LogEntry) (*FreezeWipeEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionDCTFreeze, core.BuiltInFunctionDCTUnFreeze, core.BuiltInFunctionDCTWipe)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FreezeWipeEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		TokenID:    topics.tokenID,
+		Nonce:      topics.nonce,
+		Value:      topics.value,
+		Account:    entry.Topics[numTokenTopics],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *FreezeWipeEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Caller,
+		Topics:     append(encodeTokenTopics(event.TokenID, event.Nonce, event.Value), event.Account),
+	}
+}
+
+// NFTCreateEvent is emitted by DCTNFTCreate. TokenData holds the marshalled dct.DCToken.
+type NFTCreateEvent struct {
+	Creator   []byte
+	TokenID   []byte
+	Nonce     uint64
+	Quantity  *big.Int
+	TokenData []byte
+}
+
+// DecodeNFTCreateEvent decodes a DCTNFTCreate log entry
+func DecodeNFTCreateEvent(entry *vmcommon.LogEntry) (*NFTCreateEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionDCTNFTCreate)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTCreateEvent{
+		Creator:   entry.Address,
+		TokenID:   topics.tokenID,
+		Nonce:     topics.nonce,
+		Quantity:  topics.value,
+		TokenData: entry.Topics[numTokenTopics],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *NFTCreateEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTCreate),
+		Address:    event.Creator,
+		Topics:     append(encodeTokenTopics(event.TokenID, event.Nonce, event.Quantity), event.TokenData),
+	}
+}
+
+// NFTAddURIEvent is emitted by DCTNFTAddURI
+type NFTAddURIEvent struct {
+	Caller  []byte
+	TokenID []byte
+	Nonce   uint64
+	URIs    [][]byte
+}
+
+// DecodeNFTAddURIEvent decodes a DCTNFTAddURI log entry
+func DecodeNFTAddURIEvent(entry *vmcommon.LogEntry) (*NFTAddURIEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionDCTNFTAddURI)
+	if err != nil {
+		return nil, err
+	}
+	err = checkMinNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTAddURIEvent{
+		Caller:  entry.Address,
+		TokenID: topics.tokenID,
+		Nonce:   topics.nonce,
+		URIs:    entry.Topics[numTokenTopics:],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *NFTAddURIEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTAddURI),
+		Address:    event.Caller,
+		Topics:     append(encodeTokenTopics(event.TokenID, event.Nonce, nil), event.URIs...),
+	}
+}
+
+// NFTUpdateAttributesEvent is emitted by DCTNFTUpdateAttributes
+type NFTUpdateAttributesEvent struct {
+	Caller     []byte
+	TokenID    []byte
+	Nonce      uint64
+	Attributes []byte
+}
+
+// DecodeNFTUpdateAttributesEvent decodes a DCTNFTUpdateAttributes log entry
+func DecodeNFTUpdateAttributesEvent(entry *vmcommon.LogEntry) (*NFTUpdateAttributesEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionDCTNFTUpdateAttributes)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTUpdateAttributesEvent{
+		Caller:     entry.Address,
+		TokenID:    topics.tokenID,
+		Nonce:      topics.nonce,
+		Attributes: entry.Topics[numTokenTopics],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *NFTUpdateAttributesEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTUpdateAttributes),
+		Address:    event.Caller,
+		Topics:     append(encodeTokenTopics(event.TokenID, event.Nonce, nil), event.Attributes),
+	}
+}
+
+// RolesEvent is emitted by DCTSetRole and DCTUnSetRole. Address is the account the roles were changed for.
+type RolesEvent struct {
+	Identifier string
+	Address    []byte
+	TokenID    []byte
+	Roles      [][]byte
+}
+
+// DecodeRolesEvent decodes a DCTSetRole or DCTUnSetRole log entry
+func DecodeRolesEvent(entry *vmcommon.LogEntry) (*RolesEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionSetDCTRole, core.BuiltInFunctionUnSetDCTRole)
+	if err != nil {
+		return nil, err
+	}
+	err = checkMinNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RolesEvent{
+		Identifier: string(entry.Identifier),
+		Address:    entry.Address,
+		TokenID:    topics.tokenID,
+		Roles:      entry.Topics[numTokenTopics:],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *RolesEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Address,
+		Topics:     append(encodeTokenTopics(event.TokenID, 0, nil), event.Roles...),
+	}
+}
+
+// CreateRoleTransferEvent is emitted by DCTNFTCreateRoleTransfer, once for the account that lost the NFT create
+// role and once for the account that received it
+type CreateRoleTransferEvent struct {
+	Address []byte
+	TokenID []byte
+	HasRole bool
+}
+
+// DecodeCreateRoleTransferEvent decodes a DCTNFTCreateRoleTransfer log entry
+func DecodeCreateRoleTransferEvent(entry *vmcommon.LogEntry) (*CreateRoleTransferEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionDCTNFTCreateRoleTransfer)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+	hasRole, err := decodeBool(entry.Topics[numTokenTopics])
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateRoleTransferEvent{
+		Address: entry.Address,
+		TokenID: topics.tokenID,
+		HasRole: hasRole,
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *CreateRoleTransferEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTCreateRoleTransfer),
+		Address:    event.Address,
+		Topics:     append(encodeTokenTopics(event.TokenID, 0, nil), encodeBool(event.HasRole)),
+	}
+}
+
+// TransferRoleAddressEvent is emitted by DCTTransferRoleAddAddress and DCTTransferRoleDeleteAddress.
+// Address is the system account, which holds the list of addresses.
+type TransferRoleAddressEvent struct {
+	Identifier string
+	Address    []byte
+	TokenID    []byte
+	Addresses  [][]byte
+}
+
+// DecodeTransferRoleAddressEvent decodes a DCTTransferRoleAddAddress or DCTTransferRoleDeleteAddress log entry
+func DecodeTransferRoleAddressEvent(entry *vmcommon.LogEntry) (*TransferRoleAddressEvent, error) {
+	err := checkIdentifier(entry, vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress)
+	if err != nil {
+		return nil, err
+	}
+	err = checkMinNumTopics(entry, numTokenTopics+1)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := decodeTokenTopics(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferRoleAddressEvent{
+		Identifier: string(entry.Identifier),
+		Address:    entry.Address,
+		TokenID:    topics.tokenID,
+		Addresses:  entry.Topics[numTokenTopics:],
+	}, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *TransferRoleAddressEvent) ToLogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Address,
+		Topics:     append(encodeTokenTopics(event.TokenID, 0, nil), event.Addresses...),
+	}
+}
@@ -0,0 +1,112 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+const (
+	maxNonceLength = 8
+	trueTopic      = "true"
+	falseTopic     = "false"
+)
+
+func encodeNonce(nonce uint64) []byte {
+	return big.NewInt(0).SetUint64(nonce).Bytes()
+}
+
+func decodeNonce(topic []byte) (uint64, error) {
+	if len(topic) > maxNonceLength {
+		return 0, fmt.Errorf("%w, length %d", ErrNonceTooLarge, len(topic))
+	}
+
+	return big.NewInt(0).SetBytes(topic).Uint64(), nil
+}
+
+func encodeValue(value *big.Int) []byte {
+	if value == nil {
+		return make([]byte, 0)
+	}
+
+	return value.Bytes()
+}
+
+func decodeValue(topic []byte) *big.Int {
+	return big.NewInt(0).SetBytes(topic)
+}
+
+func encodeBool(value bool) []byte {
+	return []byte(strconv.FormatBool(value))
+}
+
+func decodeBool(topic []byte) (bool, error) {
+	switch string(topic) {
+	case trueTopic:
+		return true, nil
+	case falseTopic:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %q", ErrInvalidBoolTopic, topic)
+	}
+}
+
+func checkIdentifier(entry *vmcommon.LogEntry, allowedIdentifiers ...string) error {
+	if entry == nil {
+		return ErrNilLogEntry
+	}
+
+	for _, identifier := range allowedIdentifiers {
+		if string(entry.Identifier) == identifier {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidIdentifier, entry.Identifier)
+}
+
+func checkNumTopics(entry *vmcommon.LogEntry, expected int) error {
+	if len(entry.Topics) != expected {
+		return fmt.Errorf("%w for %s: expected %d, got %d", ErrInvalidNumberOfTopics, entry.Identifier, expected, len(entry.Topics))
+	}
+
+	return nil
+}
+
+func checkMinNumTopics(entry *vmcommon.LogEntry, minimum int) error {
+	if len(entry.Topics) < minimum {
+		return fmt.Errorf("%w for %s: expected at least %d, got %d", ErrInvalidNumberOfTopics, entry.Identifier, minimum, len(entry.Topics))
+	}
+
+	return nil
+}
+
+// tokenTopics holds the token identifier, nonce and value topics that start every DCT log entry
+type tokenTopics struct {
+	tokenID []byte
+	nonce   uint64
+	value   *big.Int
+}
+
+func decodeTokenTopics(topics [][]byte) (*tokenTopics, error) {
+	if len(topics[0]) == 0 {
+		return nil, fmt.Errorf("%w: token identifier", ErrEmptyTopic)
+	}
+
+	nonce, err := decodeNonce(topics[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenTopics{
+		tokenID: topics[0],
+		nonce:   nonce,
+		value:   decodeValue(topics[2]),
+	}, nil
+}
+
+func encodeTokenTopics(tokenID []byte, nonce uint64, value *big.Int) [][]byte {
+	return [][]byte{tokenID, encodeNonce(nonce), encodeValue(value)}
+}
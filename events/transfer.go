@@ -0,0 +1,88 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+const numTopicsPerTransferredToken = 3
+
+var _ Event = (*TransferEvent)(nil)
+
+// TokenTransfer is one token moved by a transfer event
+type TokenTransfer struct {
+	TokenID []byte
+	Nonce   uint64
+	Value   *big.Int
+}
+
+// TransferEvent is emitted by DCTTransfer, DCTNFTTransfer and MultiDCTNFTTransfer. The topics hold a
+// (token identifier, nonce, value) triple for every transferred token, followed by the receiver.
+type TransferEvent struct {
+	Identifier string
+	Sender     []byte
+	Receiver   []byte
+	Tokens     []*TokenTransfer
+	Data       [][]byte
+}
+
+// DecodeTransferEvent decodes a DCTTransfer, DCTNFTTransfer or MultiDCTNFTTransfer log entry
+func DecodeTransferEvent(entry *vmcommon.LogEntry) (*TransferEvent, error) {
+	err := checkIdentifier(entry, core.BuiltInFunctionDCTTransfer, core.BuiltInFunctionDCTNFTTransfer, core.BuiltInFunctionMultiDCTNFTTransfer)
+	if err != nil {
+		return nil, err
+	}
+	err = checkMinNumTopics(entry, numTopicsPerTransferredToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	numTokens := (len(entry.Topics) - 1) / numTopicsPerTransferredToken
+	if numTokens*numTopicsPerTransferredToken+1 != len(entry.Topics) {
+		return nil, fmt.Errorf("%w for %s: %d topics can not hold whole token triples", ErrInvalidNumberOfTopics, entry.Identifier, len(entry.Topics))
+	}
+	if numTokens > 1 && string(entry.Identifier) != core.BuiltInFunctionMultiDCTNFTTransfer {
+		return nil, fmt.Errorf("%w for %s: only one token can be transferred", ErrInvalidNumberOfTopics, entry.Identifier)
+	}
+
+	event := &TransferEvent{
+		Identifier: string(entry.Identifier),
+		Sender:     entry.Address,
+		Receiver:   entry.Topics[len(entry.Topics)-1],
+		Tokens:     make([]*TokenTransfer, 0, numTokens),
+		Data:       entry.Data,
+	}
+	for i := 0; i < numTokens; i++ {
+		topics, errDecode := decodeTokenTopics(entry.Topics[i*numTopicsPerTransferredToken:])
+		if errDecode != nil {
+			return nil, fmt.Errorf("%w for token %d", errDecode, i)
+		}
+
+		event.Tokens = append(event.Tokens, &TokenTransfer{
+			TokenID: topics.tokenID,
+			Nonce:   topics.nonce,
+			Value:   topics.value,
+		})
+	}
+
+	return event, nil
+}
+
+// ToLogEntry encodes the event the same way the built-in functions do
+func (event *TransferEvent) ToLogEntry() *vmcommon.LogEntry {
+	topics := make([][]byte, 0, len(event.Tokens)*numTopicsPerTransferredToken+1)
+	for _, token := range event.Tokens {
+		topics = append(topics, encodeTokenTopics(token.TokenID, token.Nonce, token.Value)...)
+	}
+	topics = append(topics, event.Receiver)
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Sender,
+		Topics:     topics,
+		Data:       event.Data,
+	}
+}
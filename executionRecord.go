@@ -0,0 +1,38 @@
+package vmcommon
+
+import "math/big"
+
+// StorageEntry holds the value of one account storage key. A nil value means that the key does not exist.
+type StorageEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// AccountState holds the fields of an account and the values of a subset of its storage keys
+type AccountState struct {
+	Address         []byte
+	Nonce           uint64
+	Balance         *big.Int
+	DeveloperReward *big.Int
+	OwnerAddress    []byte
+	UserName        []byte
+	CodeMetadata    []byte
+
+	// Storage lists the storage keys, sorted by key.
+	Storage []*StorageEntry
+}
+
+// ExecutionRecord holds everything needed to replay a built-in function call and check its results
+type ExecutionRecord struct {
+	Input *ContractCallInput
+
+	// PreState lists the accounts the call loaded, sorted by address, as they were before the call.
+	// Only the storage keys the call accessed are included.
+	PreState []*AccountState
+
+	// VMOutput is the output of the call, including the state diff. It is nil if the call failed.
+	VMOutput *VMOutput
+
+	// Error is the message of the error the call failed with. It is empty if the call succeeded.
+	Error string
+}
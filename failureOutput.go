@@ -0,0 +1,47 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// NewFailureVMOutput creates the output of a failed call of the function from the error it returned. The failure
+// consumes all the provided gas, so nothing remains and nothing is refunded. The return code is the one carried by
+// the first ReturnCodeHandler found in the chain of err, or ExecutionFailed, and the return message is the error
+// message prefixed by the function name, if provided.
+func NewFailureVMOutput(function string, err error) *VMOutput {
+	vmOutput := &VMOutput{
+		ReturnCode:      ExecutionFailed,
+		GasRemaining:    0,
+		GasRefund:       big.NewInt(0),
+		OutputAccounts:  make(map[string]*OutputAccount),
+		DeletedAccounts: make([][]byte, 0),
+		TouchedAccounts: make([][]byte, 0),
+		Logs:            make([]*LogEntry, 0),
+	}
+	if err != nil {
+		vmOutput.ReturnCode = ReturnCodeOfError(err)
+		vmOutput.ReturnMessage = err.Error()
+		if len(function) > 0 {
+			vmOutput.ReturnMessage = fmt.Sprintf("%s: %s", function, err.Error())
+		}
+	}
+
+	return vmOutput
+}
+
+// ReturnCodeOfError returns the return code carried by the first ReturnCodeHandler found in the chain of err.
+// A nil error returns Ok and an error which carries no return code returns ExecutionFailed.
+func ReturnCodeOfError(err error) ReturnCode {
+	if err == nil {
+		return Ok
+	}
+
+	var handler ReturnCodeHandler
+	if errors.As(err, &handler) {
+		return handler.GetReturnCode()
+	}
+
+	return ExecutionFailed
+}
@@ -0,0 +1,65 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type returnCodeError struct {
+	returnCode ReturnCode
+}
+
+func (e *returnCodeError) Error() string {
+	return "return code error"
+}
+
+func (e *returnCodeError) GetReturnCode() ReturnCode {
+	return e.returnCode
+}
+
+func TestNewFailureVMOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error with return code", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("%w: wrapped", &returnCodeError{returnCode: OutOfFunds})
+		vmOutput := NewFailureVMOutput("function", err)
+		assert.Equal(t, &VMOutput{
+			ReturnCode:      OutOfFunds,
+			ReturnMessage:   "function: return code error: wrapped",
+			GasRemaining:    0,
+			GasRefund:       big.NewInt(0),
+			OutputAccounts:  make(map[string]*OutputAccount),
+			DeletedAccounts: make([][]byte, 0),
+			TouchedAccounts: make([][]byte, 0),
+			Logs:            make([]*LogEntry, 0),
+		}, vmOutput)
+	})
+	t.Run("error without return code", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := NewFailureVMOutput("", errors.New("expected error"))
+		assert.Equal(t, ExecutionFailed, vmOutput.ReturnCode)
+		assert.Equal(t, "expected error", vmOutput.ReturnMessage)
+	})
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := NewFailureVMOutput("function", nil)
+		assert.Equal(t, ExecutionFailed, vmOutput.ReturnCode)
+		assert.Empty(t, vmOutput.ReturnMessage)
+	})
+}
+
+func TestReturnCodeOfError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Ok, ReturnCodeOfError(nil))
+	assert.Equal(t, ExecutionFailed, ReturnCodeOfError(errors.New("expected error")))
+	assert.Equal(t, OutOfGas, ReturnCodeOfError(&returnCodeError{returnCode: OutOfGas}))
+}
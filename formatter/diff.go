@@ -0,0 +1,118 @@
+package formatter
+
+import (
+	"strings"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// contextLines is the number of unchanged lines shown around every change
+const contextLines = 2
+
+const (
+	unchangedPrefix = "  "
+	removedPrefix   = "- "
+	addedPrefix     = "+ "
+	skippedLine     = "..."
+)
+
+// Diff renders both VM outputs and returns the lines of the expected output missing from the actual one,
+// prefixed by "- ", and the lines added by the actual output, prefixed by "+ ", surrounded by a few unchanged
+// lines. It returns an empty string if both outputs are rendered the same.
+func (f *formatter) Diff(expected *vmcommon.VMOutput, actual *vmcommon.VMOutput) string {
+	return diffLines(f.OutputLines(expected), f.OutputLines(actual))
+}
+
+// DiffInputs is the Diff counterpart for contract call inputs
+func (f *formatter) DiffInputs(expected *vmcommon.ContractCallInput, actual *vmcommon.ContractCallInput) string {
+	return diffLines(f.InputLines(expected), f.InputLines(actual))
+}
+
+type diffLine struct {
+	prefix string
+	text   string
+}
+
+func diffLines(expected []string, actual []string) string {
+	lines := computeDiff(expected, actual)
+
+	isShown := make([]bool, len(lines))
+	hasChanges := false
+	for index, line := range lines {
+		if line.prefix == unchangedPrefix {
+			continue
+		}
+
+		hasChanges = true
+		for shown := index - contextLines; shown <= index+contextLines; shown++ {
+			if shown >= 0 && shown < len(lines) {
+				isShown[shown] = true
+			}
+		}
+	}
+	if !hasChanges {
+		return ""
+	}
+
+	result := make([]string, 0)
+	isSkipping := false
+	for index, line := range lines {
+		if !isShown[index] {
+			if !isSkipping {
+				result = append(result, skippedLine)
+			}
+			isSkipping = true
+			continue
+		}
+
+		isSkipping = false
+		result = append(result, line.prefix+line.text)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// computeDiff aligns the lines on their longest common subsequence
+func computeDiff(expected []string, actual []string) []diffLine {
+	lengths := make([][]int, len(expected)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+				continue
+			}
+			lengths[i][j] = lengths[i+1][j]
+			if lengths[i][j+1] > lengths[i][j] {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(expected)+len(actual))
+	i, j := 0, 0
+	for i < len(expected) && j < len(actual) {
+		switch {
+		case expected[i] == actual[j]:
+			lines = append(lines, diffLine{prefix: unchangedPrefix, text: expected[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			lines = append(lines, diffLine{prefix: removedPrefix, text: expected[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{prefix: addedPrefix, text: actual[j]})
+			j++
+		}
+	}
+	for ; i < len(expected); i++ {
+		lines = append(lines, diffLine{prefix: removedPrefix, text: expected[i]})
+	}
+	for ; j < len(actual); j++ {
+		lines = append(lines, diffLine{prefix: addedPrefix, text: actual[j]})
+	}
+
+	return lines
+}
@@ -0,0 +1,300 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+const (
+	indentation          = "  "
+	maxBigIntValueLength = 32
+	argumentsSeparator   = "@"
+	systemAccountName    = "system account"
+)
+
+// ArgsFormatter defines the arguments needed to create a formatter
+type ArgsFormatter struct {
+	// AddressConverter renders the addresses, for example as bech32. If nil, the addresses are hex encoded.
+	AddressConverter core.PubkeyConverter
+}
+
+type formatter struct {
+	addressConverter core.PubkeyConverter
+}
+
+// NewFormatter creates a component which renders contract call inputs and VM outputs as indented lines. The
+// addresses are decoded, the data of the transfers is split in function and arguments and every value is shown
+// as text if printable, such as token identifiers, or as hex followed by its big integer value.
+func NewFormatter(args ArgsFormatter) *formatter {
+	f := &formatter{}
+	if !check.IfNil(args.AddressConverter) {
+		f.addressConverter = args.AddressConverter
+	}
+
+	return f
+}
+
+// FormatInput renders the contract call input
+func (f *formatter) FormatInput(input *vmcommon.ContractCallInput) string {
+	return strings.Join(f.InputLines(input), "\n")
+}
+
+// FormatOutput renders the VM output
+func (f *formatter) FormatOutput(vmOutput *vmcommon.VMOutput) string {
+	return strings.Join(f.OutputLines(vmOutput), "\n")
+}
+
+// InputLines renders the contract call input, one line for every field
+func (f *formatter) InputLines(input *vmcommon.ContractCallInput) []string {
+	if input == nil {
+		return []string{"nil input"}
+	}
+
+	lines := []string{
+		"function: " + input.Function,
+		"caller: " + f.formatAddress(input.CallerAddr),
+		"recipient: " + f.formatAddress(input.RecipientAddr),
+		"call value: " + formatBigInt(input.CallValue),
+		"call type: " + input.CallType.ToString(),
+		fmt.Sprintf("gas provided: %d", input.GasProvided),
+	}
+	if input.GasLocked > 0 {
+		lines = append(lines, fmt.Sprintf("gas locked: %d", input.GasLocked))
+	}
+	lines = append(lines, formatList("arguments", input.Arguments, formatValue)...)
+	if len(input.DCTTransfers) > 0 {
+		lines = append(lines, "dct transfers:")
+		for _, transfer := range input.DCTTransfers {
+			if transfer == nil {
+				lines = append(lines, indentation+"nil")
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s%s nonce %d value %s", indentation, transfer.DCTTokenName, transfer.DCTTokenNonce, formatBigInt(transfer.DCTValue)))
+		}
+	}
+	if len(input.OriginalCallerAddr) > 0 && !bytes.Equal(input.OriginalCallerAddr, input.CallerAddr) {
+		lines = append(lines, "original caller: "+f.formatAddress(input.OriginalCallerAddr))
+	}
+
+	return lines
+}
+
+// OutputLines renders the VM output, one line for every field. The output accounts and the storage updates are
+// sorted, so equal outputs are always rendered the same.
+func (f *formatter) OutputLines(vmOutput *vmcommon.VMOutput) []string {
+	if vmOutput == nil {
+		return []string{"nil output"}
+	}
+
+	lines := []string{"return code: " + vmOutput.ReturnCode.String()}
+	if len(vmOutput.ReturnMessage) > 0 {
+		lines = append(lines, "return message: "+vmOutput.ReturnMessage)
+	}
+	lines = append(lines, fmt.Sprintf("gas remaining: %d", vmOutput.GasRemaining))
+	if vmOutput.GasRefund != nil && vmOutput.GasRefund.Sign() != 0 {
+		lines = append(lines, "gas refund: "+vmOutput.GasRefund.String())
+	}
+	lines = append(lines, formatList("return data", vmOutput.ReturnData, formatValue)...)
+
+	keys := make([]string, 0, len(vmOutput.OutputAccounts))
+	for key := range vmOutput.OutputAccounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, f.outputAccountLines(key, vmOutput.OutputAccounts[key])...)
+	}
+
+	lines = append(lines, formatList("deleted accounts", vmOutput.DeletedAccounts, f.formatAddress)...)
+	lines = append(lines, formatList("touched accounts", vmOutput.TouchedAccounts, f.formatAddress)...)
+	for index, logEntry := range vmOutput.Logs {
+		lines = append(lines, f.logEntryLines(index, logEntry)...)
+	}
+	for _, gasCharge := range vmOutput.GasCharges {
+		if gasCharge == nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("gas charge %s.%s: %d units, %d gas", gasCharge.Category, gasCharge.CostParameter, gasCharge.Units, gasCharge.Amount))
+	}
+
+	return lines
+}
+
+func (f *formatter) outputAccountLines(key string, account *vmcommon.OutputAccount) []string {
+	header := "account " + f.formatAddress([]byte(key))
+	if account == nil {
+		return []string{header + ": nil"}
+	}
+
+	lines := []string{header + ":"}
+	if key != string(account.Address) {
+		lines = append(lines, indentation+"address: "+f.formatAddress(account.Address))
+	}
+	if account.Nonce > 0 {
+		lines = append(lines, fmt.Sprintf("%snonce: %d", indentation, account.Nonce))
+	}
+	if account.Balance != nil {
+		lines = append(lines, indentation+"balance: "+account.Balance.String())
+	}
+	if account.BalanceDelta != nil && account.BalanceDelta.Sign() != 0 {
+		lines = append(lines, indentation+"balance delta: "+account.BalanceDelta.String())
+	}
+	if len(account.Code) > 0 {
+		lines = append(lines, fmt.Sprintf("%scode: %d bytes, metadata %x, deployer %s", indentation, len(account.Code), account.CodeMetadata, f.formatAddress(account.CodeDeployerAddress)))
+	}
+	if account.GasUsed > 0 {
+		lines = append(lines, fmt.Sprintf("%sgas used: %d", indentation, account.GasUsed))
+	}
+
+	storageKeys := make([]string, 0, len(account.StorageUpdates))
+	for storageKey := range account.StorageUpdates {
+		storageKeys = append(storageKeys, storageKey)
+	}
+	sort.Strings(storageKeys)
+	for _, storageKey := range storageKeys {
+		update := account.StorageUpdates[storageKey]
+		if update == nil {
+			lines = append(lines, fmt.Sprintf("%sstorage %s: nil", indentation, formatValue([]byte(storageKey))))
+			continue
+		}
+
+		written := ""
+		if update.Written {
+			written = " (written)"
+		}
+		lines = append(lines, fmt.Sprintf("%sstorage %s = %s%s", indentation, formatValue([]byte(storageKey)), formatValue(update.Data), written))
+	}
+
+	for _, transfer := range account.OutputTransfers {
+		lines = append(lines, fmt.Sprintf("%stransfer %d: value %s, gas limit %d, %s", indentation, transfer.Index, formatBigInt(transfer.Value), transfer.GasLimit, transfer.CallType.ToString()))
+		if len(transfer.SenderAddress) > 0 {
+			lines = append(lines, indentation+indentation+"sender: "+f.formatAddress(transfer.SenderAddress))
+		}
+		lines = append(lines, formatCallData(indentation+indentation, transfer.Data)...)
+	}
+
+	return lines
+}
+
+func (f *formatter) logEntryLines(index int, logEntry *vmcommon.LogEntry) []string {
+	if logEntry == nil {
+		return []string{fmt.Sprintf("log %d: nil", index)}
+	}
+
+	lines := []string{
+		fmt.Sprintf("log %d: %s", index, logEntry.Identifier),
+		indentation + "address: " + f.formatAddress(logEntry.Address),
+	}
+	for topicIndex, topic := range logEntry.Topics {
+		lines = append(lines, fmt.Sprintf("%stopic %d: %s", indentation, topicIndex, f.formatTopic(topic)))
+	}
+	for dataIndex, data := range logEntry.Data {
+		lines = append(lines, fmt.Sprintf("%sdata %d: %s", indentation, dataIndex, formatValue(data)))
+	}
+
+	return lines
+}
+
+// formatTopic renders the topics having the length of an address as addresses
+func (f *formatter) formatTopic(topic []byte) string {
+	if !check.IfNil(f.addressConverter) && len(topic) == f.addressConverter.Len() {
+		return f.formatAddress(topic)
+	}
+
+	return formatValue(topic)
+}
+
+func (f *formatter) formatAddress(address []byte) string {
+	if len(address) == 0 {
+		return "<empty>"
+	}
+	if bytes.Equal(address, vmcommon.SystemAccountAddress) {
+		return systemAccountName
+	}
+	if check.IfNil(f.addressConverter) || len(address) != f.addressConverter.Len() {
+		return hex.EncodeToString(address)
+	}
+
+	encoded, err := f.addressConverter.Encode(address)
+	if err != nil {
+		return hex.EncodeToString(address)
+	}
+
+	return encoded
+}
+
+// formatCallData splits the data in the called function and its hex encoded arguments
+func formatCallData(prefix string, data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	tokens := strings.Split(string(data), argumentsSeparator)
+	lines := []string{prefix + "function: " + tokens[0]}
+	for index, token := range tokens[1:] {
+		argument, err := hex.DecodeString(token)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("%sargument %d: %q (not hex)", prefix, index, token))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%sargument %d: %s", prefix, index, formatValue(argument)))
+	}
+
+	return lines
+}
+
+func formatList(name string, values [][]byte, format func(value []byte) string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	lines := []string{name + ":"}
+	for _, value := range values {
+		lines = append(lines, indentation+format(value))
+	}
+
+	return lines
+}
+
+// formatValue renders printable values as quoted text and the other values as hex, followed by their big
+// integer value if short enough
+func formatValue(value []byte) string {
+	if len(value) == 0 {
+		return `""`
+	}
+	if isPrintable(value) {
+		return fmt.Sprintf("%q", value)
+	}
+	if len(value) <= maxBigIntValueLength {
+		return fmt.Sprintf("0x%x (%s)", value, big.NewInt(0).SetBytes(value).String())
+	}
+
+	return fmt.Sprintf("0x%x", value)
+}
+
+func isPrintable(value []byte) bool {
+	for _, character := range value {
+		if character < 0x20 || character > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formatBigInt(value *big.Int) string {
+	return vmcommon.ZeroValueIfNil(value).String()
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (f *formatter) IsInterfaceNil() bool {
+	return f == nil
+}
@@ -0,0 +1,177 @@
+package formatter
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/pubkeyConverter"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func createAddress(lastByte byte) []byte {
+	address := bytes.Repeat([]byte{0}, 32)
+	address[31] = lastByte
+
+	return address
+}
+
+func createOutput() *vmcommon.VMOutput {
+	alice := createAddress(1)
+	bob := createAddress(2)
+
+	return &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: 90,
+		ReturnData:   [][]byte{[]byte("ok")},
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			string(bob): {
+				Address:      bob,
+				BalanceDelta: big.NewInt(0),
+				OutputTransfers: []vmcommon.OutputTransfer{
+					{
+						Index:         1,
+						Value:         big.NewInt(0),
+						GasLimit:      5,
+						Data:          []byte("DCTTransfer@544b4e2d616263646566@64"),
+						CallType:      vm.DirectCall,
+						SenderAddress: alice,
+					},
+				},
+			},
+			string(vmcommon.SystemAccountAddress): {
+				Address: vmcommon.SystemAccountAddress,
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte{1, 2}, Written: true},
+				},
+			},
+		},
+		Logs: []*vmcommon.LogEntry{
+			{
+				Identifier: []byte(core.BuiltInFunctionDCTTransfer),
+				Address:    alice,
+				Topics:     [][]byte{[]byte("TKN-abcdef"), {}, big.NewInt(100).Bytes(), bob},
+			},
+		},
+	}
+}
+
+func TestFormatter_FormatOutput(t *testing.T) {
+	t.Parallel()
+
+	addressConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	require.Nil(t, err)
+	f := NewFormatter(ArgsFormatter{AddressConverter: addressConverter})
+	assert.False(t, f.IsInterfaceNil())
+
+	alice, _ := addressConverter.Encode(createAddress(1))
+	bob, _ := addressConverter.Encode(createAddress(2))
+	expected := []string{
+		"return code: ok",
+		"gas remaining: 90",
+		"return data:",
+		`  "ok"`,
+		"account " + bob + ":",
+		"  transfer 1: value 0, gas limit 5, directCall",
+		"    sender: " + alice,
+		"    function: DCTTransfer",
+		`    argument 0: "TKN-abcdef"`,
+		`    argument 1: "d"`,
+		"account system account:",
+		`  storage "key" = 0x0102 (258) (written)`,
+		"log 0: DCTTransfer",
+		"  address: " + alice,
+		`  topic 0: "TKN-abcdef"`,
+		`  topic 1: ""`,
+		`  topic 2: "d"`,
+		"  topic 3: " + bob,
+	}
+	assert.Equal(t, strings.Join(expected, "\n"), f.FormatOutput(createOutput()))
+	assert.Equal(t, "nil output", f.FormatOutput(nil))
+}
+
+func TestFormatter_FormatInput(t *testing.T) {
+	t.Parallel()
+
+	f := NewFormatter(ArgsFormatter{})
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  createAddress(1),
+			Arguments:   [][]byte{[]byte("TKN-abcdef"), {0x03, 0xe8}},
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			DCTTransfers: []*vmcommon.DCTTransfer{
+				{DCTTokenName: []byte("NFT-abcdef"), DCTTokenNonce: 3, DCTValue: big.NewInt(1)},
+			},
+		},
+		RecipientAddr: createAddress(2),
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+
+	expected := []string{
+		"function: DCTTransfer",
+		"caller: 0000000000000000000000000000000000000000000000000000000000000001",
+		"recipient: 0000000000000000000000000000000000000000000000000000000000000002",
+		"call value: 0",
+		"call type: directCall",
+		"gas provided: 100",
+		"arguments:",
+		`  "TKN-abcdef"`,
+		"  0x03e8 (1000)",
+		"dct transfers:",
+		"  NFT-abcdef nonce 3 value 1",
+	}
+	assert.Equal(t, strings.Join(expected, "\n"), f.FormatInput(input))
+	assert.Equal(t, "nil input", f.FormatInput(nil))
+}
+
+func TestFormatter_Diff(t *testing.T) {
+	t.Parallel()
+
+	f := NewFormatter(ArgsFormatter{})
+	assert.Empty(t, f.Diff(createOutput(), createOutput()))
+
+	actual := createOutput()
+	actual.GasRemaining = 80
+	actual.Logs[0].Topics[2] = big.NewInt(99).Bytes()
+
+	expected := []string{
+		"  return code: ok",
+		"- gas remaining: 90",
+		"+ gas remaining: 80",
+		"  return data:",
+		`    "ok"`,
+		"...",
+		`    topic 0: "TKN-abcdef"`,
+		`    topic 1: ""`,
+		`-   topic 2: "d"`,
+		`+   topic 2: "c"`,
+		"    topic 3: 0x0000000000000000000000000000000000000000000000000000000000000002 (2)",
+	}
+	assert.Equal(t, strings.Join(expected, "\n"), f.Diff(createOutput(), actual))
+}
+
+func TestFormatter_DiffInputs(t *testing.T) {
+	t.Parallel()
+
+	f := NewFormatter(ArgsFormatter{})
+	expected := &vmcommon.ContractCallInput{Function: "first"}
+	actual := &vmcommon.ContractCallInput{Function: "second"}
+
+	diff := f.DiffInputs(expected, actual)
+	assert.True(t, strings.HasPrefix(diff, "- function: first\n+ function: second\n"))
+	assert.Empty(t, f.DiffInputs(expected, expected))
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "- a", diffLines([]string{"a"}, nil))
+	assert.Equal(t, "+ a", diffLines(nil, []string{"a"}))
+	assert.Equal(t, "  a\n- b\n+ c\n  d", diffLines([]string{"a", "b", "d"}, []string{"a", "c", "d"}))
+}
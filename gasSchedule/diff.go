@@ -0,0 +1,57 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"reflect"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// Change is one cost that differs between two gas schedules
+type Change struct {
+	Section  string
+	Key      string
+	OldValue uint64
+	NewValue uint64
+}
+
+// String returns the change as Section.Key: old -> new (+delta%)
+func (c *Change) String() string {
+	return fmt.Sprintf("%s.%s: %d -> %d (%s)", c.Section, c.Key, c.OldValue, c.NewValue, c.relativeChange())
+}
+
+func (c *Change) relativeChange() string {
+	if c.OldValue == 0 {
+		return "new"
+	}
+
+	percent := (float64(c.NewValue) - float64(c.OldValue)) * 100 / float64(c.OldValue)
+	return fmt.Sprintf("%+.2f%%", percent)
+}
+
+// Diff returns the costs that differ between the old and the new gas schedule, in declaration order,
+// so that a gas schedule change can be reviewed before it is activated
+func Diff(oldGasCost *vmcommon.GasCost, newGasCost *vmcommon.GasCost) ([]*Change, error) {
+	if oldGasCost == nil || newGasCost == nil {
+		return nil, ErrNilGasCost
+	}
+
+	newValues := ToGasMap(newGasCost)
+	changes := make([]*Change, 0)
+	forEachCost(oldGasCost, func(section string, key string, field reflect.Value) {
+		oldValue := field.Uint()
+		newValue := newValues[section][key]
+		if oldValue == newValue {
+			return
+		}
+
+		changes = append(changes, &Change{
+			Section:  section,
+			Key:      key,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	})
+
+	return changes, nil
+}
@@ -0,0 +1,32 @@
+package gasSchedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	_, err := Diff(nil, createTestGasCost())
+	assert.Equal(t, ErrNilGasCost, err)
+
+	oldGasCost := createTestGasCost()
+	changes, err := Diff(oldGasCost, createTestGasCost())
+	require.Nil(t, err)
+	assert.Empty(t, changes)
+
+	newGasCost := createTestGasCost()
+	newGasCost.BuiltInCost.DCTTransfer = 22
+	newGasCost.BaseOperationCost.StorePerByte = 0
+	changes, err = Diff(oldGasCost, newGasCost)
+	require.Nil(t, err)
+	assert.Equal(t, []*Change{
+		{Section: "BaseOperationCost", Key: "StorePerByte", OldValue: 1, NewValue: 0},
+		{Section: "BuiltInCost", Key: "DCTTransfer", OldValue: 11, NewValue: 22},
+	}, changes)
+	assert.Equal(t, "BaseOperationCost.StorePerByte: 1 -> 0 (-100.00%)", changes[0].String())
+	assert.Equal(t, "BuiltInCost.DCTTransfer: 11 -> 22 (+100.00%)", changes[1].String())
+}
@@ -0,0 +1,24 @@
+package gasSchedule
+
+import "errors"
+
+// ErrUnknownFormat signals that the format of a gas schedule file could not be determined
+var ErrUnknownFormat = errors.New("unknown gas schedule format")
+
+// ErrInvalidFile signals that a gas schedule file could not be parsed
+var ErrInvalidFile = errors.New("invalid gas schedule file")
+
+// ErrUnknownKey signals that a gas schedule section contains a key which is not a known cost
+var ErrUnknownKey = errors.New("unknown key")
+
+// ErrMissingKey signals that a known cost is not defined in the gas schedule
+var ErrMissingKey = errors.New("missing key")
+
+// ErrInvalidValue signals that a gas schedule value is not an unsigned integer
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrValueOutOfRange signals that a gas schedule value is zero, negative or does not fit 64 bits
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// ErrNilGasCost signals that a nil gas cost was provided
+var ErrNilGasCost = errors.New("nil gas cost")
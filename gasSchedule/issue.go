@@ -0,0 +1,81 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Issue is one problem found in a gas schedule file
+type Issue struct {
+	// File is the name of the gas schedule file.
+	File string
+
+	// Line is the 1-based line the issue was found at. It is 0 when the issue has no position,
+	// such as a key missing from a missing section.
+	Line int
+
+	Section string
+	Key     string
+	Err     error
+}
+
+// Error returns the issue prefixed with its position, as file:line: Section.Key: error
+func (issue *Issue) Error() string {
+	position := issue.File
+	if issue.Line > 0 {
+		position = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+	}
+
+	name := issue.Section
+	if len(issue.Key) > 0 {
+		name += "." + issue.Key
+	}
+
+	return fmt.Sprintf("%s: %s: %v", position, name, issue.Err)
+}
+
+// Unwrap returns the error of the issue
+func (issue *Issue) Unwrap() error {
+	return issue.Err
+}
+
+// ValidationError holds all the issues found in a gas schedule file, sorted by line. The issues without
+// a position are the last ones.
+type ValidationError struct {
+	Issues []*Issue
+}
+
+// Error returns all the issues, one per line
+func (ve *ValidationError) Error() string {
+	messages := make([]string, 0, len(ve.Issues))
+	for _, issue := range ve.Issues {
+		messages = append(messages, issue.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the issues, so that errors.Is matches the error of any issue
+func (ve *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(ve.Issues))
+	for _, issue := range ve.Issues {
+		errs = append(errs, issue)
+	}
+
+	return errs
+}
+
+func sortIssues(issues []*Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			// the issues without a position go last
+			return issues[j].Line == 0 || (issues[i].Line != 0 && issues[i].Line < issues[j].Line)
+		}
+		if issues[i].Section != issues[j].Section {
+			return issues[i].Section < issues[j].Section
+		}
+
+		return issues[i].Key < issues[j].Key
+	})
+}
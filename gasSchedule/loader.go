@@ -0,0 +1,219 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// Format is the encoding of a gas schedule file
+type Format string
+
+const (
+	// FormatTOML is the format of .toml gas schedule files
+	FormatTOML Format = "toml"
+
+	// FormatJSON is the format of .json gas schedule files
+	FormatJSON Format = "json"
+)
+
+// FormatFromFileName returns the gas schedule format matching the file extension
+func FormatFromFileName(fileName string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".toml":
+		return FormatTOML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w for file %s", ErrUnknownFormat, fileName)
+	}
+}
+
+// LoadFile reads a gas schedule file, in the format given by its extension
+func LoadFile(path string) (*vmcommon.GasCost, error) {
+	format, err := FormatFromFileName(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(data, format, path)
+}
+
+// Load parses and validates a gas schedule. Only the sections of vmcommon.GasCost are read, the other sections
+// of the file are ignored as they configure the virtual machines. All the unknown keys, missing keys and invalid
+// values are returned together in a *ValidationError. The file name is only used to report the issues.
+func Load(data []byte, format Format, fileName string) (*vmcommon.GasCost, error) {
+	schedule, err := parse(data, format, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	gasCost := &vmcommon.GasCost{}
+	issues := fillGasCost(gasCost, schedule, fileName)
+	if len(issues) > 0 {
+		sortIssues(issues)
+		return nil, &ValidationError{Issues: issues}
+	}
+
+	return gasCost, nil
+}
+
+func parse(data []byte, format Format, fileName string) (*parsedSchedule, error) {
+	switch format {
+	case FormatTOML:
+		schedule, err := parseTOML(data)
+		if err != nil {
+			// the toml errors already hold the (line, column) position
+			return nil, fmt.Errorf("%w %s: %v", ErrInvalidFile, fileName, err)
+		}
+		return schedule, nil
+	case FormatJSON:
+		schedule, err := parseJSON(data)
+		if err != nil {
+			line := jsonErrorLine(data, err)
+			if line > 0 {
+				return nil, fmt.Errorf("%w %s:%d: %v", ErrInvalidFile, fileName, line, err)
+			}
+			return nil, fmt.Errorf("%w %s: %v", ErrInvalidFile, fileName, err)
+		}
+		return schedule, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+func fillGasCost(gasCost *vmcommon.GasCost, schedule *parsedSchedule, fileName string) []*Issue {
+	issues := make([]*Issue, 0)
+	definedKeys := make(map[string]struct{})
+	for _, parsed := range schedule.values {
+		issue := &Issue{
+			File:    fileName,
+			Line:    parsed.line,
+			Section: parsed.section,
+			Key:     parsed.key,
+		}
+
+		if len(parsed.key) == 0 {
+			issue.Err = fmt.Errorf("%w, the section must be a table", ErrInvalidValue)
+			issues = append(issues, issue)
+			continue
+		}
+
+		field := costField(gasCost, parsed.section, parsed.key)
+		if !field.IsValid() {
+			issue.Err = ErrUnknownKey
+			issues = append(issues, issue)
+			continue
+		}
+		definedKeys[parsed.section+"."+parsed.key] = struct{}{}
+
+		value, err := toUint64(parsed.value)
+		if err != nil {
+			issue.Err = err
+			issues = append(issues, issue)
+			continue
+		}
+
+		field.SetUint(value)
+	}
+
+	forEachCost(gasCost, func(section string, key string, _ reflect.Value) {
+		_, found := definedKeys[section+"."+key]
+		if found {
+			return
+		}
+
+		issues = append(issues, &Issue{
+			File:    fileName,
+			Line:    schedule.sectionLines[section],
+			Section: section,
+			Key:     key,
+			Err:     ErrMissingKey,
+		})
+	})
+
+	return issues
+}
+
+func toUint64(value interface{}) (uint64, error) {
+	var number *big.Int
+	switch v := value.(type) {
+	case int64:
+		number = big.NewInt(v)
+	case uint64:
+		number = big.NewInt(0).SetUint64(v)
+	case fmt.Stringer:
+		// json.Number values
+		var ok bool
+		number, ok = big.NewInt(0).SetString(v.String(), 10)
+		if !ok {
+			return 0, fmt.Errorf("%w %v, expected an unsigned integer", ErrInvalidValue, v)
+		}
+	default:
+		return 0, fmt.Errorf("%w %v, expected an unsigned integer", ErrInvalidValue, v)
+	}
+
+	if number.Sign() <= 0 || !number.IsUint64() {
+		return 0, fmt.Errorf("%w %s, expected a value between 1 and %d", ErrValueOutOfRange, number.String(), uint64(1<<64-1))
+	}
+
+	return number.Uint64(), nil
+}
+
+// ToGasMap converts the gas cost to the gas schedule map expected by GasScheduleChange
+func ToGasMap(gasCost *vmcommon.GasCost) map[string]map[string]uint64 {
+	gasMap := make(map[string]map[string]uint64)
+	if gasCost == nil {
+		return gasMap
+	}
+
+	forEachCost(gasCost, func(section string, key string, field reflect.Value) {
+		if gasMap[section] == nil {
+			gasMap[section] = make(map[string]uint64)
+		}
+		gasMap[section][key] = field.Uint()
+	})
+
+	return gasMap
+}
+
+// forEachCost calls the handler for every cost of the gas cost, in declaration order
+func forEachCost(gasCost *vmcommon.GasCost, handler func(section string, key string, field reflect.Value)) {
+	gasCostValue := reflect.ValueOf(gasCost).Elem()
+	for i := 0; i < gasCostValue.NumField(); i++ {
+		section := gasCostValue.Type().Field(i).Name
+		sectionValue := gasCostValue.Field(i)
+		for j := 0; j < sectionValue.NumField(); j++ {
+			handler(section, sectionValue.Type().Field(j).Name, sectionValue.Field(j))
+		}
+	}
+}
+
+func costField(gasCost *vmcommon.GasCost, section string, key string) reflect.Value {
+	sectionValue := reflect.ValueOf(gasCost).Elem().FieldByName(section)
+	if !sectionValue.IsValid() || sectionValue.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	field := sectionValue.FieldByName(key)
+	if !field.IsValid() || field.Kind() != reflect.Uint64 {
+		return reflect.Value{}
+	}
+
+	return field
+}
+
+func isKnownSection(section string) bool {
+	field, found := reflect.TypeOf(vmcommon.GasCost{}).FieldByName(section)
+	return found && field.Type.Kind() == reflect.Struct
+}
@@ -0,0 +1,199 @@
+package gasSchedule
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func createTestGasCost() *vmcommon.GasCost {
+	gasCost := &vmcommon.GasCost{}
+	value := uint64(1)
+	forEachCost(gasCost, func(_ string, _ string, field reflect.Value) {
+		field.SetUint(value)
+		value++
+	})
+
+	return gasCost
+}
+
+func createTOMLSchedule(gasCost *vmcommon.GasCost) string {
+	builder := &strings.Builder{}
+	builder.WriteString("[WASMOpcodeCost]\n    Unreachable = 5\n")
+	lastSection := ""
+	forEachCost(gasCost, func(section string, key string, field reflect.Value) {
+		if section != lastSection {
+			_, _ = fmt.Fprintf(builder, "\n[%s]\n", section)
+			lastSection = section
+		}
+		_, _ = fmt.Fprintf(builder, "    %s = %d\n", key, field.Uint())
+	})
+
+	return builder.String()
+}
+
+func createJSONSchedule(gasCost *vmcommon.GasCost) string {
+	builder := &strings.Builder{}
+	builder.WriteString("{\n  \"WASMOpcodeCost\": {\"Unreachable\": 5}")
+	lastSection := ""
+	forEachCost(gasCost, func(section string, key string, field reflect.Value) {
+		if section != lastSection {
+			if len(lastSection) > 0 {
+				builder.WriteString("\n  }")
+			}
+			_, _ = fmt.Fprintf(builder, ",\n  \"%s\": {\n", section)
+			lastSection = section
+		} else {
+			builder.WriteString(",\n")
+		}
+		_, _ = fmt.Fprintf(builder, "    \"%s\": %d", key, field.Uint())
+	})
+	builder.WriteString("\n  }\n}\n")
+
+	return builder.String()
+}
+
+func requireIssues(t *testing.T, err error) []*Issue {
+	validationErr := &ValidationError{}
+	require.True(t, errors.As(err, &validationErr), err)
+
+	return validationErr.Issues
+}
+
+func TestFormatFromFileName(t *testing.T) {
+	t.Parallel()
+
+	format, err := FormatFromFileName("gasScheduleV1.TOML")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatTOML, format)
+
+	format, err = FormatFromFileName("dir/gasSchedule.json")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = FormatFromFileName("gasSchedule.yaml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestLoad_ValidSchedules(t *testing.T) {
+	t.Parallel()
+
+	expectedGasCost := createTestGasCost()
+	gasCost, err := Load([]byte(createTOMLSchedule(expectedGasCost)), FormatTOML, "gas.toml")
+	require.Nil(t, err)
+	assert.Equal(t, expectedGasCost, gasCost)
+
+	gasCost, err = Load([]byte(createJSONSchedule(expectedGasCost)), FormatJSON, "gas.json")
+	require.Nil(t, err)
+	assert.Equal(t, expectedGasCost, gasCost)
+}
+
+func TestLoad_TOMLIssues(t *testing.T) {
+	t.Parallel()
+
+	schedule := createTOMLSchedule(createTestGasCost())
+	schedule = strings.Replace(schedule, "DCTTransfer = ", "DCTTransfr = ", 1)
+	schedule = strings.Replace(schedule, "    DCTBurn = ", "    #DCTBurn = ", 1)
+	schedule = strings.Replace(schedule, "SaveUserName = 9", "SaveUserName = -9", 1)
+	schedule = strings.Replace(schedule, "SaveKeyValue = 10", "SaveKeyValue = \"ten\"", 1)
+	schedule = strings.Replace(schedule, "StorePerByte = 1\n", "StorePerByte = 0\n", 1)
+
+	_, err := Load([]byte(schedule), FormatTOML, "gas.toml")
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.ErrorIs(t, err, ErrMissingKey)
+	assert.ErrorIs(t, err, ErrValueOutOfRange)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	issues := requireIssues(t, err)
+	require.Equal(t, 6, len(issues))
+	assert.Equal(t, "gas.toml:5: BaseOperationCost.StorePerByte: value out of range 0, expected a value between 1 and 18446744073709551615", issues[0].Error())
+	assert.Equal(t, "gas.toml:12: BuiltInCost.DCTBurn: missing key", issues[1].Error())
+	assert.Equal(t, "gas.toml:12: BuiltInCost.DCTTransfer: missing key", issues[2].Error())
+	assert.Equal(t, 15, issues[3].Line)
+	assert.Equal(t, "SaveUserName", issues[3].Key)
+	assert.ErrorIs(t, issues[3], ErrValueOutOfRange)
+	assert.Equal(t, 16, issues[4].Line)
+	assert.ErrorIs(t, issues[4], ErrInvalidValue)
+	assert.Equal(t, "gas.toml:17: BuiltInCost.DCTTransfr: unknown key", issues[5].Error())
+}
+
+func TestLoad_JSONIssues(t *testing.T) {
+	t.Parallel()
+
+	schedule := createJSONSchedule(createTestGasCost())
+	schedule = strings.Replace(schedule, "\"DCTTransfer\"", "\"DCTTransfr\"", 1)
+	schedule = strings.Replace(schedule, "\"DCTBurn\": 12", "\"DCTBurn\": 1.5", 1)
+	schedule = strings.Replace(schedule, "\"DCTLocalMint\": 13", "\"DCTLocalMint\": 18446744073709551616", 1)
+
+	_, err := Load([]byte(schedule), FormatJSON, "gas.json")
+	issues := requireIssues(t, err)
+	require.Equal(t, 4, len(issues))
+	assert.Equal(t, "gas.json:11: BuiltInCost.DCTTransfer: missing key", issues[0].Error())
+	assert.Equal(t, "gas.json:16: BuiltInCost.DCTTransfr: unknown key", issues[1].Error())
+	assert.Equal(t, 17, issues[2].Line)
+	assert.ErrorIs(t, issues[2], ErrInvalidValue)
+	assert.Equal(t, 18, issues[3].Line)
+	assert.ErrorIs(t, issues[3], ErrValueOutOfRange)
+}
+
+func TestLoad_MissingSection(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load([]byte("{\"BaseOperationCost\": 5}"), FormatJSON, "gas.json")
+	issues := requireIssues(t, err)
+	assert.Equal(t, "gas.json:1: BaseOperationCost: invalid value, the section must be a table", issues[0].Error())
+	for _, issue := range issues[1:] {
+		assert.ErrorIs(t, issue, ErrMissingKey)
+	}
+	assert.Equal(t, "gas.json: BuiltInCost.ChangeOwnerAddress: missing key", issues[len(issues)-20].Error())
+}
+
+func TestLoad_InvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load([]byte("[BuiltInCost\nDCTTransfer = 1"), FormatTOML, "gas.toml")
+	assert.ErrorIs(t, err, ErrInvalidFile)
+	assert.Contains(t, err.Error(), "gas.toml: (1, ")
+
+	_, err = Load([]byte("{\n\"BuiltInCost\": {\n\"DCTTransfer\": 1,,\n}}"), FormatJSON, "gas.json")
+	assert.ErrorIs(t, err, ErrInvalidFile)
+	assert.Contains(t, err.Error(), "gas.json:3: ")
+
+	_, err = Load([]byte("{}"), Format("yaml"), "gas.yaml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	expectedGasCost := createTestGasCost()
+	path := filepath.Join(t.TempDir(), "gasSchedule.toml")
+	require.Nil(t, os.WriteFile(path, []byte(createTOMLSchedule(expectedGasCost)), 0644))
+
+	gasCost, err := LoadFile(path)
+	require.Nil(t, err)
+	assert.Equal(t, expectedGasCost, gasCost)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestToGasMap(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, ToGasMap(nil))
+
+	gasMap := ToGasMap(createTestGasCost())
+	assert.Equal(t, 2, len(gasMap))
+	assert.Equal(t, uint64(1), gasMap["BaseOperationCost"]["StorePerByte"])
+	assert.Equal(t, uint64(26), gasMap["BuiltInCost"]["TrieStorePerNode"])
+}
@@ -0,0 +1,211 @@
+package gasSchedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml"
+)
+
+// parsedValue is one key of a known section, together with the line it was defined at
+type parsedValue struct {
+	section string
+	key     string
+	value   interface{}
+	line    int
+}
+
+// parsedSchedule holds the known sections of a gas schedule file, in file order
+type parsedSchedule struct {
+	sectionLines map[string]int
+	values       []*parsedValue
+}
+
+func newParsedSchedule() *parsedSchedule {
+	return &parsedSchedule{
+		sectionLines: make(map[string]int),
+		values:       make([]*parsedValue, 0),
+	}
+}
+
+func parseTOML(data []byte) (*parsedSchedule, error) {
+	tree, err := toml.LoadBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := newParsedSchedule()
+	for _, section := range tree.Keys() {
+		if !isKnownSection(section) {
+			continue
+		}
+
+		sectionPath := []string{section}
+		schedule.sectionLines[section] = tree.GetPositionPath(sectionPath).Line
+		sectionTree, ok := tree.GetPath(sectionPath).(*toml.Tree)
+		if !ok {
+			schedule.values = append(schedule.values, &parsedValue{
+				section: section,
+				line:    schedule.sectionLines[section],
+			})
+			continue
+		}
+
+		for _, key := range sectionTree.Keys() {
+			keyPath := []string{key}
+			schedule.values = append(schedule.values, &parsedValue{
+				section: section,
+				key:     key,
+				value:   sectionTree.GetPath(keyPath),
+				line:    sectionTree.GetPositionPath(keyPath).Line,
+			})
+		}
+	}
+
+	return schedule, nil
+}
+
+func parseJSON(data []byte) (*parsedSchedule, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	schedule := newParsedSchedule()
+	err := expectDelimiter(decoder, '{')
+	if err != nil {
+		return nil, err
+	}
+
+	for decoder.More() {
+		section, err := readKey(decoder)
+		if err != nil {
+			return nil, err
+		}
+		sectionLine := lineAt(data, decoder.InputOffset())
+
+		if !isKnownSection(section) || !nextIsObject(data, decoder.InputOffset()) {
+			var value interface{}
+			err = decoder.Decode(&value)
+			if err != nil {
+				return nil, err
+			}
+			if isKnownSection(section) {
+				schedule.sectionLines[section] = sectionLine
+				schedule.values = append(schedule.values, &parsedValue{section: section, line: sectionLine})
+			}
+			continue
+		}
+
+		schedule.sectionLines[section] = sectionLine
+		err = parseJSONSection(data, decoder, schedule, section)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = expectDelimiter(decoder, '}')
+	if err != nil {
+		return nil, err
+	}
+	_, err = decoder.Token()
+	if err != io.EOF {
+		return nil, errors.New("unexpected data after the top-level object")
+	}
+
+	return schedule, nil
+}
+
+func parseJSONSection(data []byte, decoder *json.Decoder, schedule *parsedSchedule, section string) error {
+	err := expectDelimiter(decoder, '{')
+	if err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		key, err := readKey(decoder)
+		if err != nil {
+			return err
+		}
+		line := lineAt(data, decoder.InputOffset())
+
+		var value interface{}
+		err = decoder.Decode(&value)
+		if err != nil {
+			return err
+		}
+
+		schedule.values = append(schedule.values, &parsedValue{
+			section: section,
+			key:     key,
+			value:   value,
+			line:    line,
+		})
+	}
+
+	return expectDelimiter(decoder, '}')
+}
+
+func readKey(decoder *json.Decoder) (string, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := token.(string)
+	if !ok {
+		return "", fmt.Errorf("expected an object key, got %v", token)
+	}
+
+	return key, nil
+}
+
+func expectDelimiter(decoder *json.Decoder, delimiter json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delimiter {
+		return fmt.Errorf("expected %v, got %v", delimiter, token)
+	}
+
+	return nil
+}
+
+// nextIsObject returns true if the first value after the offset, skipping the key separator, is an object
+func nextIsObject(data []byte, offset int64) bool {
+	for _, b := range data[offset:] {
+		switch b {
+		case ' ', '\t', '\r', '\n', ':':
+			continue
+		default:
+			return b == '{'
+		}
+	}
+
+	return false
+}
+
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// jsonErrorLine returns the line of a JSON syntax error, or 0 if the error has no position
+func jsonErrorLine(data []byte, err error) int {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return lineAt(data, syntaxErr.Offset)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return lineAt(data, typeErr.Offset)
+	}
+
+	return 0
+}
@@ -0,0 +1,27 @@
+package vmcommon
+
+// GasCharge is one gas amount charged by a built-in function
+type GasCharge struct {
+	// Category is the gas schedule section of the cost parameter: BuiltInCost or BaseOperationCost.
+	Category string
+
+	// CostParameter is the name of the gas schedule entry, such as DCTTransfer or StorePerByte.
+	CostParameter string
+
+	// Units is how many times the cost parameter was charged, such as the number of stored bytes.
+	Units uint64
+
+	// Amount is the charged gas, equal to Units multiplied by the cost parameter value.
+	Amount uint64
+}
+
+// GasTracer receives the gas charges of every successful built-in function call
+type GasTracer interface {
+	TraceGasCharges(vmInput *ContractCallInput, charges []*GasCharge)
+	IsInterfaceNil() bool
+}
+
+// AcceptGasTracer defines the functionality of built-in functions that can report their gas charges
+type AcceptGasTracer interface {
+	SetGasTracer(gasTracer GasTracer) error
+}
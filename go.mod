@@ -4,6 +4,7 @@ go 1.20
 
 require (
 	github.com/mitchellh/mapstructure v1.5.0
+	github.com/pelletier/go-toml v1.9.5
 	github.com/stretchr/testify v1.8.4
 	github.com/subrahamanyam341/andes-core-16 v0.0.0-20240129064818-0535b8677d71
 	github.com/subrahamanyam341/andes-logger-123 v0.0.0-20240130124150-92c2af9c33e8
@@ -16,7 +17,6 @@ require (
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/mr-tron/base58 v1.2.0 // indirect
-	github.com/pelletier/go-toml v1.9.5 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/subrahamanyam341/andes-core-go v0.0.0-20240122043130-cf3213b57fdc // indirect
 	golang.org/x/sys v0.16.0 // indirect
@@ -0,0 +1,261 @@
+package inMemoryState
+
+import (
+	"crypto/sha256"
+	"sort"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.AccountsAdapter = (*AccountsAdapter)(nil)
+var _ vmcommon.AccountsIterator = (*AccountsAdapter)(nil)
+
+// journalEntry holds the state of an account before it was saved or removed. A nil previous account
+// means that the account did not exist.
+type journalEntry struct {
+	address  string
+	previous *UserAccount
+
+	// codeHash is not empty if the save wrote the code stored under it. previousCode is the code stored
+	// before under the same hash, if codeExisted is set.
+	codeHash     string
+	previousCode []byte
+	codeExisted  bool
+}
+
+// AccountsAdapter is an in-memory implementation of the vmcommon.AccountsAdapter interface.
+// Accounts are handed out as copies: changes become visible only after SaveAccount is called,
+// the same way the node's accounts adapter behaves. Every save and remove, together with the code
+// written by a save, is journalized so that the state can be reverted to any snapshot obtained
+// through JournalLen.
+type AccountsAdapter struct {
+	mutState sync.RWMutex
+	accounts map[string]*UserAccount
+	codes    map[string][]byte
+	journal  []*journalEntry
+}
+
+// NewAccountsAdapter creates an empty in-memory accounts adapter
+func NewAccountsAdapter() *AccountsAdapter {
+	return &AccountsAdapter{
+		accounts: make(map[string]*UserAccount),
+		codes:    make(map[string][]byte),
+		journal:  make([]*journalEntry, 0),
+	}
+}
+
+// GetExistingAccount returns a copy of an existing account or ErrAccountNotFound
+func (adb *AccountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return nil, ErrAccountNotFound
+	}
+
+	return account.Clone(), nil
+}
+
+// LoadAccount returns a copy of an existing account or a new empty account if it does not exist
+func (adb *AccountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return NewUserAccount(address)
+	}
+
+	return account.Clone(), nil
+}
+
+// SaveAccount stores a copy of the provided account, journalizing the previous state
+func (adb *AccountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccount
+	}
+
+	userAccount, ok := account.(*UserAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	adb.mutState.Lock()
+	defer adb.mutState.Unlock()
+
+	address := string(userAccount.AddressBytes())
+	entry := adb.journalizeAccount(address)
+	adb.accounts[address] = userAccount.Clone()
+	if len(userAccount.GetCodeHash()) > 0 {
+		codeHash := string(userAccount.GetCodeHash())
+		entry.codeHash = codeHash
+		entry.previousCode, entry.codeExisted = adb.codes[codeHash]
+		adb.codes[codeHash] = copyBytes(userAccount.GetCode())
+	}
+
+	return nil
+}
+
+// RemoveAccount removes the account, journalizing its previous state
+func (adb *AccountsAdapter) RemoveAccount(address []byte) error {
+	if len(address) == 0 {
+		return ErrNilAddress
+	}
+
+	adb.mutState.Lock()
+	defer adb.mutState.Unlock()
+
+	_, found := adb.accounts[string(address)]
+	if !found {
+		return nil
+	}
+
+	adb.journalizeAccount(string(address))
+	delete(adb.accounts, string(address))
+
+	return nil
+}
+
+func (adb *AccountsAdapter) journalizeAccount(address string) *journalEntry {
+	entry := &journalEntry{address: address}
+	previous, found := adb.accounts[address]
+	if found {
+		entry.previous = previous
+	}
+
+	adb.journal = append(adb.journal, entry)
+
+	return entry
+}
+
+// Commit clears the journal and returns the current root hash
+func (adb *AccountsAdapter) Commit() ([]byte, error) {
+	adb.mutState.Lock()
+	defer adb.mutState.Unlock()
+
+	adb.journal = make([]*journalEntry, 0)
+
+	return adb.computeRootHash(), nil
+}
+
+// JournalLen returns the number of journal entries, to be used as a snapshot identifier
+func (adb *AccountsAdapter) JournalLen() int {
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	return len(adb.journal)
+}
+
+// RevertToSnapshot undoes all the changes journalized after the provided snapshot
+func (adb *AccountsAdapter) RevertToSnapshot(snapshot int) error {
+	adb.mutState.Lock()
+	defer adb.mutState.Unlock()
+
+	if snapshot < 0 || snapshot > len(adb.journal) {
+		return ErrInvalidSnapshot
+	}
+
+	for i := len(adb.journal) - 1; i >= snapshot; i-- {
+		entry := adb.journal[i]
+		adb.revertCode(entry)
+		if entry.previous == nil {
+			delete(adb.accounts, entry.address)
+			continue
+		}
+
+		adb.accounts[entry.address] = entry.previous
+	}
+	adb.journal = adb.journal[:snapshot]
+
+	return nil
+}
+
+func (adb *AccountsAdapter) revertCode(entry *journalEntry) {
+	if len(entry.codeHash) == 0 {
+		return
+	}
+	if !entry.codeExisted {
+		delete(adb.codes, entry.codeHash)
+		return
+	}
+
+	adb.codes[entry.codeHash] = entry.previousCode
+}
+
+// GetCode returns the code saved under the provided code hash
+func (adb *AccountsAdapter) GetCode(codeHash []byte) []byte {
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	return copyBytes(adb.codes[string(codeHash)])
+}
+
+// RootHash returns a deterministic hash over all the accounts, in address order
+func (adb *AccountsAdapter) RootHash() ([]byte, error) {
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	return adb.computeRootHash(), nil
+}
+
+func (adb *AccountsAdapter) computeRootHash() []byte {
+	hasher := sha256.New()
+	for _, address := range adb.sortedAddresses() {
+		writeLengthPrefixed(hasher, adb.accounts[address].Hash())
+	}
+
+	return hasher.Sum(nil)
+}
+
+// GetAllState returns a copy of all the key-value pairs of the account data trie
+func (adb *AccountsAdapter) GetAllState(address []byte) (map[string][]byte, error) {
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return nil, ErrAccountNotFound
+	}
+
+	return account.DataTrie().GetAllState(), nil
+}
+
+// Addresses returns the addresses of all the existing accounts, sorted
+func (adb *AccountsAdapter) Addresses() [][]byte {
+	adb.mutState.RLock()
+	defer adb.mutState.RUnlock()
+
+	sortedAddresses := adb.sortedAddresses()
+	addresses := make([][]byte, 0, len(sortedAddresses))
+	for _, address := range sortedAddresses {
+		addresses = append(addresses, []byte(address))
+	}
+
+	return addresses
+}
+
+func (adb *AccountsAdapter) sortedAddresses() []string {
+	addresses := make([]string, 0, len(adb.accounts))
+	for address := range adb.accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adb *AccountsAdapter) IsInterfaceNil() bool {
+	return adb == nil
+}
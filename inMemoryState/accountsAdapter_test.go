@@ -0,0 +1,144 @@
+package inMemoryState
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func loadUserAccount(t *testing.T, adb *AccountsAdapter, address []byte) *UserAccount {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(*UserAccount)
+}
+
+func TestAccountsAdapter_LoadAccountReturnsCopies(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	account := loadUserAccount(t, adb, []byte("address"))
+	_ = account.AddToBalance(big.NewInt(10))
+
+	_, err := adb.GetExistingAccount([]byte("address"))
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	require.Nil(t, adb.SaveAccount(account))
+	_ = account.AddToBalance(big.NewInt(10))
+
+	existing, err := adb.GetExistingAccount([]byte("address"))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), existing.(*UserAccount).GetBalance())
+}
+
+func TestAccountsAdapter_SaveAccountErrors(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	assert.Equal(t, ErrNilAccount, adb.SaveAccount(nil))
+	assert.Equal(t, ErrWrongTypeAssertion, adb.SaveAccount(mock.NewUserAccount([]byte("address"))))
+
+	_, err := adb.LoadAccount(nil)
+	assert.Equal(t, ErrNilAddress, err)
+}
+
+func TestAccountsAdapter_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	account := loadUserAccount(t, adb, []byte("address1"))
+	_ = account.AddToBalance(big.NewInt(10))
+	_ = adb.SaveAccount(account)
+	rootHashBefore, _ := adb.RootHash()
+
+	snapshot := adb.JournalLen()
+	assert.Equal(t, 1, snapshot)
+
+	account = loadUserAccount(t, adb, []byte("address1"))
+	_ = account.AddToBalance(big.NewInt(10))
+	_ = adb.SaveAccount(account)
+	newAccount := loadUserAccount(t, adb, []byte("address2"))
+	_ = adb.SaveAccount(newAccount)
+	_ = adb.RemoveAccount([]byte("address1"))
+	assert.Equal(t, 4, adb.JournalLen())
+
+	assert.Equal(t, ErrInvalidSnapshot, adb.RevertToSnapshot(5))
+	assert.Equal(t, ErrInvalidSnapshot, adb.RevertToSnapshot(-1))
+
+	err := adb.RevertToSnapshot(snapshot)
+	require.Nil(t, err)
+	assert.Equal(t, snapshot, adb.JournalLen())
+
+	rootHashAfter, _ := adb.RootHash()
+	assert.Equal(t, rootHashBefore, rootHashAfter)
+	assert.Equal(t, [][]byte{[]byte("address1")}, adb.Addresses())
+	existing, _ := adb.GetExistingAccount([]byte("address1"))
+	assert.Equal(t, big.NewInt(10), existing.(*UserAccount).GetBalance())
+}
+
+func TestAccountsAdapter_RevertToSnapshotRestoresCode(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	account := loadUserAccount(t, adb, []byte("address1"))
+	account.SetCode([]byte("code1"))
+	_ = adb.SaveAccount(account)
+	code1Hash := account.GetCodeHash()
+
+	snapshot := adb.JournalLen()
+
+	account = loadUserAccount(t, adb, []byte("address1"))
+	account.SetCode([]byte("code2"))
+	_ = adb.SaveAccount(account)
+	code2Hash := account.GetCodeHash()
+	newAccount := loadUserAccount(t, adb, []byte("address2"))
+	newAccount.SetCode([]byte("code1"))
+	_ = adb.SaveAccount(newAccount)
+	assert.Equal(t, []byte("code2"), adb.GetCode(code2Hash))
+
+	err := adb.RevertToSnapshot(snapshot)
+	require.Nil(t, err)
+	assert.Nil(t, adb.GetCode(code2Hash))
+	assert.Equal(t, []byte("code1"), adb.GetCode(code1Hash))
+
+	err = adb.RevertToSnapshot(0)
+	require.Nil(t, err)
+	assert.Nil(t, adb.GetCode(code1Hash))
+	assert.Empty(t, adb.Addresses())
+}
+
+func TestAccountsAdapter_CommitClearsJournal(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	account := loadUserAccount(t, adb, []byte("address"))
+	account.SetCode([]byte("code"))
+	_ = adb.SaveAccount(account)
+
+	rootHash, err := adb.Commit()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, adb.JournalLen())
+
+	currentRootHash, _ := adb.RootHash()
+	assert.Equal(t, rootHash, currentRootHash)
+	assert.Equal(t, []byte("code"), adb.GetCode(account.GetCodeHash()))
+}
+
+func TestAccountsAdapter_RootHashDoesNotDependOnInsertionOrder(t *testing.T) {
+	t.Parallel()
+
+	adb1 := NewAccountsAdapter()
+	_ = adb1.SaveAccount(loadUserAccount(t, adb1, []byte("address1")))
+	_ = adb1.SaveAccount(loadUserAccount(t, adb1, []byte("address2")))
+
+	adb2 := NewAccountsAdapter()
+	_ = adb2.SaveAccount(loadUserAccount(t, adb2, []byte("address2")))
+	_ = adb2.SaveAccount(loadUserAccount(t, adb2, []byte("address1")))
+
+	rootHash1, _ := adb1.RootHash()
+	rootHash2, _ := adb2.RootHash()
+	assert.Equal(t, rootHash1, rootHash2)
+}
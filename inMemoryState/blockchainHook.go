@@ -0,0 +1,515 @@
+package inMemoryState
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.BlockchainHook = (*BlockchainHook)(nil)
+
+const dctKeyPrefix = core.ProtectedKeyPrefix + core.DCTKeyIdentifier
+
+// BlockInfo holds the block related values exposed by the blockchain hook
+type BlockInfo struct {
+	Nonce      uint64
+	Round      uint64
+	TimeStamp  uint64
+	RandomSeed []byte
+	Epoch      uint32
+	RootHash   []byte
+}
+
+// ArgsBlockchainHook defines the arguments needed to create an in-memory blockchain hook
+type ArgsBlockchainHook struct {
+	Accounts              *AccountsAdapter
+	BuiltInFunctions      vmcommon.BuiltInFunctionContainer
+	ShardCoordinator      vmcommon.Coordinator
+	NFTStorageHandler     vmcommon.SimpleDCTNFTStorageHandler
+	GlobalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+}
+
+// BlockchainHook is an in-memory implementation of the vmcommon.BlockchainHook interface that executes
+// built-in functions directly against an in-memory accounts adapter. Smart contract execution is not supported.
+type BlockchainHook struct {
+	accounts              *AccountsAdapter
+	builtInFunctions      vmcommon.BuiltInFunctionContainer
+	shardCoordinator      vmcommon.Coordinator
+	nftStorageHandler     vmcommon.SimpleDCTNFTStorageHandler
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+
+	mutBlockInfo  sync.RWMutex
+	lastBlock     BlockInfo
+	currentBlock  BlockInfo
+	blockHashes   map[uint64][]byte
+	mutCompiled   sync.RWMutex
+	compiledCodes map[string][]byte
+	mutProcessing sync.Mutex
+}
+
+// NewBlockchainHook creates a new in-memory blockchain hook
+func NewBlockchainHook(args ArgsBlockchainHook) (*BlockchainHook, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.BuiltInFunctions) {
+		return nil, ErrNilBuiltInFunctionContainer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.NFTStorageHandler) {
+		return nil, ErrNilNFTStorageHandler
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
+		return nil, ErrNilGlobalSettingsHandler
+	}
+
+	return &BlockchainHook{
+		accounts:              args.Accounts,
+		builtInFunctions:      args.BuiltInFunctions,
+		shardCoordinator:      args.ShardCoordinator,
+		nftStorageHandler:     args.NFTStorageHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		blockHashes:           make(map[uint64][]byte),
+		compiledCodes:         make(map[string][]byte),
+	}, nil
+}
+
+// SetCurrentBlockInfo sets the values returned by the Current* methods
+func (bh *BlockchainHook) SetCurrentBlockInfo(blockInfo BlockInfo) {
+	bh.mutBlockInfo.Lock()
+	bh.currentBlock = blockInfo
+	bh.mutBlockInfo.Unlock()
+}
+
+// SetLastBlockInfo sets the values returned by the Last* methods
+func (bh *BlockchainHook) SetLastBlockInfo(blockInfo BlockInfo) {
+	bh.mutBlockInfo.Lock()
+	bh.lastBlock = blockInfo
+	bh.mutBlockInfo.Unlock()
+}
+
+// SetBlockhash sets the hash returned by GetBlockhash for the given nonce
+func (bh *BlockchainHook) SetBlockhash(nonce uint64, hash []byte) {
+	bh.mutBlockInfo.Lock()
+	bh.blockHashes[nonce] = copyBytes(hash)
+	bh.mutBlockInfo.Unlock()
+}
+
+// NewAddress computes a deterministic smart contract address from the creator address and nonce.
+// The address keeps the smart contract prefix, the VM type and the shard identifier of the creator.
+func (bh *BlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	addressLength := len(creatorAddress)
+	if addressLength <= vmcommon.NumInitCharactersForScAddress+vmcommon.ShardIdentiferLen {
+		return nil, ErrInvalidAddressLength
+	}
+	if addressLength > vmcommon.NumInitCharactersForScAddress+vmcommon.ShardIdentiferLen+sha256.Size {
+		return nil, ErrInvalidAddressLength
+	}
+	if len(vmType) != vmcommon.VMTypeLen {
+		return nil, vmcommon.ErrInvalidVMType
+	}
+
+	nonceBuff := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonceBuff, creatorNonce)
+	hash := sha256.Sum256(append(copyBytes(creatorAddress), nonceBuff...))
+
+	newAddress := make([]byte, 0, addressLength)
+	newAddress = append(newAddress, make([]byte, vmcommon.NumInitCharactersForScAddress-vmcommon.VMTypeLen)...)
+	newAddress = append(newAddress, vmType...)
+
+	numHashBytes := addressLength - vmcommon.NumInitCharactersForScAddress - vmcommon.ShardIdentiferLen
+	newAddress = append(newAddress, hash[:numHashBytes]...)
+	newAddress = append(newAddress, creatorAddress[addressLength-vmcommon.ShardIdentiferLen:]...)
+
+	return newAddress, nil
+}
+
+// GetStorageData returns the value stored under the given key or an empty value if the account or the key is missing
+func (bh *BlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	account, err := bh.accounts.GetExistingAccount(accountAddress)
+	if err != nil {
+		return make([]byte, 0), 0, nil
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, 0, ErrWrongTypeAssertion
+	}
+
+	value, depth, err := userAccount.AccountDataHandler().RetrieveValue(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	if value == nil {
+		value = make([]byte, 0)
+	}
+
+	return value, depth, nil
+}
+
+// GetBlockhash returns the block hash previously set for the given nonce
+func (bh *BlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	hash, found := bh.blockHashes[nonce]
+	if !found {
+		return nil, ErrBlockHashNotFound
+	}
+
+	return copyBytes(hash), nil
+}
+
+// LastNonce returns the nonce of the last block
+func (bh *BlockchainHook) LastNonce() uint64 {
+	return bh.getLastBlock().Nonce
+}
+
+// LastRound returns the round of the last block
+func (bh *BlockchainHook) LastRound() uint64 {
+	return bh.getLastBlock().Round
+}
+
+// LastTimeStamp returns the timestamp of the last block
+func (bh *BlockchainHook) LastTimeStamp() uint64 {
+	return bh.getLastBlock().TimeStamp
+}
+
+// LastRandomSeed returns the random seed of the last block
+func (bh *BlockchainHook) LastRandomSeed() []byte {
+	return bh.getLastBlock().RandomSeed
+}
+
+// LastEpoch returns the epoch of the last block
+func (bh *BlockchainHook) LastEpoch() uint32 {
+	return bh.getLastBlock().Epoch
+}
+
+// GetStateRootHash returns the state root hash of the last block
+func (bh *BlockchainHook) GetStateRootHash() []byte {
+	return bh.getLastBlock().RootHash
+}
+
+// CurrentNonce returns the nonce of the current block
+func (bh *BlockchainHook) CurrentNonce() uint64 {
+	return bh.getCurrentBlock().Nonce
+}
+
+// CurrentRound returns the round of the current block
+func (bh *BlockchainHook) CurrentRound() uint64 {
+	return bh.getCurrentBlock().Round
+}
+
+// CurrentTimeStamp returns the timestamp of the current block
+func (bh *BlockchainHook) CurrentTimeStamp() uint64 {
+	return bh.getCurrentBlock().TimeStamp
+}
+
+// CurrentRandomSeed returns the random seed of the current block
+func (bh *BlockchainHook) CurrentRandomSeed() []byte {
+	return bh.getCurrentBlock().RandomSeed
+}
+
+// CurrentEpoch returns the epoch of the current block
+func (bh *BlockchainHook) CurrentEpoch() uint32 {
+	return bh.getCurrentBlock().Epoch
+}
+
+func (bh *BlockchainHook) getLastBlock() BlockInfo {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlock
+}
+
+func (bh *BlockchainHook) getCurrentBlock() BlockInfo {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlock
+}
+
+// ProcessBuiltInFunction loads the sender and the destination accounts, if they are in the self shard, executes the
+// built-in function and saves the accounts. If the call returns an error or a return code other than Ok, all the
+// changes made during the call are reverted. The logs are published to the log subscribers of the container only for
+// the calls which were kept.
+func (bh *BlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	function, err := bh.builtInFunctions.Get(input.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	bh.mutProcessing.Lock()
+	defer bh.mutProcessing.Unlock()
+
+	snapshot := bh.accounts.JournalLen()
+	vmOutput, err := bh.processBuiltInFunction(function, input)
+	if err != nil || vmOutput == nil || vmOutput.ReturnCode != vmcommon.Ok {
+		errRevert := bh.accounts.RevertToSnapshot(snapshot)
+		if errRevert != nil {
+			return nil, errRevert
+		}
+
+		return vmOutput, err
+	}
+
+	bh.publishLogs(input, vmOutput)
+
+	return vmOutput, nil
+}
+
+func (bh *BlockchainHook) processBuiltInFunction(
+	function vmcommon.BuiltinFunction,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	sndAccount, err := bh.loadUserAccountIfInSelfShard(input.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	dstAccount := sndAccount
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		dstAccount, err = bh.loadUserAccountIfInSelfShard(input.RecipientAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput, err := bh.runBuiltInFunction(function, toUserAccountHandler(sndAccount), toUserAccountHandler(dstAccount), input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bh.saveIfNotNil(sndAccount)
+	if err != nil {
+		return nil, err
+	}
+	if dstAccount != sndAccount {
+		err = bh.saveIfNotNil(dstAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vmOutput, nil
+}
+
+// runBuiltInFunction runs the function through the container if the container processes the calls itself, so that
+// the output carries what the container adds to it, like the state diff. The logs are not published, as the call may
+// still be reverted.
+func (bh *BlockchainHook) runBuiltInFunction(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	deferredLogsProcessor, ok := bh.builtInFunctions.(vmcommon.DeferredLogsProcessor)
+	if ok {
+		return deferredLogsProcessor.ProcessBuiltinFunctionWithoutLogs(function, acntSnd, acntDst, input)
+	}
+
+	processor, ok := bh.builtInFunctions.(vmcommon.BuiltInFunctionProcessor)
+	if ok {
+		return processor.ProcessBuiltinFunction(function, acntSnd, acntDst, input)
+	}
+
+	return function.ProcessBuiltinFunction(acntSnd, acntDst, input)
+}
+
+func (bh *BlockchainHook) publishLogs(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	publisher, ok := bh.builtInFunctions.(vmcommon.LogPublisher)
+	if !ok {
+		return
+	}
+
+	publisher.PublishLogs(input, vmOutput)
+}
+
+// toUserAccountHandler avoids handing out interfaces that hold nil pointers
+func toUserAccountHandler(account *UserAccount) vmcommon.UserAccountHandler {
+	if account == nil {
+		return nil
+	}
+
+	return account
+}
+
+func (bh *BlockchainHook) loadUserAccountIfInSelfShard(address []byte) (*UserAccount, error) {
+	if len(address) == 0 {
+		return nil, nil
+	}
+	if bh.shardCoordinator.ComputeId(address) != bh.shardCoordinator.SelfId() {
+		return nil, nil
+	}
+
+	account, err := bh.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(*UserAccount)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+func (bh *BlockchainHook) saveIfNotNil(account *UserAccount) error {
+	if account == nil {
+		return nil
+	}
+
+	return bh.accounts.SaveAccount(account)
+}
+
+// GetBuiltinFunctionNames returns the names of the functions held by the built-in function container
+func (bh *BlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	return bh.builtInFunctions.Keys()
+}
+
+// GetAllState returns the full state of the account
+func (bh *BlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	return bh.accounts.GetAllState(address)
+}
+
+// GetUserAccount returns an existing user account
+func (bh *BlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := bh.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+// GetCode returns the code of the given account
+func (bh *BlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return bh.accounts.GetCode(account.GetCodeHash())
+}
+
+// GetShardOfAddress returns the shard of the given address
+func (bh *BlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	return bh.shardCoordinator.ComputeId(address)
+}
+
+// IsSmartContract returns true if the address is a smart contract address
+func (bh *BlockchainHook) IsSmartContract(address []byte) bool {
+	return vmcommon.IsSmartContractAddress(address)
+}
+
+// IsPayable returns true if the receiver can receive MOA from the sender
+func (bh *BlockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	if !vmcommon.IsSmartContractAddress(recvAddress) {
+		return true, nil
+	}
+	if bh.shardCoordinator.ComputeId(recvAddress) != bh.shardCoordinator.SelfId() {
+		return true, nil
+	}
+
+	account, err := bh.GetUserAccount(recvAddress)
+	if err != nil {
+		return false, err
+	}
+
+	metadata := vmcommon.CodeMetadataFromBytes(account.GetCodeMetadata())
+	if metadata.Payable {
+		return true, nil
+	}
+
+	return metadata.PayableBySC && vmcommon.IsSmartContractAddress(sndAddress), nil
+}
+
+// SaveCompiledCode saves the compiled code in memory
+func (bh *BlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	bh.mutCompiled.Lock()
+	bh.compiledCodes[string(codeHash)] = copyBytes(code)
+	bh.mutCompiled.Unlock()
+}
+
+// GetCompiledCode returns the compiled code if it was previously saved
+func (bh *BlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	bh.mutCompiled.RLock()
+	defer bh.mutCompiled.RUnlock()
+
+	code, found := bh.compiledCodes[string(codeHash)]
+	return found, copyBytes(code)
+}
+
+// ClearCompiledCodes removes all the compiled codes
+func (bh *BlockchainHook) ClearCompiledCodes() {
+	bh.mutCompiled.Lock()
+	bh.compiledCodes = make(map[string][]byte)
+	bh.mutCompiled.Unlock()
+}
+
+// GetDCTToken returns the DCT token held by the account for the given token identifier and nonce
+func (bh *BlockchainHook) GetDCTToken(address []byte, tokenID []byte, nonce uint64) (*dct.DCToken, error) {
+	account, err := bh.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	dctTokenKey := []byte(dctKeyPrefix + string(tokenID))
+	dctData, _, err := bh.nftStorageHandler.GetDCTNFTTokenOnDestination(userAccount, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return dctData, nil
+}
+
+// IsPaused returns true if the token is globally paused
+func (bh *BlockchainHook) IsPaused(tokenID []byte) bool {
+	return bh.globalSettingsHandler.IsPaused([]byte(dctKeyPrefix + string(tokenID)))
+}
+
+// IsLimitedTransfer returns true if the token has limited transfers
+func (bh *BlockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	return bh.globalSettingsHandler.IsLimitedTransfer([]byte(dctKeyPrefix + string(tokenID)))
+}
+
+// GetSnapshot returns the current journal length of the accounts adapter
+func (bh *BlockchainHook) GetSnapshot() int {
+	return bh.accounts.JournalLen()
+}
+
+// RevertToSnapshot reverts the accounts adapter to the given snapshot
+func (bh *BlockchainHook) RevertToSnapshot(snapshot int) error {
+	return bh.accounts.RevertToSnapshot(snapshot)
+}
+
+// ExecuteSmartContractCallOnOtherVM returns ErrSmartContractCallsNotSupported as there is no VM attached
+func (bh *BlockchainHook) ExecuteSmartContractCallOnOtherVM(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return nil, ErrSmartContractCallsNotSupported
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bh *BlockchainHook) IsInterfaceNil() bool {
+	return bh == nil
+}
@@ -0,0 +1,369 @@
+package inMemoryState
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/builtInFunctions"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+var (
+	testTokenID = []byte("TKN-abcdef")
+	testAlice   = bytes.Repeat([]byte{1}, 32)
+	testBob     = bytes.Repeat([]byte{2}, 32)
+)
+
+func createGasMap(value uint64) map[string]map[string]uint64 {
+	baseOperationCost := make(map[string]uint64)
+	for _, key := range []string{"StorePerByte", "ReleasePerByte", "DataCopyPerByte", "PersistPerByte", "CompilePerByte", "AoTPreparePerByte"} {
+		baseOperationCost[key] = value
+	}
+
+	builtInCost := make(map[string]uint64)
+	for _, key := range []string{"ChangeOwnerAddress", "ClaimDeveloperRewards", "SaveUserName", "SaveKeyValue", "DCTTransfer",
+		"DCTBurn", "DCTLocalMint", "DCTLocalBurn", "DCTNFTCreate", "DCTNFTAddQuantity", "DCTNFTBurn", "DCTNFTTransfer",
+		"DCTNFTChangeCreateOwner", "DCTNFTMultiTransfer", "DCTNFTAddURI", "DCTNFTUpdateAttributes", "SetGuardian",
+		"GuardAccount", "TrieLoadPerNode", "TrieStorePerNode"} {
+		builtInCost[key] = value
+	}
+
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: baseOperationCost,
+		core.BuiltInCostString:       builtInCost,
+	}
+}
+
+func createBlockchainHookWithBuiltInFunctions(t *testing.T, adb *AccountsAdapter) *BlockchainHook {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(1)
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           createGasMap(1),
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      &mock.MarshalizerMock{},
+		Accounts:                         adb,
+		ShardCoordinator:                 shardCoordinator,
+		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{IsSaveToSystemAccountFlagEnabledField: true},
+		GuardedAccountHandler:            &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+
+	hook, err := NewBlockchainHook(ArgsBlockchainHook{
+		Accounts:              adb,
+		BuiltInFunctions:      creator.BuiltInFunctionContainer(),
+		ShardCoordinator:      shardCoordinator,
+		NFTStorageHandler:     creator.NFTStorageHandler(),
+		GlobalSettingsHandler: creator.DCTGlobalSettingsHandler(),
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.SetPayableHandler(hook))
+
+	return hook
+}
+
+func saveMarshalledValue(t *testing.T, adb *AccountsAdapter, address []byte, key []byte, value interface{}) {
+	marshalizer := &mock.MarshalizerMock{}
+	buff, err := marshalizer.Marshal(value)
+	require.Nil(t, err)
+
+	account := loadUserAccount(t, adb, address)
+	require.Nil(t, account.AccountDataHandler().SaveKeyValue(key, buff))
+	require.Nil(t, adb.SaveAccount(account))
+}
+
+func TestNewBlockchainHook(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func() ArgsBlockchainHook {
+		return ArgsBlockchainHook{
+			Accounts:              NewAccountsAdapter(),
+			BuiltInFunctions:      builtInFunctions.NewBuiltInFunctionContainer(),
+			ShardCoordinator:      mock.NewMultiShardsCoordinatorMock(1),
+			NFTStorageHandler:     &mock.DCTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		}
+	}
+
+	args := createArgs()
+	args.Accounts = nil
+	_, err := NewBlockchainHook(args)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	args = createArgs()
+	args.BuiltInFunctions = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+	args = createArgs()
+	args.ShardCoordinator = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilShardCoordinator, err)
+
+	args = createArgs()
+	args.NFTStorageHandler = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilNFTStorageHandler, err)
+
+	args = createArgs()
+	args.GlobalSettingsHandler = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilGlobalSettingsHandler, err)
+
+	hook, err := NewBlockchainHook(createArgs())
+	assert.Nil(t, err)
+	assert.False(t, hook.IsInterfaceNil())
+}
+
+func TestBlockchainHook_BlockInfo(t *testing.T) {
+	t.Parallel()
+
+	hook := createBlockchainHookWithBuiltInFunctions(t, NewAccountsAdapter())
+	hook.SetCurrentBlockInfo(BlockInfo{Nonce: 2, Round: 3, TimeStamp: 4, RandomSeed: []byte("seed"), Epoch: 5})
+	hook.SetLastBlockInfo(BlockInfo{Nonce: 1, Round: 2, TimeStamp: 3, RandomSeed: []byte("last"), Epoch: 4, RootHash: []byte("root")})
+	hook.SetBlockhash(1, []byte("hash"))
+
+	assert.Equal(t, uint64(2), hook.CurrentNonce())
+	assert.Equal(t, uint64(3), hook.CurrentRound())
+	assert.Equal(t, uint64(4), hook.CurrentTimeStamp())
+	assert.Equal(t, []byte("seed"), hook.CurrentRandomSeed())
+	assert.Equal(t, uint32(5), hook.CurrentEpoch())
+	assert.Equal(t, uint64(1), hook.LastNonce())
+	assert.Equal(t, uint64(2), hook.LastRound())
+	assert.Equal(t, uint64(3), hook.LastTimeStamp())
+	assert.Equal(t, []byte("last"), hook.LastRandomSeed())
+	assert.Equal(t, uint32(4), hook.LastEpoch())
+	assert.Equal(t, []byte("root"), hook.GetStateRootHash())
+
+	hash, err := hook.GetBlockhash(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hash"), hash)
+	_, err = hook.GetBlockhash(2)
+	assert.Equal(t, ErrBlockHashNotFound, err)
+}
+
+func TestBlockchainHook_NewAddress(t *testing.T) {
+	t.Parallel()
+
+	hook := createBlockchainHookWithBuiltInFunctions(t, NewAccountsAdapter())
+
+	_, err := hook.NewAddress([]byte("short"), 0, []byte{5, 0})
+	assert.Equal(t, ErrInvalidAddressLength, err)
+	_, err = hook.NewAddress(testAlice, 0, []byte{5})
+	assert.Equal(t, vmcommon.ErrInvalidVMType, err)
+
+	address1, err := hook.NewAddress(testAlice, 0, []byte{5, 0})
+	require.Nil(t, err)
+	address2, _ := hook.NewAddress(testAlice, 0, []byte{5, 0})
+	address3, _ := hook.NewAddress(testAlice, 1, []byte{5, 0})
+
+	assert.Equal(t, address1, address2)
+	assert.NotEqual(t, address1, address3)
+	assert.Equal(t, len(testAlice), len(address1))
+	assert.True(t, hook.IsSmartContract(address1))
+	assert.Equal(t, testAlice[30:], address1[30:])
+}
+
+func TestBlockchainHook_IsPayable(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	hook := createBlockchainHookWithBuiltInFunctions(t, adb)
+	scAddress, _ := hook.NewAddress(testAlice, 0, []byte{5, 0})
+
+	isPayable, err := hook.IsPayable(testAlice, testBob)
+	assert.Nil(t, err)
+	assert.True(t, isPayable)
+
+	_, err = hook.IsPayable(testAlice, scAddress)
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	scAccount := loadUserAccount(t, adb, scAddress)
+	scAccount.SetCodeMetadata((&vmcommon.CodeMetadata{PayableBySC: true}).ToBytes())
+	_ = adb.SaveAccount(scAccount)
+
+	isPayable, _ = hook.IsPayable(testAlice, scAddress)
+	assert.False(t, isPayable)
+	isPayable, _ = hook.IsPayable(scAddress, scAddress)
+	assert.True(t, isPayable)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionDCTTransfer(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	hook := createBlockchainHookWithBuiltInFunctions(t, adb)
+	tokenKey := []byte(dctKeyPrefix + string(testTokenID))
+	saveMarshalledValue(t, adb, testAlice, tokenKey, &dct.DCToken{Value: big.NewInt(100)})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testAlice,
+			Arguments:   [][]byte{testTokenID, big.NewInt(30).Bytes()},
+			CallValue:   big.NewInt(0),
+			GasProvided: 10,
+		},
+		RecipientAddr: testBob,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+
+	vmOutput, err := hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	assert.Equal(t, uint64(9), vmOutput.GasRemaining)
+
+	aliceToken, err := hook.GetDCTToken(testAlice, testTokenID, 0)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(70), aliceToken.Value)
+	bobToken, err := hook.GetDCTToken(testBob, testTokenID, 0)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(30), bobToken.Value)
+
+	input.Arguments[1] = big.NewInt(1000).Bytes()
+	journalLen := adb.JournalLen()
+	_, err = hook.ProcessBuiltInFunction(input)
+	assert.Equal(t, builtInFunctions.ErrInsufficientFunds, err)
+	assert.Equal(t, journalLen, adb.JournalLen())
+
+	input.Function = "missing"
+	_, err = hook.ProcessBuiltInFunction(input)
+	assert.NotNil(t, err)
+
+	_, err = hook.ProcessBuiltInFunction(nil)
+	assert.Equal(t, ErrNilVMInput, err)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionDCTNFTCreate(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	hook := createBlockchainHookWithBuiltInFunctions(t, adb)
+	roleKey := []byte(core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier + string(testTokenID))
+	saveMarshalledValue(t, adb, testAlice, roleKey, &dct.DCTRoles{Roles: [][]byte{[]byte(core.DCTRoleNFTCreate)}})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: testAlice,
+			Arguments: [][]byte{
+				testTokenID,
+				big.NewInt(1).Bytes(),
+				[]byte("name"),
+				big.NewInt(100).Bytes(),
+				[]byte("hash"),
+				[]byte("attributes"),
+				[]byte("uri"),
+			},
+			CallValue:   big.NewInt(0),
+			GasProvided: 1000,
+		},
+		RecipientAddr: testAlice,
+		Function:      core.BuiltInFunctionDCTNFTCreate,
+	}
+
+	vmOutput, err := hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{big.NewInt(1).Bytes()}, vmOutput.ReturnData)
+
+	nft, err := hook.GetDCTToken(testAlice, testTokenID, 1)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), nft.Value)
+	require.NotNil(t, nft.TokenMetaData)
+	assert.Equal(t, []byte("name"), nft.TokenMetaData.Name)
+
+	systemAccountState, err := hook.GetAllState(vmcommon.SystemAccountAddress)
+	require.Nil(t, err)
+	assert.NotEmpty(t, systemAccountState)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionShouldRevertOnFailingReturnCode(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	hook := createBlockchainHookWithBuiltInFunctions(t, adb)
+	failingFunctionName := "failingFunction"
+	err := hook.builtInFunctions.Add(failingFunctionName, &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError}, nil
+		},
+	})
+	require.Nil(t, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testAlice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 10,
+		},
+		RecipientAddr: testAlice,
+		Function:      failingFunctionName,
+	}
+	journalLen := adb.JournalLen()
+	vmOutput, err := hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	assert.Equal(t, journalLen, adb.JournalLen())
+
+	value, _, err := loadUserAccount(t, adb, testAlice).AccountDataHandler().RetrieveValue([]byte("key"))
+	require.Nil(t, err)
+	assert.Empty(t, value)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionShouldPublishTheLogsOfTheKeptCalls(t *testing.T) {
+	t.Parallel()
+
+	adb := NewAccountsAdapter()
+	hook := createBlockchainHookWithBuiltInFunctions(t, adb)
+	tokenKey := []byte(dctKeyPrefix + string(testTokenID))
+	saveMarshalledValue(t, adb, testAlice, tokenKey, &dct.DCToken{Value: big.NewInt(100)})
+
+	received := make([]*vmcommon.BuiltInLogEvent, 0)
+	err := hook.builtInFunctions.(vmcommon.AcceptLogSubscriber).RegisterLogSubscriber(&mock.LogSubscriberStub{
+		ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+			received = append(received, event)
+		},
+	})
+	require.Nil(t, err)
+	failingFunctionName := "failingFunction"
+	err = hook.builtInFunctions.Add(failingFunctionName, &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, Logs: []*vmcommon.LogEntry{{Identifier: []byte("reverted")}}}, nil
+		},
+	})
+	require.Nil(t, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testAlice,
+			Arguments:   [][]byte{testTokenID, big.NewInt(30).Bytes()},
+			CallValue:   big.NewInt(0),
+			GasProvided: 10,
+		},
+		RecipientAddr: testBob,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+	_, err = hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(received))
+	assert.Equal(t, core.BuiltInFunctionDCTTransfer, string(received[0].LogEntry.Identifier))
+
+	input.Function = failingFunctionName
+	journalLen := adb.JournalLen()
+	vmOutput, err := hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	assert.Equal(t, journalLen, adb.JournalLen())
+	assert.Equal(t, 1, len(received))
+
+	value, _, err := loadUserAccount(t, adb, testAlice).AccountDataHandler().RetrieveValue([]byte("key"))
+	require.Nil(t, err)
+	assert.Empty(t, value)
+}
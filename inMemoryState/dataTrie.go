@@ -0,0 +1,188 @@
+package inMemoryState
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.AccountDataHandler = (*DataTrie)(nil)
+
+type dataTrieLeaf struct {
+	value   []byte
+	version core.TrieNodeVersion
+}
+
+// DataTrie is an in-memory key-value store that implements the vmcommon.AccountDataHandler interface.
+// Saving an empty value removes the key, the same way the node's data trie does.
+type DataTrie struct {
+	leaves map[string]*dataTrieLeaf
+}
+
+// NewDataTrie creates an empty data trie
+func NewDataTrie() *DataTrie {
+	return &DataTrie{
+		leaves: make(map[string]*dataTrieLeaf),
+	}
+}
+
+// RetrieveValue returns a copy of the value stored under the given key. The returned depth is always 0
+// as there are no trie nodes to be traversed.
+func (dt *DataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	leaf, found := dt.leaves[string(key)]
+	if !found {
+		return nil, 0, nil
+	}
+
+	return copyBytes(leaf.value), 0, nil
+}
+
+// SaveKeyValue stores a copy of the value under the given key. An empty value deletes the key.
+func (dt *DataTrie) SaveKeyValue(key []byte, value []byte) error {
+	if len(value) == 0 {
+		delete(dt.leaves, string(key))
+		return nil
+	}
+
+	leaf, found := dt.leaves[string(key)]
+	if !found {
+		leaf = &dataTrieLeaf{version: core.NotSpecified}
+		dt.leaves[string(key)] = leaf
+	}
+	leaf.value = copyBytes(value)
+
+	return nil
+}
+
+// MigrateDataTrieLeaves walks the leaves in key order and migrates the ones with the old version to the new
+// version, for as long as the provided trie migrator allows it
+func (dt *DataTrie) MigrateDataTrieLeaves(args vmcommon.ArgsMigrateDataTrieLeaves) error {
+	if check.IfNil(args.TrieMigrator) {
+		return nil
+	}
+
+	for _, key := range dt.sortedKeys() {
+		shouldContinue := args.TrieMigrator.ConsumeStorageLoadGas()
+
+		leaf := dt.leaves[key]
+		if leaf.version == args.OldVersion {
+			leafData := core.TrieData{
+				Key:     []byte(key),
+				Value:   copyBytes(leaf.value),
+				Version: leaf.version,
+			}
+
+			canMigrateMore, err := args.TrieMigrator.AddLeafToMigrationQueue(leafData, args.NewVersion)
+			if err != nil {
+				return err
+			}
+			shouldContinue = shouldContinue && canMigrateMore
+		}
+
+		if !shouldContinue {
+			break
+		}
+	}
+
+	for _, leafData := range args.TrieMigrator.GetLeavesToBeMigrated() {
+		leaf, found := dt.leaves[string(leafData.Key)]
+		if !found {
+			continue
+		}
+		leaf.version = args.NewVersion
+	}
+
+	return nil
+}
+
+// GetLeafVersion returns the version of the leaf stored under the given key
+func (dt *DataTrie) GetLeafVersion(key []byte) (core.TrieNodeVersion, bool) {
+	leaf, found := dt.leaves[string(key)]
+	if !found {
+		return core.NotSpecified, false
+	}
+
+	return leaf.version, true
+}
+
+// GetAllState returns a copy of all the key-value pairs held by the data trie
+func (dt *DataTrie) GetAllState() map[string][]byte {
+	state := make(map[string][]byte, len(dt.leaves))
+	for key, leaf := range dt.leaves {
+		state[key] = copyBytes(leaf.value)
+	}
+
+	return state
+}
+
+// Len returns the number of keys held by the data trie
+func (dt *DataTrie) Len() int {
+	return len(dt.leaves)
+}
+
+// RootHash returns a deterministic hash computed over all the key-value pairs, in key order.
+// An empty data trie has a nil root hash.
+func (dt *DataTrie) RootHash() []byte {
+	if len(dt.leaves) == 0 {
+		return nil
+	}
+
+	hasher := sha256.New()
+	for _, key := range dt.sortedKeys() {
+		writeLengthPrefixed(hasher, []byte(key))
+		writeLengthPrefixed(hasher, dt.leaves[key].value)
+	}
+
+	return hasher.Sum(nil)
+}
+
+// Clone returns a deep copy of the data trie
+func (dt *DataTrie) Clone() *DataTrie {
+	clone := NewDataTrie()
+	for key, leaf := range dt.leaves {
+		clone.leaves[key] = &dataTrieLeaf{
+			value:   copyBytes(leaf.value),
+			version: leaf.version,
+		}
+	}
+
+	return clone
+}
+
+func (dt *DataTrie) sortedKeys() []string {
+	keys := make([]string, 0, len(dt.leaves))
+	for key := range dt.leaves {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dt *DataTrie) IsInterfaceNil() bool {
+	return dt == nil
+}
+
+func writeLengthPrefixed(writer io.Writer, data []byte) {
+	lenBuff := make([]byte, 8)
+	binary.BigEndian.PutUint64(lenBuff, uint64(len(data)))
+	_, _ = writer.Write(lenBuff)
+	_, _ = writer.Write(data)
+}
+
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	result := make([]byte, len(data))
+	copy(result, data)
+
+	return result
+}
@@ -0,0 +1,126 @@
+package inMemoryState
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/dataTrieMigrator"
+)
+
+func TestDataTrie_SaveAndRetrieve(t *testing.T) {
+	t.Parallel()
+
+	dt := NewDataTrie()
+	value := []byte("value")
+	err := dt.SaveKeyValue([]byte("key"), value)
+	require.Nil(t, err)
+
+	value[0] = 'X'
+	retrieved, depth, err := dt.RetrieveValue([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), depth)
+	assert.Equal(t, []byte("value"), retrieved)
+
+	retrieved[0] = 'Y'
+	retrieved, _, _ = dt.RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), retrieved)
+
+	retrieved, _, err = dt.RetrieveValue([]byte("missing"))
+	assert.Nil(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDataTrie_SaveEmptyValueDeletesKey(t *testing.T) {
+	t.Parallel()
+
+	dt := NewDataTrie()
+	_ = dt.SaveKeyValue([]byte("key"), []byte("value"))
+	require.Equal(t, 1, dt.Len())
+
+	_ = dt.SaveKeyValue([]byte("key"), nil)
+	assert.Equal(t, 0, dt.Len())
+	assert.Nil(t, dt.RootHash())
+}
+
+func TestDataTrie_RootHashIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	dt1 := NewDataTrie()
+	_ = dt1.SaveKeyValue([]byte("a"), []byte("1"))
+	_ = dt1.SaveKeyValue([]byte("b"), []byte("2"))
+
+	dt2 := NewDataTrie()
+	_ = dt2.SaveKeyValue([]byte("b"), []byte("2"))
+	_ = dt2.SaveKeyValue([]byte("a"), []byte("1"))
+
+	assert.Equal(t, dt1.RootHash(), dt2.RootHash())
+
+	_ = dt2.SaveKeyValue([]byte("a"), []byte("3"))
+	assert.NotEqual(t, dt1.RootHash(), dt2.RootHash())
+}
+
+func TestDataTrie_CloneIsIndependent(t *testing.T) {
+	t.Parallel()
+
+	dt := NewDataTrie()
+	_ = dt.SaveKeyValue([]byte("key"), []byte("value"))
+
+	clone := dt.Clone()
+	_ = clone.SaveKeyValue([]byte("key"), []byte("changed"))
+
+	value, _, _ := dt.RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, map[string][]byte{"key": []byte("changed")}, clone.GetAllState())
+}
+
+func TestDataTrie_MigrateDataTrieLeaves(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil migrator should do nothing", func(t *testing.T) {
+		t.Parallel()
+
+		dt := NewDataTrie()
+		_ = dt.SaveKeyValue([]byte("key"), []byte("value"))
+
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{})
+		assert.Nil(t, err)
+
+		version, found := dt.GetLeafVersion([]byte("key"))
+		assert.True(t, found)
+		assert.Equal(t, core.NotSpecified, version)
+	})
+	t.Run("should migrate leaves while there is gas", func(t *testing.T) {
+		t.Parallel()
+
+		dt := NewDataTrie()
+		_ = dt.SaveKeyValue([]byte("key1"), []byte("value1"))
+		_ = dt.SaveKeyValue([]byte("key2"), []byte("value2"))
+		_ = dt.SaveKeyValue([]byte("key3"), []byte("value3"))
+
+		dtm := dataTrieMigrator.NewDataTrieMigrator(dataTrieMigrator.ArgsNewDataTrieMigrator{
+			GasProvided: 40,
+			DataTrieGasCost: dataTrieMigrator.DataTrieGasCost{
+				TrieLoadPerNode:  5,
+				TrieStorePerNode: 10,
+			},
+		})
+
+		err := dt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion:   core.NotSpecified,
+			NewVersion:   core.AutoBalanceEnabled,
+			TrieMigrator: dtm,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(dtm.GetLeavesToBeMigrated()))
+
+		version, _ := dt.GetLeafVersion([]byte("key1"))
+		assert.Equal(t, core.AutoBalanceEnabled, version)
+		version, _ = dt.GetLeafVersion([]byte("key2"))
+		assert.Equal(t, core.AutoBalanceEnabled, version)
+		version, _ = dt.GetLeafVersion([]byte("key3"))
+		assert.Equal(t, core.NotSpecified, version)
+	})
+}
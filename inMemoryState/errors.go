@@ -0,0 +1,54 @@
+package inMemoryState
+
+import "errors"
+
+// ErrNilAddress signals that a nil or empty address was provided
+var ErrNilAddress = errors.New("nil address")
+
+// ErrAccountNotFound signals that the requested account does not exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrNilAccount signals that a nil account was provided
+var ErrNilAccount = errors.New("nil account")
+
+// ErrWrongTypeAssertion signals that a wrong type assertion occurred
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrInsufficientFunds signals that the account balance is not enough for the requested operation
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrOperationNotPermitted signals that the operation is not permitted for the caller
+var ErrOperationNotPermitted = errors.New("operation not permitted")
+
+// ErrInvalidAddressLength signals that the provided address has an invalid length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrNilValue signals that a nil value was provided
+var ErrNilValue = errors.New("nil value")
+
+// ErrInvalidSnapshot signals that the provided snapshot identifier is not within the journal bounds
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter was provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilBuiltInFunctionContainer signals that a nil built-in function container was provided
+var ErrNilBuiltInFunctionContainer = errors.New("nil built-in function container")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator was provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilNFTStorageHandler signals that a nil NFT storage handler was provided
+var ErrNilNFTStorageHandler = errors.New("nil NFT storage handler")
+
+// ErrNilGlobalSettingsHandler signals that a nil global settings handler was provided
+var ErrNilGlobalSettingsHandler = errors.New("nil global settings handler")
+
+// ErrNilVMInput signals that a nil VM input was provided
+var ErrNilVMInput = errors.New("nil vm input")
+
+// ErrBlockHashNotFound signals that no block hash is known for the requested nonce
+var ErrBlockHashNotFound = errors.New("block hash not found")
+
+// ErrSmartContractCallsNotSupported signals that the in-memory blockchain hook cannot execute smart contracts
+var ErrSmartContractCallsNotSupported = errors.New("smart contract calls are not supported")
@@ -0,0 +1,222 @@
+package inMemoryState
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.UserAccountHandler = (*UserAccount)(nil)
+
+// UserAccount is an in-memory implementation of the vmcommon.UserAccountHandler interface
+type UserAccount struct {
+	address         []byte
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	ownerAddress    []byte
+	userName        []byte
+	code            []byte
+	codeHash        []byte
+	codeMetadata    []byte
+	dataTrie        *DataTrie
+}
+
+// NewUserAccount creates an empty user account for the provided address
+func NewUserAccount(address []byte) (*UserAccount, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	return &UserAccount{
+		address:         copyBytes(address),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+		dataTrie:        NewDataTrie(),
+	}, nil
+}
+
+// AddressBytes returns the address of the account
+func (ua *UserAccount) AddressBytes() []byte {
+	return ua.address
+}
+
+// IncreaseNonce adds the given value to the current nonce
+func (ua *UserAccount) IncreaseNonce(value uint64) {
+	ua.nonce += value
+}
+
+// GetNonce returns the account nonce
+func (ua *UserAccount) GetNonce() uint64 {
+	return ua.nonce
+}
+
+// AddToBalance adds the value to the balance. A negative value that would make the balance negative is rejected.
+func (ua *UserAccount) AddToBalance(value *big.Int) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	newBalance := big.NewInt(0).Add(ua.balance, value)
+	if newBalance.Sign() < 0 {
+		return ErrInsufficientFunds
+	}
+
+	ua.balance = newBalance
+	return nil
+}
+
+// GetBalance returns a copy of the account balance
+func (ua *UserAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(ua.balance)
+}
+
+// AddToDeveloperReward adds the value to the accumulated developer rewards
+func (ua *UserAccount) AddToDeveloperReward(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	ua.developerReward = big.NewInt(0).Add(ua.developerReward, value)
+}
+
+// ClaimDeveloperRewards resets the developer rewards and returns the claimed value. Only the owner may claim.
+func (ua *UserAccount) ClaimDeveloperRewards(sndAddress []byte) (*big.Int, error) {
+	if !bytes.Equal(sndAddress, ua.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	oldValue := ua.developerReward
+	ua.developerReward = big.NewInt(0)
+
+	return oldValue, nil
+}
+
+// GetDeveloperReward returns a copy of the accumulated developer rewards
+func (ua *UserAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(ua.developerReward)
+}
+
+// ChangeOwnerAddress sets a new owner address. Only the current owner may change it.
+func (ua *UserAccount) ChangeOwnerAddress(sndAddress []byte, newAddress []byte) error {
+	if !bytes.Equal(sndAddress, ua.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(ua.address) {
+		return ErrInvalidAddressLength
+	}
+
+	ua.ownerAddress = copyBytes(newAddress)
+	return nil
+}
+
+// SetOwnerAddress sets the owner address without any checks
+func (ua *UserAccount) SetOwnerAddress(address []byte) {
+	ua.ownerAddress = copyBytes(address)
+}
+
+// GetOwnerAddress returns the owner address
+func (ua *UserAccount) GetOwnerAddress() []byte {
+	return ua.ownerAddress
+}
+
+// SetUserName sets the user name
+func (ua *UserAccount) SetUserName(userName []byte) {
+	ua.userName = copyBytes(userName)
+}
+
+// GetUserName returns the user name
+func (ua *UserAccount) GetUserName() []byte {
+	return ua.userName
+}
+
+// SetCode sets the code of the account together with its code hash
+func (ua *UserAccount) SetCode(code []byte) {
+	ua.code = copyBytes(code)
+	if len(code) == 0 {
+		ua.codeHash = nil
+		return
+	}
+
+	codeHash := sha256.Sum256(code)
+	ua.codeHash = codeHash[:]
+}
+
+// GetCode returns the code of the account
+func (ua *UserAccount) GetCode() []byte {
+	return ua.code
+}
+
+// GetCodeHash returns the code hash of the account
+func (ua *UserAccount) GetCodeHash() []byte {
+	return ua.codeHash
+}
+
+// SetCodeMetadata sets the code metadata
+func (ua *UserAccount) SetCodeMetadata(codeMetadata []byte) {
+	ua.codeMetadata = copyBytes(codeMetadata)
+}
+
+// GetCodeMetadata returns the code metadata
+func (ua *UserAccount) GetCodeMetadata() []byte {
+	return ua.codeMetadata
+}
+
+// GetRootHash returns the root hash of the account's data trie
+func (ua *UserAccount) GetRootHash() []byte {
+	return ua.dataTrie.RootHash()
+}
+
+// AccountDataHandler returns the data trie of the account
+func (ua *UserAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return ua.dataTrie
+}
+
+// DataTrie returns the data trie of the account
+func (ua *UserAccount) DataTrie() *DataTrie {
+	return ua.dataTrie
+}
+
+// Clone returns a deep copy of the account
+func (ua *UserAccount) Clone() *UserAccount {
+	return &UserAccount{
+		address:         copyBytes(ua.address),
+		nonce:           ua.nonce,
+		balance:         big.NewInt(0).Set(ua.balance),
+		developerReward: big.NewInt(0).Set(ua.developerReward),
+		ownerAddress:    copyBytes(ua.ownerAddress),
+		userName:        copyBytes(ua.userName),
+		code:            copyBytes(ua.code),
+		codeHash:        copyBytes(ua.codeHash),
+		codeMetadata:    copyBytes(ua.codeMetadata),
+		dataTrie:        ua.dataTrie.Clone(),
+	}
+}
+
+// Hash returns a deterministic hash over all the account fields, including the data trie root hash
+func (ua *UserAccount) Hash() []byte {
+	hasher := sha256.New()
+
+	nonceBuff := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonceBuff, ua.nonce)
+
+	writeLengthPrefixed(hasher, ua.address)
+	writeLengthPrefixed(hasher, nonceBuff)
+	writeLengthPrefixed(hasher, ua.balance.Bytes())
+	writeLengthPrefixed(hasher, ua.developerReward.Bytes())
+	writeLengthPrefixed(hasher, ua.ownerAddress)
+	writeLengthPrefixed(hasher, ua.userName)
+	writeLengthPrefixed(hasher, ua.codeHash)
+	writeLengthPrefixed(hasher, ua.codeMetadata)
+	writeLengthPrefixed(hasher, ua.dataTrie.RootHash())
+
+	return hasher.Sum(nil)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ua *UserAccount) IsInterfaceNil() bool {
+	return ua == nil
+}
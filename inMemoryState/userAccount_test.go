@@ -0,0 +1,81 @@
+package inMemoryState
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserAccount(t *testing.T) {
+	t.Parallel()
+
+	account, err := NewUserAccount(nil)
+	assert.Nil(t, account)
+	assert.Equal(t, ErrNilAddress, err)
+
+	account, err = NewUserAccount([]byte("address"))
+	assert.Nil(t, err)
+	assert.False(t, account.IsInterfaceNil())
+	assert.Equal(t, big.NewInt(0), account.GetBalance())
+	assert.Equal(t, big.NewInt(0), account.GetDeveloperReward())
+}
+
+func TestUserAccount_AddToBalance(t *testing.T) {
+	t.Parallel()
+
+	account, _ := NewUserAccount([]byte("address"))
+	assert.Equal(t, ErrNilValue, account.AddToBalance(nil))
+	assert.Nil(t, account.AddToBalance(big.NewInt(10)))
+	assert.Equal(t, ErrInsufficientFunds, account.AddToBalance(big.NewInt(-11)))
+	assert.Nil(t, account.AddToBalance(big.NewInt(-10)))
+	assert.Equal(t, big.NewInt(0), account.GetBalance())
+}
+
+func TestUserAccount_OwnerOperations(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	newOwner := bytes.Repeat([]byte{2}, 32)
+	account, _ := NewUserAccount(bytes.Repeat([]byte{3}, 32))
+	account.SetOwnerAddress(owner)
+	account.AddToDeveloperReward(big.NewInt(100))
+
+	_, err := account.ClaimDeveloperRewards(newOwner)
+	assert.Equal(t, ErrOperationNotPermitted, err)
+
+	reward, err := account.ClaimDeveloperRewards(owner)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), reward)
+	assert.Equal(t, big.NewInt(0), account.GetDeveloperReward())
+
+	assert.Equal(t, ErrOperationNotPermitted, account.ChangeOwnerAddress(newOwner, newOwner))
+	assert.Equal(t, ErrInvalidAddressLength, account.ChangeOwnerAddress(owner, []byte("short")))
+	assert.Nil(t, account.ChangeOwnerAddress(owner, newOwner))
+	assert.Equal(t, newOwner, account.GetOwnerAddress())
+}
+
+func TestUserAccount_CloneIsIndependent(t *testing.T) {
+	t.Parallel()
+
+	account, _ := NewUserAccount([]byte("address"))
+	account.SetCode([]byte("code"))
+	_ = account.AddToBalance(big.NewInt(5))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	clone := account.Clone()
+	require.Equal(t, account.Hash(), clone.Hash())
+
+	_ = clone.AddToBalance(big.NewInt(5))
+	clone.IncreaseNonce(1)
+	_ = clone.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("changed"))
+
+	assert.Equal(t, big.NewInt(5), account.GetBalance())
+	assert.Equal(t, uint64(0), account.GetNonce())
+	value, _, _ := account.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+	assert.NotEqual(t, account.Hash(), clone.Hash())
+	assert.Equal(t, account.GetCodeHash(), clone.GetCodeHash())
+}
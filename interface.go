@@ -337,6 +337,38 @@ type BuiltInFunctionFactory interface {
 	IsInterfaceNil() bool
 }
 
+// BuiltInFunctionSimulator runs built-in functions without changing the accounts state
+type BuiltInFunctionSimulator interface {
+	SimulateBuiltInFunction(input *ContractCallInput) (*SimulationResult, error)
+	IsInterfaceNil() bool
+}
+
+// BuiltInFunctionRecorder runs built-in functions without changing the accounts state and records everything
+// needed to replay them
+type BuiltInFunctionRecorder interface {
+	RecordBuiltInFunction(input *ContractCallInput) (*ExecutionRecord, error)
+	IsInterfaceNil() bool
+}
+
+// AccountsIterator lists all the accounts of a state and the key-value pairs of their data tries
+type AccountsIterator interface {
+	Addresses() [][]byte
+	GetAllState(address []byte) (map[string][]byte, error)
+	IsInterfaceNil() bool
+}
+
+// ExistingAccountGetter returns the accounts already saved in the state
+type ExistingAccountGetter interface {
+	GetExistingAccount(address []byte) (AccountHandler, error)
+	IsInterfaceNil() bool
+}
+
+// ReturnCodeHandler is an error which knows the return code to report for it
+type ReturnCodeHandler interface {
+	error
+	GetReturnCode() ReturnCode
+}
+
 // PayableChecker will handle checking if transfer can happen of DCT tokens towards destination
 type PayableChecker interface {
 	CheckPayable(vmInput *ContractCallInput, dstAddress []byte, minLenArguments int) error
@@ -350,6 +382,13 @@ type AcceptPayableChecker interface {
 	IsInterfaceNil() bool
 }
 
+// AcceptCustomGasCost defines the methods of a custom built-in function to accept its gas cost
+// whenever the gas schedule changes
+type AcceptCustomGasCost interface {
+	SetCustomGasCost(gasCost uint64)
+	IsInterfaceNil() bool
+}
+
 // EnableEpochsHandler is used to verify which flags are set in the current epoch based on EnableEpochs config
 type EnableEpochsHandler interface {
 	IsGlobalMintBurnFlagEnabled() bool
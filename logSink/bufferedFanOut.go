@@ -0,0 +1,150 @@
+package logSink
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var _ vmcommon.LogSubscriber = (*bufferedFanOut)(nil)
+
+// ArgsBufferedFanOut defines the arguments needed to create a buffered fan-out
+type ArgsBufferedFanOut struct {
+	BufferSize  int
+	Subscribers []vmcommon.LogSubscriber
+}
+
+// bufferedFanOut queues the received logs and delivers them to all the subscribers on its own goroutine, so the
+// built-in functions never wait for slow subscribers. The logs received while the buffer is full are dropped.
+type bufferedFanOut struct {
+	subscribers []vmcommon.LogSubscriber
+	events      chan *vmcommon.BuiltInLogEvent
+	done        chan struct{}
+	numDropped  atomic.Uint64
+
+	mutClose sync.RWMutex
+	closed   bool
+}
+
+// NewBufferedFanOut creates a buffered fan-out and starts delivering the logs to the subscribers
+func NewBufferedFanOut(args ArgsBufferedFanOut) (*bufferedFanOut, error) {
+	if args.BufferSize < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBufferSize, args.BufferSize)
+	}
+	if len(args.Subscribers) == 0 {
+		return nil, ErrNoLogSubscribers
+	}
+	for i, subscriber := range args.Subscribers {
+		if check.IfNil(subscriber) {
+			return nil, fmt.Errorf("%w at index %d", ErrNilLogSubscriber, i)
+		}
+	}
+
+	fanOut := &bufferedFanOut{
+		subscribers: append(make([]vmcommon.LogSubscriber, 0, len(args.Subscribers)), args.Subscribers...),
+		events:      make(chan *vmcommon.BuiltInLogEvent, args.BufferSize),
+		done:        make(chan struct{}),
+	}
+	go fanOut.deliver()
+
+	return fanOut, nil
+}
+
+// ReceiveLog queues a copy of the event, or drops it if the buffer is full or the fan-out was closed
+func (fanOut *bufferedFanOut) ReceiveLog(event *vmcommon.BuiltInLogEvent) {
+	if event == nil {
+		return
+	}
+
+	fanOut.mutClose.RLock()
+	defer fanOut.mutClose.RUnlock()
+
+	if fanOut.closed {
+		fanOut.numDropped.Add(1)
+		return
+	}
+
+	select {
+	case fanOut.events <- cloneEvent(event):
+	default:
+		fanOut.numDropped.Add(1)
+	}
+}
+
+// NumDropped returns how many logs were dropped because the buffer was full or the fan-out was closed
+func (fanOut *bufferedFanOut) NumDropped() uint64 {
+	return fanOut.numDropped.Load()
+}
+
+// Close stops accepting logs and waits until the queued ones are delivered
+func (fanOut *bufferedFanOut) Close() error {
+	fanOut.mutClose.Lock()
+	if !fanOut.closed {
+		fanOut.closed = true
+		close(fanOut.events)
+	}
+	fanOut.mutClose.Unlock()
+
+	<-fanOut.done
+
+	return nil
+}
+
+func (fanOut *bufferedFanOut) deliver() {
+	defer close(fanOut.done)
+
+	for event := range fanOut.events {
+		for _, subscriber := range fanOut.subscribers {
+			subscriber.ReceiveLog(event)
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fanOut *bufferedFanOut) IsInterfaceNil() bool {
+	return fanOut == nil
+}
+
+// cloneEvent copies the event, as the VM output holding the log entry might change after the call
+func cloneEvent(event *vmcommon.BuiltInLogEvent) *vmcommon.BuiltInLogEvent {
+	clone := &vmcommon.BuiltInLogEvent{
+		OriginalTxHash: cloneBytes(event.OriginalTxHash),
+		CurrentTxHash:  cloneBytes(event.CurrentTxHash),
+		CallerAddr:     cloneBytes(event.CallerAddr),
+		Function:       event.Function,
+	}
+	if event.LogEntry != nil {
+		clone.LogEntry = &vmcommon.LogEntry{
+			Identifier: cloneBytes(event.LogEntry.Identifier),
+			Address:    cloneBytes(event.LogEntry.Address),
+			Topics:     cloneSlices(event.LogEntry.Topics),
+			Data:       cloneSlices(event.LogEntry.Data),
+		}
+	}
+
+	return clone
+}
+
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
+
+func cloneSlices(slices [][]byte) [][]byte {
+	if slices == nil {
+		return nil
+	}
+
+	clone := make([][]byte, 0, len(slices))
+	for _, data := range slices {
+		clone = append(clone, cloneBytes(data))
+	}
+
+	return clone
+}
@@ -0,0 +1,133 @@
+package logSink
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func createEvent(identifier string) *vmcommon.BuiltInLogEvent {
+	return &vmcommon.BuiltInLogEvent{
+		OriginalTxHash: []byte("original"),
+		CurrentTxHash:  []byte("current"),
+		CallerAddr:     []byte("caller"),
+		Function:       "DCTTransfer",
+		LogEntry: &vmcommon.LogEntry{
+			Identifier: []byte(identifier),
+			Address:    []byte("address"),
+			Topics:     [][]byte{[]byte("TKN-abcdef"), {}, {100}},
+			Data:       [][]byte{[]byte("data")},
+		},
+	}
+}
+
+func TestNewBufferedFanOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid buffer size should error", func(t *testing.T) {
+		t.Parallel()
+
+		fanOut, err := NewBufferedFanOut(ArgsBufferedFanOut{Subscribers: []vmcommon.LogSubscriber{&mock.LogSubscriberStub{}}})
+		assert.True(t, fanOut.IsInterfaceNil())
+		assert.True(t, errors.Is(err, ErrInvalidBufferSize))
+	})
+	t.Run("no subscribers should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewBufferedFanOut(ArgsBufferedFanOut{BufferSize: 1})
+		assert.Equal(t, ErrNoLogSubscribers, err)
+	})
+	t.Run("nil subscriber should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewBufferedFanOut(ArgsBufferedFanOut{
+			BufferSize:  1,
+			Subscribers: []vmcommon.LogSubscriber{&mock.LogSubscriberStub{}, nil},
+		})
+		assert.True(t, errors.Is(err, ErrNilLogSubscriber))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		fanOut, err := NewBufferedFanOut(ArgsBufferedFanOut{
+			BufferSize:  1,
+			Subscribers: []vmcommon.LogSubscriber{&mock.LogSubscriberStub{}},
+		})
+		require.Nil(t, err)
+		assert.False(t, fanOut.IsInterfaceNil())
+		assert.Nil(t, fanOut.Close())
+	})
+}
+
+func TestBufferedFanOut_ReceiveLog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should deliver copies to all subscribers in order", func(t *testing.T) {
+		t.Parallel()
+
+		mut := sync.Mutex{}
+		received := make(map[int][]*vmcommon.BuiltInLogEvent)
+		subscribers := make([]vmcommon.LogSubscriber, 0)
+		for i := 0; i < 3; i++ {
+			index := i
+			subscribers = append(subscribers, &mock.LogSubscriberStub{
+				ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+					mut.Lock()
+					received[index] = append(received[index], event)
+					mut.Unlock()
+				},
+			})
+		}
+		fanOut, err := NewBufferedFanOut(ArgsBufferedFanOut{BufferSize: 10, Subscribers: subscribers})
+		require.Nil(t, err)
+
+		first := createEvent("first")
+		fanOut.ReceiveLog(first)
+		fanOut.ReceiveLog(nil)
+		fanOut.ReceiveLog(createEvent("second"))
+		first.LogEntry.Topics[0][0] = 'X'
+		require.Nil(t, fanOut.Close())
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, []*vmcommon.BuiltInLogEvent{createEvent("first"), createEvent("second")}, received[i])
+		}
+		assert.Equal(t, uint64(0), fanOut.NumDropped())
+	})
+	t.Run("full buffer and closed fan-out should drop", func(t *testing.T) {
+		t.Parallel()
+
+		blocked := make(chan struct{})
+		unblock := make(chan struct{})
+		numReceived := 0
+		subscriber := &mock.LogSubscriberStub{
+			ReceiveLogCalled: func(event *vmcommon.BuiltInLogEvent) {
+				if numReceived == 0 {
+					close(blocked)
+					<-unblock
+				}
+				numReceived++
+			},
+		}
+		fanOut, err := NewBufferedFanOut(ArgsBufferedFanOut{BufferSize: 1, Subscribers: []vmcommon.LogSubscriber{subscriber}})
+		require.Nil(t, err)
+
+		fanOut.ReceiveLog(createEvent("delivering"))
+		<-blocked
+		fanOut.ReceiveLog(createEvent("queued"))
+		fanOut.ReceiveLog(createEvent("dropped"))
+		assert.Equal(t, uint64(1), fanOut.NumDropped())
+
+		close(unblock)
+		require.Nil(t, fanOut.Close())
+		require.Nil(t, fanOut.Close())
+		fanOut.ReceiveLog(createEvent("after close"))
+
+		assert.Equal(t, 2, numReceived)
+		assert.Equal(t, uint64(2), fanOut.NumDropped())
+	})
+}
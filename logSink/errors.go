@@ -0,0 +1,15 @@
+package logSink
+
+import "errors"
+
+// ErrNilLogSubscriber signals that a nil log subscriber was provided
+var ErrNilLogSubscriber = errors.New("nil log subscriber")
+
+// ErrNoLogSubscribers signals that no log subscriber was provided
+var ErrNoLogSubscribers = errors.New("no log subscribers")
+
+// ErrInvalidBufferSize signals that the provided buffer size is not positive
+var ErrInvalidBufferSize = errors.New("invalid buffer size")
+
+// ErrEmptyFilePath signals that an empty file path was provided
+var ErrEmptyFilePath = errors.New("empty file path")
@@ -0,0 +1,144 @@
+package logSink
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	logger "github.com/subrahamanyam341/andes-logger-123"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+var log = logger.GetOrCreate("logSink")
+
+var _ vmcommon.LogSubscriber = (*jsonlFileSink)(nil)
+
+const jsonlFilePermissions = 0644
+
+// ArgsJSONLFileSink defines the arguments needed to create a JSONL file sink
+type ArgsJSONLFileSink struct {
+	FilePath         string
+	AddressConverter core.PubkeyConverter
+}
+
+// LogEventRecord is one line of the JSONL file. The hashes, topics and data are hex encoded and the addresses are
+// encoded with the address converter, or hex encoded if none was provided.
+type LogEventRecord struct {
+	OriginalTxHash string   `json:"originalTxHash"`
+	CurrentTxHash  string   `json:"currentTxHash"`
+	Caller         string   `json:"caller"`
+	Function       string   `json:"function"`
+	Identifier     string   `json:"identifier"`
+	Address        string   `json:"address"`
+	Topics         []string `json:"topics"`
+	Data           []string `json:"data"`
+}
+
+// jsonlFileSink appends every received log as one JSON line to a file, meant for local debugging
+type jsonlFileSink struct {
+	addressConverter core.PubkeyConverter
+
+	mutFile sync.Mutex
+	file    *os.File
+}
+
+// NewJSONLFileSink creates a sink appending to the provided file, which is created if missing
+func NewJSONLFileSink(args ArgsJSONLFileSink) (*jsonlFileSink, error) {
+	if len(args.FilePath) == 0 {
+		return nil, ErrEmptyFilePath
+	}
+
+	file, err := os.OpenFile(args.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, jsonlFilePermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonlFileSink{
+		addressConverter: args.AddressConverter,
+		file:             file,
+	}, nil
+}
+
+// ReceiveLog writes the event as one JSON line. Write errors are logged, as the built-in functions do not wait
+// for the subscribers.
+func (sink *jsonlFileSink) ReceiveLog(event *vmcommon.BuiltInLogEvent) {
+	if event == nil || event.LogEntry == nil {
+		return
+	}
+
+	line, err := json.Marshal(sink.createRecord(event))
+	if err != nil {
+		log.Warn("jsonlFileSink.ReceiveLog: marshal", "error", err)
+		return
+	}
+	line = append(line, '\n')
+
+	sink.mutFile.Lock()
+	defer sink.mutFile.Unlock()
+
+	if sink.file == nil {
+		return
+	}
+	_, err = sink.file.Write(line)
+	if err != nil {
+		log.Warn("jsonlFileSink.ReceiveLog: write", "file", sink.file.Name(), "error", err)
+	}
+}
+
+// Close closes the file; the logs received afterwards are ignored
+func (sink *jsonlFileSink) Close() error {
+	sink.mutFile.Lock()
+	defer sink.mutFile.Unlock()
+
+	if sink.file == nil {
+		return nil
+	}
+
+	err := sink.file.Close()
+	sink.file = nil
+
+	return err
+}
+
+func (sink *jsonlFileSink) createRecord(event *vmcommon.BuiltInLogEvent) *LogEventRecord {
+	return &LogEventRecord{
+		OriginalTxHash: hex.EncodeToString(event.OriginalTxHash),
+		CurrentTxHash:  hex.EncodeToString(event.CurrentTxHash),
+		Caller:         sink.encodeAddress(event.CallerAddr),
+		Function:       event.Function,
+		Identifier:     string(event.LogEntry.Identifier),
+		Address:        sink.encodeAddress(event.LogEntry.Address),
+		Topics:         encodeHexSlices(event.LogEntry.Topics),
+		Data:           encodeHexSlices(event.LogEntry.Data),
+	}
+}
+
+func (sink *jsonlFileSink) encodeAddress(address []byte) string {
+	if check.IfNil(sink.addressConverter) || len(address) != sink.addressConverter.Len() {
+		return hex.EncodeToString(address)
+	}
+
+	encoded, err := sink.addressConverter.Encode(address)
+	if err != nil {
+		return hex.EncodeToString(address)
+	}
+
+	return encoded
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sink *jsonlFileSink) IsInterfaceNil() bool {
+	return sink == nil
+}
+
+func encodeHexSlices(slices [][]byte) []string {
+	encoded := make([]string, 0, len(slices))
+	for _, data := range slices {
+		encoded = append(encoded, hex.EncodeToString(data))
+	}
+
+	return encoded
+}
@@ -0,0 +1,113 @@
+package logSink
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/pubkeyConverter"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func readRecords(t *testing.T, filePath string) []*LogEventRecord {
+	file, err := os.Open(filePath)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records := make([]*LogEventRecord, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &LogEventRecord{}
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), record))
+		records = append(records, record)
+	}
+	require.Nil(t, scanner.Err())
+
+	return records
+}
+
+func TestNewJSONLFileSink(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty file path should error", func(t *testing.T) {
+		t.Parallel()
+
+		sink, err := NewJSONLFileSink(ArgsJSONLFileSink{})
+		assert.True(t, sink.IsInterfaceNil())
+		assert.Equal(t, ErrEmptyFilePath, err)
+	})
+	t.Run("missing directory should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewJSONLFileSink(ArgsJSONLFileSink{FilePath: filepath.Join(t.TempDir(), "missing", "logs.jsonl")})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestJSONLFileSink_ReceiveLog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hex addresses", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "logs.jsonl")
+		sink, err := NewJSONLFileSink(ArgsJSONLFileSink{FilePath: filePath})
+		require.Nil(t, err)
+		assert.False(t, sink.IsInterfaceNil())
+
+		sink.ReceiveLog(createEvent("first"))
+		sink.ReceiveLog(nil)
+		sink.ReceiveLog(&vmcommon.BuiltInLogEvent{})
+		sink.ReceiveLog(createEvent("second"))
+		require.Nil(t, sink.Close())
+		require.Nil(t, sink.Close())
+		sink.ReceiveLog(createEvent("after close"))
+
+		records := readRecords(t, filePath)
+		require.Equal(t, 2, len(records))
+		assert.Equal(t, &LogEventRecord{
+			OriginalTxHash: hex.EncodeToString([]byte("original")),
+			CurrentTxHash:  hex.EncodeToString([]byte("current")),
+			Caller:         hex.EncodeToString([]byte("caller")),
+			Function:       "DCTTransfer",
+			Identifier:     "first",
+			Address:        hex.EncodeToString([]byte("address")),
+			Topics:         []string{hex.EncodeToString([]byte("TKN-abcdef")), "", "64"},
+			Data:           []string{hex.EncodeToString([]byte("data"))},
+		}, records[0])
+		assert.Equal(t, "second", records[1].Identifier)
+	})
+	t.Run("should append to the existing file", func(t *testing.T) {
+		t.Parallel()
+
+		addressConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+		require.Nil(t, err)
+
+		filePath := filepath.Join(t.TempDir(), "logs.jsonl")
+		for i := 0; i < 2; i++ {
+			sink, errCreate := NewJSONLFileSink(ArgsJSONLFileSink{FilePath: filePath, AddressConverter: addressConverter})
+			require.Nil(t, errCreate)
+
+			event := createEvent("transfer")
+			event.CallerAddr = bytes.Repeat([]byte{1}, 32)
+			sink.ReceiveLog(event)
+			require.Nil(t, sink.Close())
+		}
+
+		caller, _ := addressConverter.Encode(bytes.Repeat([]byte{1}, 32))
+		records := readRecords(t, filePath)
+		require.Equal(t, 2, len(records))
+		for _, record := range records {
+			assert.Equal(t, caller, record.Caller)
+			assert.Equal(t, hex.EncodeToString([]byte("address")), record.Address)
+		}
+	})
+}
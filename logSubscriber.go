@@ -0,0 +1,34 @@
+package vmcommon
+
+// BuiltInLogEvent is a log entry emitted by a built-in function, along with the context of the call that emitted it
+type BuiltInLogEvent struct {
+	OriginalTxHash []byte
+	CurrentTxHash  []byte
+	CallerAddr     []byte
+	Function       string
+	LogEntry       *LogEntry
+}
+
+// LogSubscriber receives the log entries of the committed built-in function calls
+type LogSubscriber interface {
+	ReceiveLog(event *BuiltInLogEvent)
+	IsInterfaceNil() bool
+}
+
+// AcceptLogSubscriber defines the functionality of components that can notify log subscribers
+type AcceptLogSubscriber interface {
+	RegisterLogSubscriber(subscriber LogSubscriber) error
+}
+
+// LogPublisher sends the logs of a built-in function call to the log subscribers. The host calls it for the calls
+// processed through DeferredLogsProcessor, only once it kept them, so the subscribers never see the logs of reverted
+// calls.
+type LogPublisher interface {
+	PublishLogs(vmInput *ContractCallInput, vmOutput *VMOutput)
+}
+
+// DeferredLogsProcessor defines a built-in function processor which can run a call without publishing its logs. The
+// hosts which may still revert the call use it and publish the logs through LogPublisher once they kept the call.
+type DeferredLogsProcessor interface {
+	ProcessBuiltinFunctionWithoutLogs(function BuiltinFunction, acntSnd, acntDst UserAccountHandler, vmInput *ContractCallInput) (*VMOutput, error)
+}
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+
+// EpochNotifierStub -
+type EpochNotifierStub struct {
+	RegisterNotifyHandlerCalled func(handler vmcommon.EpochSubscriberHandler)
+}
+
+// RegisterNotifyHandler -
+func (e *EpochNotifierStub) RegisterNotifyHandler(handler vmcommon.EpochSubscriberHandler) {
+	if e.RegisterNotifyHandlerCalled != nil {
+		e.RegisterNotifyHandlerCalled(handler)
+	}
+}
+
+// IsInterfaceNil -
+func (e *EpochNotifierStub) IsInterfaceNil() bool {
+	return e == nil
+}
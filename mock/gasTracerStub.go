@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+
+// GasTracerStub -
+type GasTracerStub struct {
+	TraceGasChargesCalled func(vmInput *vmcommon.ContractCallInput, charges []*vmcommon.GasCharge)
+}
+
+// TraceGasCharges -
+func (g *GasTracerStub) TraceGasCharges(vmInput *vmcommon.ContractCallInput, charges []*vmcommon.GasCharge) {
+	if g.TraceGasChargesCalled != nil {
+		g.TraceGasChargesCalled(vmInput, charges)
+	}
+}
+
+// IsInterfaceNil -
+func (g *GasTracerStub) IsInterfaceNil() bool {
+	return g == nil
+}
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+
+// LogSubscriberStub -
+type LogSubscriberStub struct {
+	ReceiveLogCalled func(event *vmcommon.BuiltInLogEvent)
+}
+
+// ReceiveLog -
+func (l *LogSubscriberStub) ReceiveLog(event *vmcommon.BuiltInLogEvent) {
+	if l.ReceiveLogCalled != nil {
+		l.ReceiveLogCalled(event)
+	}
+}
+
+// IsInterfaceNil -
+func (l *LogSubscriberStub) IsInterfaceNil() bool {
+	return l == nil
+}
@@ -163,6 +163,14 @@ type VMOutput struct {
 	// The logs should be accessible to the UI.
 	// The logs are part of the transaction receipt.
 	Logs []*LogEntry
+
+	// StateDiff lists the account changes written directly by built-in functions, sorted by address.
+	// It is filled only when the execution was recorded.
+	StateDiff []*AccountDiff
+
+	// GasCharges is the breakdown of the gas charged by a built-in function.
+	// It is filled only when a gas tracer is set on the built-in functions.
+	GasCharges []*GasCharge
 }
 
 // GetFirstReturnData is a helper function that returns the first ReturnData of VMOutput, interpreted as specified.
@@ -0,0 +1,615 @@
+package outputCodec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// The binary encoding follows the protobuf wire format. The fields are written in the order of their numbers,
+// the output accounts are sorted by address and the storage updates by offset, so equal outputs always have
+// the same encoding. The field numbers are never reused or renumbered.
+//
+// VMOutput: 1 ReturnData (repeated), 2 ReturnCode, 3 ReturnMessage, 4 GasRemaining, 5 GasRefund,
+// 6 OutputAccounts (repeated), 7 DeletedAccounts (repeated), 8 TouchedAccounts (repeated), 9 Logs (repeated),
+// 10 StateDiff (repeated), 11 GasCharges (repeated)
+//
+// Scalars holding their zero value and nil byte slices, big integers and messages are not written, so a nil
+// value is kept apart from an empty one. The elements of the repeated fields are always written.
+
+// MarshalBinary returns the canonical binary encoding of the VM output
+func MarshalBinary(vmOutput *vmcommon.VMOutput) ([]byte, error) {
+	if vmOutput == nil {
+		return nil, ErrNilVMOutput
+	}
+
+	enc := &encoder{}
+	err := encodeVMOutput(enc, vmOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	return enc.buff, nil
+}
+
+// ComputeHash returns the SHA-256 hash of the canonical binary encoding of the VM output
+func ComputeHash(vmOutput *vmcommon.VMOutput) ([]byte, error) {
+	encoded, err := MarshalBinary(vmOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(encoded)
+	return hash[:], nil
+}
+
+// UnmarshalBinary decodes a VM output encoded by MarshalBinary. The maps of the decoded output are never nil and
+// the empty elements of the repeated byte slice fields are decoded as empty, not nil, slices.
+func UnmarshalBinary(buff []byte) (*vmcommon.VMOutput, error) {
+	vmOutput := &vmcommon.VMOutput{
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+	}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		return decodeVMOutputField(dec, field, wireType, vmOutput)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vmOutput, nil
+}
+
+func encodeVMOutput(enc *encoder, vmOutput *vmcommon.VMOutput) error {
+	for _, returnData := range vmOutput.ReturnData {
+		enc.writeBytes(1, returnData)
+	}
+	enc.writeInt64(2, int64(vmOutput.ReturnCode))
+	enc.writeString(3, vmOutput.ReturnMessage)
+	enc.writeUint64(4, vmOutput.GasRemaining)
+	enc.writeBigInt(5, vmOutput.GasRefund)
+
+	outputAccounts, err := sortedOutputAccounts(vmOutput.OutputAccounts)
+	if err != nil {
+		return err
+	}
+	for _, outputAccount := range outputAccounts {
+		err = enc.writeMessage(6, func(enc *encoder) error {
+			return encodeOutputAccount(enc, outputAccount)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, address := range vmOutput.DeletedAccounts {
+		enc.writeBytes(7, address)
+	}
+	for _, address := range vmOutput.TouchedAccounts {
+		enc.writeBytes(8, address)
+	}
+	for _, logEntry := range vmOutput.Logs {
+		if logEntry == nil {
+			return fmt.Errorf("%w in logs", ErrNilElement)
+		}
+		_ = enc.writeMessage(9, func(enc *encoder) error {
+			encodeLogEntry(enc, logEntry)
+			return nil
+		})
+	}
+	for _, accountDiff := range vmOutput.StateDiff {
+		err = enc.writeMessage(10, func(enc *encoder) error {
+			return encodeAccountDiff(enc, accountDiff)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, gasCharge := range vmOutput.GasCharges {
+		if gasCharge == nil {
+			return fmt.Errorf("%w in gas charges", ErrNilElement)
+		}
+		_ = enc.writeMessage(11, func(enc *encoder) error {
+			encodeGasCharge(enc, gasCharge)
+			return nil
+		})
+	}
+
+	return nil
+}
+
+func decodeVMOutputField(dec *decoder, field uint64, wireType uint64, vmOutput *vmcommon.VMOutput) error {
+	var err error
+	switch field {
+	case 1:
+		var returnData []byte
+		returnData, err = dec.readBytes(wireType)
+		vmOutput.ReturnData = append(vmOutput.ReturnData, returnData)
+	case 2:
+		var returnCode int64
+		returnCode, err = dec.readInt64(wireType)
+		vmOutput.ReturnCode = vmcommon.ReturnCode(returnCode)
+	case 3:
+		vmOutput.ReturnMessage, err = dec.readString(wireType)
+	case 4:
+		vmOutput.GasRemaining, err = dec.readUint64(wireType)
+	case 5:
+		vmOutput.GasRefund, err = dec.readBigInt(wireType)
+	case 6:
+		var outputAccount *vmcommon.OutputAccount
+		outputAccount, err = decodeNested(dec, wireType, decodeOutputAccount)
+		if err != nil {
+			return err
+		}
+		_, found := vmOutput.OutputAccounts[string(outputAccount.Address)]
+		if found {
+			return fmt.Errorf("%w: duplicated output account %x", ErrInvalidEncoding, outputAccount.Address)
+		}
+		vmOutput.OutputAccounts[string(outputAccount.Address)] = outputAccount
+	case 7:
+		var address []byte
+		address, err = dec.readBytes(wireType)
+		vmOutput.DeletedAccounts = append(vmOutput.DeletedAccounts, address)
+	case 8:
+		var address []byte
+		address, err = dec.readBytes(wireType)
+		vmOutput.TouchedAccounts = append(vmOutput.TouchedAccounts, address)
+	case 9:
+		var logEntry *vmcommon.LogEntry
+		logEntry, err = decodeNested(dec, wireType, decodeLogEntry)
+		vmOutput.Logs = append(vmOutput.Logs, logEntry)
+	case 10:
+		var accountDiff *vmcommon.AccountDiff
+		accountDiff, err = decodeNested(dec, wireType, decodeAccountDiff)
+		vmOutput.StateDiff = append(vmOutput.StateDiff, accountDiff)
+	case 11:
+		var gasCharge *vmcommon.GasCharge
+		gasCharge, err = decodeNested(dec, wireType, decodeGasCharge)
+		vmOutput.GasCharges = append(vmOutput.GasCharges, gasCharge)
+	default:
+		err = unknownField(field)
+	}
+
+	return err
+}
+
+// decodeNested reads a length delimited field and decodes it as a message
+func decodeNested[T any](dec *decoder, wireType uint64, decode func(buff []byte) (*T, error)) (*T, error) {
+	buff, err := dec.readBytes(wireType)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(buff)
+}
+
+func sortedOutputAccounts(outputAccounts map[string]*vmcommon.OutputAccount) ([]*vmcommon.OutputAccount, error) {
+	sorted := make([]*vmcommon.OutputAccount, 0, len(outputAccounts))
+	for key, outputAccount := range outputAccounts {
+		if outputAccount == nil {
+			return nil, fmt.Errorf("%w in output accounts", ErrNilElement)
+		}
+		if key != string(outputAccount.Address) {
+			return nil, fmt.Errorf("%w: output account %x", ErrKeyMismatch, outputAccount.Address)
+		}
+
+		sorted = append(sorted, outputAccount)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Address, sorted[j].Address) < 0
+	})
+
+	return sorted, nil
+}
+
+func sortedStorageUpdates(storageUpdates map[string]*vmcommon.StorageUpdate) ([]*vmcommon.StorageUpdate, error) {
+	sorted := make([]*vmcommon.StorageUpdate, 0, len(storageUpdates))
+	for key, storageUpdate := range storageUpdates {
+		if storageUpdate == nil {
+			return nil, fmt.Errorf("%w in storage updates", ErrNilElement)
+		}
+		if key != string(storageUpdate.Offset) {
+			return nil, fmt.Errorf("%w: storage update %x", ErrKeyMismatch, storageUpdate.Offset)
+		}
+
+		sorted = append(sorted, storageUpdate)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Offset, sorted[j].Offset) < 0
+	})
+
+	return sorted, nil
+}
+
+// OutputAccount: 1 Address, 2 Nonce, 3 Balance, 4 StorageUpdates (repeated), 5 Code, 6 CodeMetadata,
+// 7 CodeDeployerAddress, 8 BalanceDelta, 9 OutputTransfers (repeated), 10 GasUsed, 11 BytesAddedToStorage,
+// 12 BytesDeletedFromStorage, 13 BytesConsumedByTxAsNetworking
+func encodeOutputAccount(enc *encoder, outputAccount *vmcommon.OutputAccount) error {
+	enc.writeOptionalBytes(1, outputAccount.Address)
+	enc.writeUint64(2, outputAccount.Nonce)
+	enc.writeBigInt(3, outputAccount.Balance)
+
+	storageUpdates, err := sortedStorageUpdates(outputAccount.StorageUpdates)
+	if err != nil {
+		return fmt.Errorf("%w for output account %x", err, outputAccount.Address)
+	}
+	for _, storageUpdate := range storageUpdates {
+		_ = enc.writeMessage(4, func(enc *encoder) error {
+			encodeStorageUpdate(enc, storageUpdate)
+			return nil
+		})
+	}
+
+	enc.writeOptionalBytes(5, outputAccount.Code)
+	enc.writeOptionalBytes(6, outputAccount.CodeMetadata)
+	enc.writeOptionalBytes(7, outputAccount.CodeDeployerAddress)
+	enc.writeBigInt(8, outputAccount.BalanceDelta)
+	for index := range outputAccount.OutputTransfers {
+		_ = enc.writeMessage(9, func(enc *encoder) error {
+			encodeOutputTransfer(enc, &outputAccount.OutputTransfers[index])
+			return nil
+		})
+	}
+	enc.writeUint64(10, outputAccount.GasUsed)
+	enc.writeUint64(11, outputAccount.BytesAddedToStorage)
+	enc.writeUint64(12, outputAccount.BytesDeletedFromStorage)
+	enc.writeUint64(13, outputAccount.BytesConsumedByTxAsNetworking)
+
+	return nil
+}
+
+func decodeOutputAccount(buff []byte) (*vmcommon.OutputAccount, error) {
+	outputAccount := &vmcommon.OutputAccount{
+		StorageUpdates: make(map[string]*vmcommon.StorageUpdate),
+	}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			outputAccount.Address, err = dec.readBytes(wireType)
+		case 2:
+			outputAccount.Nonce, err = dec.readUint64(wireType)
+		case 3:
+			outputAccount.Balance, err = dec.readBigInt(wireType)
+		case 4:
+			var storageUpdate *vmcommon.StorageUpdate
+			storageUpdate, err = decodeNested(dec, wireType, decodeStorageUpdate)
+			if err != nil {
+				return err
+			}
+			_, found := outputAccount.StorageUpdates[string(storageUpdate.Offset)]
+			if found {
+				return fmt.Errorf("%w: duplicated storage update %x", ErrInvalidEncoding, storageUpdate.Offset)
+			}
+			outputAccount.StorageUpdates[string(storageUpdate.Offset)] = storageUpdate
+		case 5:
+			outputAccount.Code, err = dec.readBytes(wireType)
+		case 6:
+			outputAccount.CodeMetadata, err = dec.readBytes(wireType)
+		case 7:
+			outputAccount.CodeDeployerAddress, err = dec.readBytes(wireType)
+		case 8:
+			outputAccount.BalanceDelta, err = dec.readBigInt(wireType)
+		case 9:
+			var outputTransfer *vmcommon.OutputTransfer
+			outputTransfer, err = decodeNested(dec, wireType, decodeOutputTransfer)
+			if err == nil {
+				outputAccount.OutputTransfers = append(outputAccount.OutputTransfers, *outputTransfer)
+			}
+		case 10:
+			outputAccount.GasUsed, err = dec.readUint64(wireType)
+		case 11:
+			outputAccount.BytesAddedToStorage, err = dec.readUint64(wireType)
+		case 12:
+			outputAccount.BytesDeletedFromStorage, err = dec.readUint64(wireType)
+		case 13:
+			outputAccount.BytesConsumedByTxAsNetworking, err = dec.readUint64(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return outputAccount, err
+}
+
+// StorageUpdate: 1 Offset, 2 Data, 3 Written
+func encodeStorageUpdate(enc *encoder, storageUpdate *vmcommon.StorageUpdate) {
+	enc.writeOptionalBytes(1, storageUpdate.Offset)
+	enc.writeOptionalBytes(2, storageUpdate.Data)
+	enc.writeBool(3, storageUpdate.Written)
+}
+
+func decodeStorageUpdate(buff []byte) (*vmcommon.StorageUpdate, error) {
+	storageUpdate := &vmcommon.StorageUpdate{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			storageUpdate.Offset, err = dec.readBytes(wireType)
+		case 2:
+			storageUpdate.Data, err = dec.readBytes(wireType)
+		case 3:
+			storageUpdate.Written, err = dec.readBool(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return storageUpdate, err
+}
+
+// OutputTransfer: 1 Index, 2 Value, 3 GasLimit, 4 GasLocked, 5 AsyncData, 6 Data, 7 CallType, 8 SenderAddress
+func encodeOutputTransfer(enc *encoder, outputTransfer *vmcommon.OutputTransfer) {
+	enc.writeUint64(1, uint64(outputTransfer.Index))
+	enc.writeBigInt(2, outputTransfer.Value)
+	enc.writeUint64(3, outputTransfer.GasLimit)
+	enc.writeUint64(4, outputTransfer.GasLocked)
+	enc.writeOptionalBytes(5, outputTransfer.AsyncData)
+	enc.writeOptionalBytes(6, outputTransfer.Data)
+	enc.writeInt64(7, int64(outputTransfer.CallType))
+	enc.writeOptionalBytes(8, outputTransfer.SenderAddress)
+}
+
+func decodeOutputTransfer(buff []byte) (*vmcommon.OutputTransfer, error) {
+	outputTransfer := &vmcommon.OutputTransfer{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			var index uint64
+			index, err = dec.readUint64(wireType)
+			if err == nil && index > uint64(^uint32(0)) {
+				err = fmt.Errorf("%w: transfer index %d", ErrInvalidEncoding, index)
+			}
+			outputTransfer.Index = uint32(index)
+		case 2:
+			outputTransfer.Value, err = dec.readBigInt(wireType)
+		case 3:
+			outputTransfer.GasLimit, err = dec.readUint64(wireType)
+		case 4:
+			outputTransfer.GasLocked, err = dec.readUint64(wireType)
+		case 5:
+			outputTransfer.AsyncData, err = dec.readBytes(wireType)
+		case 6:
+			outputTransfer.Data, err = dec.readBytes(wireType)
+		case 7:
+			var callType int64
+			callType, err = dec.readInt64(wireType)
+			outputTransfer.CallType = vm.CallType(callType)
+		case 8:
+			outputTransfer.SenderAddress, err = dec.readBytes(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return outputTransfer, err
+}
+
+// LogEntry: 1 Identifier, 2 Address, 3 Topics (repeated), 4 Data (repeated)
+func encodeLogEntry(enc *encoder, logEntry *vmcommon.LogEntry) {
+	enc.writeOptionalBytes(1, logEntry.Identifier)
+	enc.writeOptionalBytes(2, logEntry.Address)
+	for _, topic := range logEntry.Topics {
+		enc.writeBytes(3, topic)
+	}
+	for _, data := range logEntry.Data {
+		enc.writeBytes(4, data)
+	}
+}
+
+func decodeLogEntry(buff []byte) (*vmcommon.LogEntry, error) {
+	logEntry := &vmcommon.LogEntry{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		var value []byte
+		switch field {
+		case 1:
+			logEntry.Identifier, err = dec.readBytes(wireType)
+		case 2:
+			logEntry.Address, err = dec.readBytes(wireType)
+		case 3:
+			value, err = dec.readBytes(wireType)
+			logEntry.Topics = append(logEntry.Topics, value)
+		case 4:
+			value, err = dec.readBytes(wireType)
+			logEntry.Data = append(logEntry.Data, value)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return logEntry, err
+}
+
+// AccountDiff: 1 Address, 2 NonceDelta, 3 BalanceDelta, 4 DeveloperRewardDelta, 5 OwnerAddress, 6 UserName,
+// 7 CodeMetadata, 8 StorageReads (repeated), 9 StorageChanges (repeated)
+func encodeAccountDiff(enc *encoder, accountDiff *vmcommon.AccountDiff) error {
+	if accountDiff == nil {
+		return fmt.Errorf("%w in state diff", ErrNilElement)
+	}
+
+	enc.writeOptionalBytes(1, accountDiff.Address)
+	enc.writeUint64(2, accountDiff.NonceDelta)
+	enc.writeBigInt(3, accountDiff.BalanceDelta)
+	enc.writeBigInt(4, accountDiff.DeveloperRewardDelta)
+	encodeValueChange(enc, 5, accountDiff.OwnerAddress)
+	encodeValueChange(enc, 6, accountDiff.UserName)
+	encodeValueChange(enc, 7, accountDiff.CodeMetadata)
+	for _, storageRead := range accountDiff.StorageReads {
+		if storageRead == nil {
+			return fmt.Errorf("%w in storage reads of %x", ErrNilElement, accountDiff.Address)
+		}
+		_ = enc.writeMessage(8, func(enc *encoder) error {
+			enc.writeOptionalBytes(1, storageRead.Key)
+			enc.writeOptionalBytes(2, storageRead.Value)
+			return nil
+		})
+	}
+	for _, storageChange := range accountDiff.StorageChanges {
+		if storageChange == nil {
+			return fmt.Errorf("%w in storage changes of %x", ErrNilElement, accountDiff.Address)
+		}
+		_ = enc.writeMessage(9, func(enc *encoder) error {
+			enc.writeOptionalBytes(1, storageChange.Key)
+			enc.writeOptionalBytes(2, storageChange.OldValue)
+			enc.writeOptionalBytes(3, storageChange.NewValue)
+			return nil
+		})
+	}
+
+	return nil
+}
+
+func decodeAccountDiff(buff []byte) (*vmcommon.AccountDiff, error) {
+	accountDiff := &vmcommon.AccountDiff{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			accountDiff.Address, err = dec.readBytes(wireType)
+		case 2:
+			accountDiff.NonceDelta, err = dec.readUint64(wireType)
+		case 3:
+			accountDiff.BalanceDelta, err = dec.readBigInt(wireType)
+		case 4:
+			accountDiff.DeveloperRewardDelta, err = dec.readBigInt(wireType)
+		case 5:
+			accountDiff.OwnerAddress, err = decodeNested(dec, wireType, decodeValueChange)
+		case 6:
+			accountDiff.UserName, err = decodeNested(dec, wireType, decodeValueChange)
+		case 7:
+			accountDiff.CodeMetadata, err = decodeNested(dec, wireType, decodeValueChange)
+		case 8:
+			var storageRead *vmcommon.StorageRead
+			storageRead, err = decodeNested(dec, wireType, decodeStorageRead)
+			accountDiff.StorageReads = append(accountDiff.StorageReads, storageRead)
+		case 9:
+			var storageChange *vmcommon.StorageChange
+			storageChange, err = decodeNested(dec, wireType, decodeStorageChange)
+			accountDiff.StorageChanges = append(accountDiff.StorageChanges, storageChange)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return accountDiff, err
+}
+
+// ValueChange: 1 Old, 2 New
+func encodeValueChange(enc *encoder, field uint64, valueChange *vmcommon.ValueChange) {
+	if valueChange == nil {
+		return
+	}
+
+	_ = enc.writeMessage(field, func(enc *encoder) error {
+		enc.writeOptionalBytes(1, valueChange.Old)
+		enc.writeOptionalBytes(2, valueChange.New)
+		return nil
+	})
+}
+
+func decodeValueChange(buff []byte) (*vmcommon.ValueChange, error) {
+	valueChange := &vmcommon.ValueChange{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			valueChange.Old, err = dec.readBytes(wireType)
+		case 2:
+			valueChange.New, err = dec.readBytes(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return valueChange, err
+}
+
+// StorageRead: 1 Key, 2 Value
+func decodeStorageRead(buff []byte) (*vmcommon.StorageRead, error) {
+	storageRead := &vmcommon.StorageRead{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			storageRead.Key, err = dec.readBytes(wireType)
+		case 2:
+			storageRead.Value, err = dec.readBytes(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return storageRead, err
+}
+
+// StorageChange: 1 Key, 2 OldValue, 3 NewValue
+func decodeStorageChange(buff []byte) (*vmcommon.StorageChange, error) {
+	storageChange := &vmcommon.StorageChange{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			storageChange.Key, err = dec.readBytes(wireType)
+		case 2:
+			storageChange.OldValue, err = dec.readBytes(wireType)
+		case 3:
+			storageChange.NewValue, err = dec.readBytes(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return storageChange, err
+}
+
+// GasCharge: 1 Category, 2 CostParameter, 3 Units, 4 Amount
+func encodeGasCharge(enc *encoder, gasCharge *vmcommon.GasCharge) {
+	enc.writeString(1, gasCharge.Category)
+	enc.writeString(2, gasCharge.CostParameter)
+	enc.writeUint64(3, gasCharge.Units)
+	enc.writeUint64(4, gasCharge.Amount)
+}
+
+func decodeGasCharge(buff []byte) (*vmcommon.GasCharge, error) {
+	gasCharge := &vmcommon.GasCharge{}
+	err := decodeMessage(buff, func(dec *decoder, field uint64, wireType uint64) error {
+		var err error
+		switch field {
+		case 1:
+			gasCharge.Category, err = dec.readString(wireType)
+		case 2:
+			gasCharge.CostParameter, err = dec.readString(wireType)
+		case 3:
+			gasCharge.Units, err = dec.readUint64(wireType)
+		case 4:
+			gasCharge.Amount, err = dec.readUint64(wireType)
+		default:
+			err = unknownField(field)
+		}
+
+		return err
+	})
+
+	return gasCharge, err
+}
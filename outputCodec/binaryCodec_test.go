@@ -0,0 +1,273 @@
+package outputCodec
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func createAddress(lastByte byte) []byte {
+	address := bytes.Repeat([]byte{1}, 32)
+	address[31] = lastByte
+
+	return address
+}
+
+// createVMOutput creates a VM output in the form returned by the decoders: the maps are not nil
+func createVMOutput() *vmcommon.VMOutput {
+	alice := createAddress(1)
+	bob := createAddress(2)
+
+	return &vmcommon.VMOutput{
+		ReturnData:    [][]byte{[]byte("ok"), {}},
+		ReturnCode:    vmcommon.Ok,
+		ReturnMessage: "message",
+		GasRemaining:  1000,
+		GasRefund:     big.NewInt(0),
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			string(alice): {
+				Address:      alice,
+				Nonce:        4,
+				BalanceDelta: big.NewInt(-150),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"b": {Offset: []byte("b"), Data: []byte("value"), Written: true},
+					"a": {Offset: []byte("a"), Data: []byte{}},
+					"c": {Offset: []byte("c")},
+				},
+				OutputTransfers: []vmcommon.OutputTransfer{
+					{
+						Index:         2,
+						Value:         big.NewInt(150),
+						GasLimit:      10,
+						GasLocked:     5,
+						AsyncData:     []byte("async"),
+						Data:          []byte("DCTTransfer@01"),
+						CallType:      vm.AsynchronousCall,
+						SenderAddress: alice,
+					},
+				},
+				GasUsed:             7,
+				BytesAddedToStorage: 3,
+			},
+			string(bob): {
+				Address:             bob,
+				Balance:             big.NewInt(10),
+				BalanceDelta:        big.NewInt(150),
+				StorageUpdates:      make(map[string]*vmcommon.StorageUpdate),
+				Code:                []byte("code"),
+				CodeMetadata:        []byte{5, 0},
+				CodeDeployerAddress: alice,
+			},
+		},
+		DeletedAccounts: [][]byte{bob},
+		TouchedAccounts: [][]byte{alice, bob},
+		Logs: []*vmcommon.LogEntry{
+			{
+				Identifier: []byte("DCTTransfer"),
+				Address:    alice,
+				Topics:     [][]byte{[]byte("TKN-abcdef"), {}, big.NewInt(150).Bytes(), bob},
+				Data:       [][]byte{[]byte("data")},
+			},
+		},
+		StateDiff: []*vmcommon.AccountDiff{
+			{
+				Address:              alice,
+				NonceDelta:           1,
+				BalanceDelta:         big.NewInt(-150),
+				DeveloperRewardDelta: big.NewInt(0),
+				UserName:             &vmcommon.ValueChange{New: []byte("alice")},
+				StorageReads:         []*vmcommon.StorageRead{{Key: []byte("a")}, {Key: []byte("b"), Value: []byte("old")}},
+				StorageChanges:       []*vmcommon.StorageChange{{Key: []byte("b"), OldValue: []byte("old"), NewValue: []byte("value")}},
+			},
+		},
+		GasCharges: []*vmcommon.GasCharge{
+			{Category: "BuiltInCost", CostParameter: "DCTTransfer", Units: 1, Amount: 10},
+		},
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil VM output should error", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := MarshalBinary(nil)
+		assert.Nil(t, encoded)
+		assert.Equal(t, ErrNilVMOutput, err)
+	})
+	t.Run("should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createVMOutput()
+		encoded, err := MarshalBinary(vmOutput)
+		require.Nil(t, err)
+
+		decoded, err := UnmarshalBinary(encoded)
+		require.Nil(t, err)
+		assert.Equal(t, vmOutput, decoded)
+	})
+	t.Run("empty VM output should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := MarshalBinary(&vmcommon.VMOutput{})
+		require.Nil(t, err)
+		assert.Empty(t, encoded)
+
+		decoded, err := UnmarshalBinary(encoded)
+		require.Nil(t, err)
+		assert.Equal(t, &vmcommon.VMOutput{OutputAccounts: make(map[string]*vmcommon.OutputAccount)}, decoded)
+	})
+	t.Run("should not depend on the maps order", func(t *testing.T) {
+		t.Parallel()
+
+		expected, err := MarshalBinary(createVMOutput())
+		require.Nil(t, err)
+		for i := 0; i < 20; i++ {
+			encoded, errMarshal := MarshalBinary(createVMOutput())
+			require.Nil(t, errMarshal)
+			assert.Equal(t, expected, encoded)
+		}
+	})
+	t.Run("should keep nil apart from empty", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createVMOutput()
+		hashNil, err := ComputeHash(vmOutput)
+		require.Nil(t, err)
+
+		vmOutput.StateDiff[0].StorageReads[0].Value = []byte{}
+		hashEmpty, err := ComputeHash(vmOutput)
+		require.Nil(t, err)
+		assert.NotEqual(t, hashNil, hashEmpty)
+	})
+	t.Run("invalid VM outputs should error", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createVMOutput()
+		vmOutput.OutputAccounts["other"] = vmOutput.OutputAccounts[string(createAddress(1))]
+		_, err := MarshalBinary(vmOutput)
+		assert.True(t, errors.Is(err, ErrKeyMismatch))
+
+		vmOutput = createVMOutput()
+		vmOutput.OutputAccounts[string(createAddress(1))].StorageUpdates["z"] = &vmcommon.StorageUpdate{Offset: []byte("y")}
+		_, err = MarshalBinary(vmOutput)
+		assert.True(t, errors.Is(err, ErrKeyMismatch))
+
+		vmOutput = createVMOutput()
+		vmOutput.Logs = append(vmOutput.Logs, nil)
+		_, err = MarshalBinary(vmOutput)
+		assert.True(t, errors.Is(err, ErrNilElement))
+
+		vmOutput = createVMOutput()
+		vmOutput.StateDiff[0].StorageChanges = append(vmOutput.StateDiff[0].StorageChanges, nil)
+		_, err = MarshalBinary(vmOutput)
+		assert.True(t, errors.Is(err, ErrNilElement))
+	})
+}
+
+func TestComputeHash(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := createVMOutput()
+	hash, err := ComputeHash(vmOutput)
+	require.Nil(t, err)
+	assert.Equal(t, 32, len(hash))
+
+	changes := []func(vmOutput *vmcommon.VMOutput){
+		func(vmOutput *vmcommon.VMOutput) { vmOutput.GasRemaining++ },
+		func(vmOutput *vmcommon.VMOutput) { vmOutput.ReturnCode = vmcommon.UserError },
+		func(vmOutput *vmcommon.VMOutput) { vmOutput.GasRefund = nil },
+		func(vmOutput *vmcommon.VMOutput) {
+			vmOutput.OutputAccounts[string(createAddress(1))].BalanceDelta = big.NewInt(150)
+		},
+		func(vmOutput *vmcommon.VMOutput) {
+			vmOutput.OutputAccounts[string(createAddress(1))].StorageUpdates["a"].Written = true
+		},
+		func(vmOutput *vmcommon.VMOutput) { vmOutput.Logs[0].Topics[1] = []byte{0} },
+		func(vmOutput *vmcommon.VMOutput) { vmOutput.StateDiff[0].UserName.Old = []byte{} },
+		func(vmOutput *vmcommon.VMOutput) { vmOutput.GasCharges[0].Amount++ },
+		func(vmOutput *vmcommon.VMOutput) {
+			vmOutput.TouchedAccounts[0], vmOutput.TouchedAccounts[1] = vmOutput.TouchedAccounts[1], vmOutput.TouchedAccounts[0]
+		},
+	}
+	for index, change := range changes {
+		changed := createVMOutput()
+		change(changed)
+
+		changedHash, errHash := ComputeHash(changed)
+		require.Nil(t, errHash)
+		assert.NotEqual(t, hash, changedHash, index)
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := MarshalBinary(createVMOutput())
+	require.Nil(t, err)
+
+	t.Run("truncated data should error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, length := range []int{1, 10, len(encoded) / 2, len(encoded) - 1} {
+			_, errDecode := UnmarshalBinary(encoded[:length])
+			assert.True(t, errors.Is(errDecode, ErrInvalidEncoding), length)
+		}
+	})
+	t.Run("unknown field should error", func(t *testing.T) {
+		t.Parallel()
+
+		enc := &encoder{}
+		enc.writeUint64(100, 1)
+		_, errDecode := UnmarshalBinary(enc.buff)
+		assert.True(t, errors.Is(errDecode, ErrInvalidEncoding))
+	})
+	t.Run("wrong wire type should error", func(t *testing.T) {
+		t.Parallel()
+
+		enc := &encoder{}
+		enc.writeBytes(4, []byte("gas"))
+		_, errDecode := UnmarshalBinary(enc.buff)
+		assert.True(t, errors.Is(errDecode, ErrInvalidEncoding))
+	})
+	t.Run("non canonical big integers should error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, value := range [][]byte{{}, {2, 1}, {0, 0, 1}, {1}} {
+			enc := &encoder{}
+			enc.writeBytes(5, value)
+			_, errDecode := UnmarshalBinary(enc.buff)
+			assert.True(t, errors.Is(errDecode, ErrInvalidEncoding), value)
+		}
+	})
+	t.Run("duplicated output accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		enc := &encoder{}
+		for i := 0; i < 2; i++ {
+			_ = enc.writeMessage(6, func(enc *encoder) error {
+				enc.writeOptionalBytes(1, createAddress(1))
+				return nil
+			})
+		}
+		_, errDecode := UnmarshalBinary(enc.buff)
+		assert.True(t, errors.Is(errDecode, ErrInvalidEncoding))
+	})
+	t.Run("decoded output should encode the same", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, errDecode := UnmarshalBinary(encoded)
+		require.Nil(t, errDecode)
+
+		reencoded, errEncode := MarshalBinary(decoded)
+		require.Nil(t, errEncode)
+		assert.Equal(t, encoded, reencoded)
+	})
+}
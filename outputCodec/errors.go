@@ -0,0 +1,21 @@
+package outputCodec
+
+import "errors"
+
+// ErrNilVMOutput signals that a nil VM output was provided
+var ErrNilVMOutput = errors.New("nil VM output")
+
+// ErrNilElement signals that the VM output holds a nil element
+var ErrNilElement = errors.New("nil element")
+
+// ErrKeyMismatch signals that a map key of the VM output does not match the address or the offset of its element
+var ErrKeyMismatch = errors.New("map key does not match the element")
+
+// ErrInvalidEncoding signals that the provided data is not a valid encoding of a VM output
+var ErrInvalidEncoding = errors.New("invalid VM output encoding")
+
+// ErrUnsupportedVersion signals that the VM output was encoded with an unknown format version
+var ErrUnsupportedVersion = errors.New("unsupported VM output encoding version")
+
+// ErrHashMismatch signals that the hash of the decoded VM output differs from the encoded one
+var ErrHashMismatch = errors.New("VM output hash mismatch")
@@ -0,0 +1,762 @@
+package outputCodec
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// currentJSONVersion is the version of the encoding written by the JSON codec
+const currentJSONVersion = 1
+
+// hexPrefix marks the hex encoded addresses which do not have the length expected by the address converter
+const hexPrefix = "0x"
+
+// ArgsJSONCodec defines the arguments needed to create a JSON codec
+type ArgsJSONCodec struct {
+	// AddressConverter encodes the addresses, for example as bech32. If nil, the addresses are hex encoded.
+	AddressConverter core.PubkeyConverter
+}
+
+// jsonVMOutput is the JSON form of a VM output. It has the same canonical ordering as the binary encoding and
+// holds the hash of the binary encoding. Byte slices are hex encoded and big integers are decimal strings. The
+// optional fields are pointers, so a nil value is kept apart from an empty one.
+type jsonVMOutput struct {
+	Version         uint32               `json:"version"`
+	Hash            string               `json:"hash"`
+	ReturnData      []string             `json:"returnData,omitempty"`
+	ReturnCode      vmcommon.ReturnCode  `json:"returnCode"`
+	ReturnMessage   string               `json:"returnMessage,omitempty"`
+	GasRemaining    uint64               `json:"gasRemaining,omitempty"`
+	GasRefund       *string              `json:"gasRefund,omitempty"`
+	OutputAccounts  []*jsonOutputAccount `json:"outputAccounts,omitempty"`
+	DeletedAccounts []string             `json:"deletedAccounts,omitempty"`
+	TouchedAccounts []string             `json:"touchedAccounts,omitempty"`
+	Logs            []*jsonLogEntry      `json:"logs,omitempty"`
+	StateDiff       []*jsonAccountDiff   `json:"stateDiff,omitempty"`
+	GasCharges      []*jsonGasCharge     `json:"gasCharges,omitempty"`
+}
+
+type jsonOutputAccount struct {
+	Address                       *string               `json:"address,omitempty"`
+	Nonce                         uint64                `json:"nonce,omitempty"`
+	Balance                       *string               `json:"balance,omitempty"`
+	StorageUpdates                []*jsonStorageUpdate  `json:"storageUpdates,omitempty"`
+	Code                          *string               `json:"code,omitempty"`
+	CodeMetadata                  *string               `json:"codeMetadata,omitempty"`
+	CodeDeployerAddress           *string               `json:"codeDeployerAddress,omitempty"`
+	BalanceDelta                  *string               `json:"balanceDelta,omitempty"`
+	OutputTransfers               []*jsonOutputTransfer `json:"outputTransfers,omitempty"`
+	GasUsed                       uint64                `json:"gasUsed,omitempty"`
+	BytesAddedToStorage           uint64                `json:"bytesAddedToStorage,omitempty"`
+	BytesDeletedFromStorage       uint64                `json:"bytesDeletedFromStorage,omitempty"`
+	BytesConsumedByTxAsNetworking uint64                `json:"bytesConsumedByTxAsNetworking,omitempty"`
+}
+
+type jsonStorageUpdate struct {
+	Offset  *string `json:"offset,omitempty"`
+	Data    *string `json:"data,omitempty"`
+	Written bool    `json:"written,omitempty"`
+}
+
+type jsonOutputTransfer struct {
+	Index         uint32      `json:"index,omitempty"`
+	Value         *string     `json:"value,omitempty"`
+	GasLimit      uint64      `json:"gasLimit,omitempty"`
+	GasLocked     uint64      `json:"gasLocked,omitempty"`
+	AsyncData     *string     `json:"asyncData,omitempty"`
+	Data          *string     `json:"data,omitempty"`
+	CallType      vm.CallType `json:"callType,omitempty"`
+	SenderAddress *string     `json:"senderAddress,omitempty"`
+}
+
+type jsonLogEntry struct {
+	Identifier *string  `json:"identifier,omitempty"`
+	Address    *string  `json:"address,omitempty"`
+	Topics     []string `json:"topics,omitempty"`
+	Data       []string `json:"data,omitempty"`
+}
+
+type jsonAccountDiff struct {
+	Address              *string              `json:"address,omitempty"`
+	NonceDelta           uint64               `json:"nonceDelta,omitempty"`
+	BalanceDelta         *string              `json:"balanceDelta,omitempty"`
+	DeveloperRewardDelta *string              `json:"developerRewardDelta,omitempty"`
+	OwnerAddress         *jsonValueChange     `json:"ownerAddress,omitempty"`
+	UserName             *jsonValueChange     `json:"userName,omitempty"`
+	CodeMetadata         *jsonValueChange     `json:"codeMetadata,omitempty"`
+	StorageReads         []*jsonStorageRead   `json:"storageReads,omitempty"`
+	StorageChanges       []*jsonStorageChange `json:"storageChanges,omitempty"`
+}
+
+type jsonValueChange struct {
+	Old *string `json:"old,omitempty"`
+	New *string `json:"new,omitempty"`
+}
+
+type jsonStorageRead struct {
+	Key   *string `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type jsonStorageChange struct {
+	Key      *string `json:"key,omitempty"`
+	OldValue *string `json:"oldValue,omitempty"`
+	NewValue *string `json:"newValue,omitempty"`
+}
+
+type jsonGasCharge struct {
+	Category      string `json:"category,omitempty"`
+	CostParameter string `json:"costParameter,omitempty"`
+	Units         uint64 `json:"units,omitempty"`
+	Amount        uint64 `json:"amount,omitempty"`
+}
+
+type jsonCodec struct {
+	addressConverter core.PubkeyConverter
+}
+
+// NewJSONCodec creates a codec which encodes VM outputs as canonical JSON documents
+func NewJSONCodec(args ArgsJSONCodec) *jsonCodec {
+	codec := &jsonCodec{}
+	if !check.IfNil(args.AddressConverter) {
+		codec.addressConverter = args.AddressConverter
+	}
+
+	return codec
+}
+
+// Marshal returns the canonical JSON encoding of the VM output, which holds the hash of its binary encoding
+func (codec *jsonCodec) Marshal(vmOutput *vmcommon.VMOutput) ([]byte, error) {
+	hash, err := ComputeHash(vmOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	// the binary encoding was already validated, so the sorting can not fail
+	outputAccounts, _ := sortedOutputAccounts(vmOutput.OutputAccounts)
+	encoded := &jsonVMOutput{
+		Version:       currentJSONVersion,
+		Hash:          hex.EncodeToString(hash),
+		ReturnData:    encodeRepeatedBytes(vmOutput.ReturnData),
+		ReturnCode:    vmOutput.ReturnCode,
+		ReturnMessage: vmOutput.ReturnMessage,
+		GasRemaining:  vmOutput.GasRemaining,
+		GasRefund:     encodeBigInt(vmOutput.GasRefund),
+	}
+	for _, outputAccount := range outputAccounts {
+		account, errEncode := codec.encodeOutputAccount(outputAccount)
+		if errEncode != nil {
+			return nil, errEncode
+		}
+		encoded.OutputAccounts = append(encoded.OutputAccounts, account)
+	}
+	encoded.DeletedAccounts, err = codec.encodeAddresses(vmOutput.DeletedAccounts)
+	if err != nil {
+		return nil, err
+	}
+	encoded.TouchedAccounts, err = codec.encodeAddresses(vmOutput.TouchedAccounts)
+	if err != nil {
+		return nil, err
+	}
+	for _, logEntry := range vmOutput.Logs {
+		entry, errEncode := codec.encodeLogEntry(logEntry)
+		if errEncode != nil {
+			return nil, errEncode
+		}
+		encoded.Logs = append(encoded.Logs, entry)
+	}
+	for _, accountDiff := range vmOutput.StateDiff {
+		diff, errEncode := codec.encodeAccountDiff(accountDiff)
+		if errEncode != nil {
+			return nil, errEncode
+		}
+		encoded.StateDiff = append(encoded.StateDiff, diff)
+	}
+	for _, gasCharge := range vmOutput.GasCharges {
+		encoded.GasCharges = append(encoded.GasCharges, &jsonGasCharge{
+			Category:      gasCharge.Category,
+			CostParameter: gasCharge.CostParameter,
+			Units:         gasCharge.Units,
+			Amount:        gasCharge.Amount,
+		})
+	}
+
+	return json.Marshal(encoded)
+}
+
+// Unmarshal decodes a VM output encoded by Marshal and checks it against the hash held by the document
+func (codec *jsonCodec) Unmarshal(data []byte) (*vmcommon.VMOutput, error) {
+	decoded := &jsonVMOutput{}
+	err := json.Unmarshal(data, decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	if decoded.Version != currentJSONVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, decoded.Version)
+	}
+
+	vmOutput, err := codec.decodeVMOutput(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+
+	hash, err := ComputeHash(vmOutput)
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(hash) != decoded.Hash {
+		return nil, fmt.Errorf("%w: computed %x, encoded %s", ErrHashMismatch, hash, decoded.Hash)
+	}
+
+	return vmOutput, nil
+}
+
+func (codec *jsonCodec) decodeVMOutput(decoded *jsonVMOutput) (*vmcommon.VMOutput, error) {
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:     decoded.ReturnCode,
+		ReturnMessage:  decoded.ReturnMessage,
+		GasRemaining:   decoded.GasRemaining,
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount, len(decoded.OutputAccounts)),
+	}
+
+	var err error
+	vmOutput.ReturnData, err = decodeRepeatedBytes(decoded.ReturnData)
+	if err != nil {
+		return nil, err
+	}
+	vmOutput.GasRefund, err = decodeBigInt(decoded.GasRefund)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range decoded.OutputAccounts {
+		if account == nil {
+			return nil, fmt.Errorf("%w in output accounts", ErrNilElement)
+		}
+
+		outputAccount, errDecode := codec.decodeOutputAccount(account)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		_, found := vmOutput.OutputAccounts[string(outputAccount.Address)]
+		if found {
+			return nil, fmt.Errorf("duplicated output account %x", outputAccount.Address)
+		}
+		vmOutput.OutputAccounts[string(outputAccount.Address)] = outputAccount
+	}
+	vmOutput.DeletedAccounts, err = codec.decodeAddresses(decoded.DeletedAccounts)
+	if err != nil {
+		return nil, err
+	}
+	vmOutput.TouchedAccounts, err = codec.decodeAddresses(decoded.TouchedAccounts)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range decoded.Logs {
+		logEntry, errDecode := codec.decodeLogEntry(entry)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		vmOutput.Logs = append(vmOutput.Logs, logEntry)
+	}
+	for _, diff := range decoded.StateDiff {
+		accountDiff, errDecode := codec.decodeAccountDiff(diff)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		vmOutput.StateDiff = append(vmOutput.StateDiff, accountDiff)
+	}
+	for _, charge := range decoded.GasCharges {
+		if charge == nil {
+			return nil, fmt.Errorf("%w in gas charges", ErrNilElement)
+		}
+		vmOutput.GasCharges = append(vmOutput.GasCharges, &vmcommon.GasCharge{
+			Category:      charge.Category,
+			CostParameter: charge.CostParameter,
+			Units:         charge.Units,
+			Amount:        charge.Amount,
+		})
+	}
+
+	return vmOutput, nil
+}
+
+func (codec *jsonCodec) encodeOutputAccount(outputAccount *vmcommon.OutputAccount) (*jsonOutputAccount, error) {
+	address, err := codec.encodeAddress(outputAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	codeDeployerAddress, err := codec.encodeAddress(outputAccount.CodeDeployerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &jsonOutputAccount{
+		Address:                       address,
+		Nonce:                         outputAccount.Nonce,
+		Balance:                       encodeBigInt(outputAccount.Balance),
+		Code:                          encodeBytes(outputAccount.Code),
+		CodeMetadata:                  encodeBytes(outputAccount.CodeMetadata),
+		CodeDeployerAddress:           codeDeployerAddress,
+		BalanceDelta:                  encodeBigInt(outputAccount.BalanceDelta),
+		GasUsed:                       outputAccount.GasUsed,
+		BytesAddedToStorage:           outputAccount.BytesAddedToStorage,
+		BytesDeletedFromStorage:       outputAccount.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: outputAccount.BytesConsumedByTxAsNetworking,
+	}
+
+	// the storage updates were already validated by the binary encoding
+	storageUpdates, _ := sortedStorageUpdates(outputAccount.StorageUpdates)
+	for _, storageUpdate := range storageUpdates {
+		account.StorageUpdates = append(account.StorageUpdates, &jsonStorageUpdate{
+			Offset:  encodeBytes(storageUpdate.Offset),
+			Data:    encodeBytes(storageUpdate.Data),
+			Written: storageUpdate.Written,
+		})
+	}
+	for _, outputTransfer := range outputAccount.OutputTransfers {
+		senderAddress, errEncode := codec.encodeAddress(outputTransfer.SenderAddress)
+		if errEncode != nil {
+			return nil, errEncode
+		}
+
+		account.OutputTransfers = append(account.OutputTransfers, &jsonOutputTransfer{
+			Index:         outputTransfer.Index,
+			Value:         encodeBigInt(outputTransfer.Value),
+			GasLimit:      outputTransfer.GasLimit,
+			GasLocked:     outputTransfer.GasLocked,
+			AsyncData:     encodeBytes(outputTransfer.AsyncData),
+			Data:          encodeBytes(outputTransfer.Data),
+			CallType:      outputTransfer.CallType,
+			SenderAddress: senderAddress,
+		})
+	}
+
+	return account, nil
+}
+
+func (codec *jsonCodec) decodeOutputAccount(account *jsonOutputAccount) (*vmcommon.OutputAccount, error) {
+	outputAccount := &vmcommon.OutputAccount{
+		Nonce:                         account.Nonce,
+		StorageUpdates:                make(map[string]*vmcommon.StorageUpdate, len(account.StorageUpdates)),
+		GasUsed:                       account.GasUsed,
+		BytesAddedToStorage:           account.BytesAddedToStorage,
+		BytesDeletedFromStorage:       account.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: account.BytesConsumedByTxAsNetworking,
+	}
+
+	var err error
+	outputAccount.Address, err = codec.decodeAddress(account.Address)
+	if err != nil {
+		return nil, err
+	}
+	outputAccount.Balance, err = decodeBigInt(account.Balance)
+	if err != nil {
+		return nil, err
+	}
+	outputAccount.Code, err = decodeBytes(account.Code)
+	if err != nil {
+		return nil, err
+	}
+	outputAccount.CodeMetadata, err = decodeBytes(account.CodeMetadata)
+	if err != nil {
+		return nil, err
+	}
+	outputAccount.CodeDeployerAddress, err = codec.decodeAddress(account.CodeDeployerAddress)
+	if err != nil {
+		return nil, err
+	}
+	outputAccount.BalanceDelta, err = decodeBigInt(account.BalanceDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, update := range account.StorageUpdates {
+		if update == nil {
+			return nil, fmt.Errorf("%w in storage updates", ErrNilElement)
+		}
+
+		storageUpdate := &vmcommon.StorageUpdate{Written: update.Written}
+		storageUpdate.Offset, err = decodeBytes(update.Offset)
+		if err != nil {
+			return nil, err
+		}
+		storageUpdate.Data, err = decodeBytes(update.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		_, found := outputAccount.StorageUpdates[string(storageUpdate.Offset)]
+		if found {
+			return nil, fmt.Errorf("duplicated storage update %x", storageUpdate.Offset)
+		}
+		outputAccount.StorageUpdates[string(storageUpdate.Offset)] = storageUpdate
+	}
+
+	for _, transfer := range account.OutputTransfers {
+		if transfer == nil {
+			return nil, fmt.Errorf("%w in output transfers", ErrNilElement)
+		}
+
+		outputTransfer := vmcommon.OutputTransfer{
+			Index:     transfer.Index,
+			GasLimit:  transfer.GasLimit,
+			GasLocked: transfer.GasLocked,
+			CallType:  transfer.CallType,
+		}
+		outputTransfer.Value, err = decodeBigInt(transfer.Value)
+		if err != nil {
+			return nil, err
+		}
+		outputTransfer.AsyncData, err = decodeBytes(transfer.AsyncData)
+		if err != nil {
+			return nil, err
+		}
+		outputTransfer.Data, err = decodeBytes(transfer.Data)
+		if err != nil {
+			return nil, err
+		}
+		outputTransfer.SenderAddress, err = codec.decodeAddress(transfer.SenderAddress)
+		if err != nil {
+			return nil, err
+		}
+		outputAccount.OutputTransfers = append(outputAccount.OutputTransfers, outputTransfer)
+	}
+
+	return outputAccount, nil
+}
+
+func (codec *jsonCodec) encodeLogEntry(logEntry *vmcommon.LogEntry) (*jsonLogEntry, error) {
+	address, err := codec.encodeAddress(logEntry.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonLogEntry{
+		Identifier: encodeBytes(logEntry.Identifier),
+		Address:    address,
+		Topics:     encodeRepeatedBytes(logEntry.Topics),
+		Data:       encodeRepeatedBytes(logEntry.Data),
+	}, nil
+}
+
+func (codec *jsonCodec) decodeLogEntry(entry *jsonLogEntry) (*vmcommon.LogEntry, error) {
+	if entry == nil {
+		return nil, fmt.Errorf("%w in logs", ErrNilElement)
+	}
+
+	logEntry := &vmcommon.LogEntry{}
+	var err error
+	logEntry.Identifier, err = decodeBytes(entry.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	logEntry.Address, err = codec.decodeAddress(entry.Address)
+	if err != nil {
+		return nil, err
+	}
+	logEntry.Topics, err = decodeRepeatedBytes(entry.Topics)
+	if err != nil {
+		return nil, err
+	}
+	logEntry.Data, err = decodeRepeatedBytes(entry.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return logEntry, nil
+}
+
+func (codec *jsonCodec) encodeAccountDiff(accountDiff *vmcommon.AccountDiff) (*jsonAccountDiff, error) {
+	address, err := codec.encodeAddress(accountDiff.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &jsonAccountDiff{
+		Address:              address,
+		NonceDelta:           accountDiff.NonceDelta,
+		BalanceDelta:         encodeBigInt(accountDiff.BalanceDelta),
+		DeveloperRewardDelta: encodeBigInt(accountDiff.DeveloperRewardDelta),
+		OwnerAddress:         encodeValueChangeJSON(accountDiff.OwnerAddress),
+		UserName:             encodeValueChangeJSON(accountDiff.UserName),
+		CodeMetadata:         encodeValueChangeJSON(accountDiff.CodeMetadata),
+	}
+	for _, storageRead := range accountDiff.StorageReads {
+		diff.StorageReads = append(diff.StorageReads, &jsonStorageRead{
+			Key:   encodeBytes(storageRead.Key),
+			Value: encodeBytes(storageRead.Value),
+		})
+	}
+	for _, storageChange := range accountDiff.StorageChanges {
+		diff.StorageChanges = append(diff.StorageChanges, &jsonStorageChange{
+			Key:      encodeBytes(storageChange.Key),
+			OldValue: encodeBytes(storageChange.OldValue),
+			NewValue: encodeBytes(storageChange.NewValue),
+		})
+	}
+
+	return diff, nil
+}
+
+func (codec *jsonCodec) decodeAccountDiff(diff *jsonAccountDiff) (*vmcommon.AccountDiff, error) {
+	if diff == nil {
+		return nil, fmt.Errorf("%w in state diff", ErrNilElement)
+	}
+
+	accountDiff := &vmcommon.AccountDiff{NonceDelta: diff.NonceDelta}
+	var err error
+	accountDiff.Address, err = codec.decodeAddress(diff.Address)
+	if err != nil {
+		return nil, err
+	}
+	accountDiff.BalanceDelta, err = decodeBigInt(diff.BalanceDelta)
+	if err != nil {
+		return nil, err
+	}
+	accountDiff.DeveloperRewardDelta, err = decodeBigInt(diff.DeveloperRewardDelta)
+	if err != nil {
+		return nil, err
+	}
+	accountDiff.OwnerAddress, err = decodeValueChangeJSON(diff.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	accountDiff.UserName, err = decodeValueChangeJSON(diff.UserName)
+	if err != nil {
+		return nil, err
+	}
+	accountDiff.CodeMetadata, err = decodeValueChangeJSON(diff.CodeMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, read := range diff.StorageReads {
+		if read == nil {
+			return nil, fmt.Errorf("%w in storage reads", ErrNilElement)
+		}
+
+		storageRead := &vmcommon.StorageRead{}
+		storageRead.Key, err = decodeBytes(read.Key)
+		if err != nil {
+			return nil, err
+		}
+		storageRead.Value, err = decodeBytes(read.Value)
+		if err != nil {
+			return nil, err
+		}
+		accountDiff.StorageReads = append(accountDiff.StorageReads, storageRead)
+	}
+	for _, change := range diff.StorageChanges {
+		if change == nil {
+			return nil, fmt.Errorf("%w in storage changes", ErrNilElement)
+		}
+
+		storageChange := &vmcommon.StorageChange{}
+		storageChange.Key, err = decodeBytes(change.Key)
+		if err != nil {
+			return nil, err
+		}
+		storageChange.OldValue, err = decodeBytes(change.OldValue)
+		if err != nil {
+			return nil, err
+		}
+		storageChange.NewValue, err = decodeBytes(change.NewValue)
+		if err != nil {
+			return nil, err
+		}
+		accountDiff.StorageChanges = append(accountDiff.StorageChanges, storageChange)
+	}
+
+	return accountDiff, nil
+}
+
+func encodeValueChangeJSON(valueChange *vmcommon.ValueChange) *jsonValueChange {
+	if valueChange == nil {
+		return nil
+	}
+
+	return &jsonValueChange{
+		Old: encodeBytes(valueChange.Old),
+		New: encodeBytes(valueChange.New),
+	}
+}
+
+func decodeValueChangeJSON(change *jsonValueChange) (*vmcommon.ValueChange, error) {
+	if change == nil {
+		return nil, nil
+	}
+
+	oldValue, err := decodeBytes(change.Old)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := decodeBytes(change.New)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.ValueChange{Old: oldValue, New: newValue}, nil
+}
+
+// encodeAddress uses the address converter for the addresses of the expected length. An empty address is
+// encoded as an empty string and an address of another length falls back to hex, prefixed with 0x so it is
+// never mistaken for a converted address.
+func (codec *jsonCodec) encodeAddress(address []byte) (*string, error) {
+	if address == nil {
+		return nil, nil
+	}
+	if len(address) == 0 || check.IfNil(codec.addressConverter) {
+		return encodeBytes(address), nil
+	}
+	if len(address) != codec.addressConverter.Len() {
+		encoded := hexPrefix + hex.EncodeToString(address)
+		return &encoded, nil
+	}
+
+	encoded, err := codec.addressConverter.Encode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encoded, nil
+}
+
+func (codec *jsonCodec) decodeAddress(encoded *string) ([]byte, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+	if len(*encoded) == 0 || check.IfNil(codec.addressConverter) {
+		return decodeBytes(encoded)
+	}
+	if len(*encoded) >= len(hexPrefix) && (*encoded)[:len(hexPrefix)] == hexPrefix {
+		address, err := hex.DecodeString((*encoded)[len(hexPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		if len(address) == codec.addressConverter.Len() {
+			return nil, fmt.Errorf("address %s should use the address converter", *encoded)
+		}
+
+		return address, nil
+	}
+
+	return codec.addressConverter.Decode(*encoded)
+}
+
+func (codec *jsonCodec) encodeAddresses(addresses [][]byte) ([]string, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	encoded := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		encodedAddress, err := codec.encodeAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		if encodedAddress == nil {
+			encodedAddress = encodeBytes([]byte{})
+		}
+		encoded = append(encoded, *encodedAddress)
+	}
+
+	return encoded, nil
+}
+
+func (codec *jsonCodec) decodeAddresses(encoded []string) ([][]byte, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	addresses := make([][]byte, 0, len(encoded))
+	for index := range encoded {
+		address, err := codec.decodeAddress(&encoded[index])
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+func encodeBytes(value []byte) *string {
+	if value == nil {
+		return nil
+	}
+
+	encoded := hex.EncodeToString(value)
+	return &encoded
+}
+
+func decodeBytes(encoded *string) ([]byte, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+
+	return hex.DecodeString(*encoded)
+}
+
+func encodeRepeatedBytes(values [][]byte) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	encoded := make([]string, 0, len(values))
+	for _, value := range values {
+		encoded = append(encoded, hex.EncodeToString(value))
+	}
+
+	return encoded
+}
+
+func decodeRepeatedBytes(encoded []string) ([][]byte, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	values := make([][]byte, 0, len(encoded))
+	for _, value := range encoded {
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, decoded)
+	}
+
+	return values, nil
+}
+
+func encodeBigInt(value *big.Int) *string {
+	if value == nil {
+		return nil
+	}
+
+	encoded := value.String()
+	return &encoded
+}
+
+func decodeBigInt(encoded *string) (*big.Int, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+
+	value, ok := big.NewInt(0).SetString(*encoded, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid big integer %s", *encoded)
+	}
+
+	return value, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (codec *jsonCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
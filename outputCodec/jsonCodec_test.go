@@ -0,0 +1,142 @@
+package outputCodec
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core/pubkeyConverter"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func TestNewJSONCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := NewJSONCodec(ArgsJSONCodec{})
+	assert.False(t, codec.IsInterfaceNil())
+}
+
+func TestJSONCodec_Marshal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil VM output should error", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := NewJSONCodec(ArgsJSONCodec{}).Marshal(nil)
+		assert.Nil(t, encoded)
+		assert.Equal(t, ErrNilVMOutput, err)
+	})
+	t.Run("hex addresses should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		codec := NewJSONCodec(ArgsJSONCodec{})
+		vmOutput := createVMOutput()
+		encoded, err := codec.Marshal(vmOutput)
+		require.Nil(t, err)
+		assert.Contains(t, string(encoded), `"returnCode":"ok"`)
+		assert.Contains(t, string(encoded), `"balanceDelta":"-150"`)
+		assert.Contains(t, string(encoded), `"address":"`+hex.EncodeToString(createAddress(1))+`"`)
+
+		decoded, err := codec.Unmarshal(encoded)
+		require.Nil(t, err)
+		assert.Equal(t, vmOutput, decoded)
+	})
+	t.Run("bech32 addresses should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		addressConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+		require.Nil(t, err)
+		codec := NewJSONCodec(ArgsJSONCodec{AddressConverter: addressConverter})
+
+		vmOutput := createVMOutput()
+		vmOutput.Logs[0].Address = []byte("short")
+		encoded, err := codec.Marshal(vmOutput)
+		require.Nil(t, err)
+
+		bech32Address, _ := addressConverter.Encode(createAddress(2))
+		assert.Contains(t, string(encoded), `"deletedAccounts":["`+bech32Address+`"]`)
+		assert.Contains(t, string(encoded), `"address":"0x`+hex.EncodeToString([]byte("short"))+`"`)
+
+		decoded, err := codec.Unmarshal(encoded)
+		require.Nil(t, err)
+		assert.Equal(t, vmOutput, decoded)
+	})
+	t.Run("should hold the hash of the binary encoding", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createVMOutput()
+		encoded, err := NewJSONCodec(ArgsJSONCodec{}).Marshal(vmOutput)
+		require.Nil(t, err)
+
+		hash, err := ComputeHash(vmOutput)
+		require.Nil(t, err)
+		document := make(map[string]interface{})
+		require.Nil(t, json.Unmarshal(encoded, &document))
+		assert.Equal(t, hex.EncodeToString(hash), document["hash"])
+	})
+	t.Run("should be deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		codec := NewJSONCodec(ArgsJSONCodec{})
+		expected, err := codec.Marshal(createVMOutput())
+		require.Nil(t, err)
+		for i := 0; i < 20; i++ {
+			encoded, errMarshal := codec.Marshal(createVMOutput())
+			require.Nil(t, errMarshal)
+			assert.Equal(t, expected, encoded)
+		}
+	})
+}
+
+func TestJSONCodec_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	codec := NewJSONCodec(ArgsJSONCodec{})
+	encoded, err := codec.Marshal(createVMOutput())
+	require.Nil(t, err)
+
+	t.Run("invalid JSON should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, errDecode := codec.Unmarshal([]byte("{"))
+		assert.True(t, errors.Is(errDecode, ErrInvalidEncoding))
+	})
+	t.Run("unknown version should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, errDecode := codec.Unmarshal([]byte(strings.Replace(string(encoded), `"version":1`, `"version":2`, 1)))
+		assert.True(t, errors.Is(errDecode, ErrUnsupportedVersion))
+	})
+	t.Run("changed content should error", func(t *testing.T) {
+		t.Parallel()
+
+		changed := strings.Replace(string(encoded), `"gasRemaining":1000`, `"gasRemaining":1001`, 1)
+		require.NotEqual(t, string(encoded), changed)
+
+		_, errDecode := codec.Unmarshal([]byte(changed))
+		assert.True(t, errors.Is(errDecode, ErrHashMismatch))
+	})
+	t.Run("invalid values should error", func(t *testing.T) {
+		t.Parallel()
+
+		changed := strings.Replace(string(encoded), `"balanceDelta":"-150"`, `"balanceDelta":"ten"`, 1)
+		_, errDecode := codec.Unmarshal([]byte(changed))
+		assert.True(t, errors.Is(errDecode, ErrInvalidEncoding))
+
+		changed = strings.Replace(string(encoded), `"code":"`, `"code":"zz`, 1)
+		_, errDecode = codec.Unmarshal([]byte(changed))
+		assert.True(t, errors.Is(errDecode, ErrInvalidEncoding))
+	})
+	t.Run("numeric return code should be accepted", func(t *testing.T) {
+		t.Parallel()
+
+		changed := strings.Replace(string(encoded), `"returnCode":"ok"`, `"returnCode":0`, 1)
+		decoded, errDecode := codec.Unmarshal([]byte(changed))
+		require.Nil(t, errDecode)
+		assert.Equal(t, vmcommon.Ok, decoded.ReturnCode)
+	})
+}
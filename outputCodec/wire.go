@@ -0,0 +1,234 @@
+package outputCodec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// the wire types of the protobuf encoding used by this package
+const (
+	wireVarint          = 0
+	wireLengthDelimited = 2
+)
+
+const (
+	bigIntPositive = 0
+	bigIntNegative = 1
+)
+
+type encoder struct {
+	buff []byte
+}
+
+func (enc *encoder) writeTag(field uint64, wireType uint64) {
+	enc.buff = binary.AppendUvarint(enc.buff, field<<3|wireType)
+}
+
+// writeUint64 writes the value if it is not zero
+func (enc *encoder) writeUint64(field uint64, value uint64) {
+	if value == 0 {
+		return
+	}
+
+	enc.writeTag(field, wireVarint)
+	enc.buff = binary.AppendUvarint(enc.buff, value)
+}
+
+// writeInt64 writes the zig-zag encoded value if it is not zero
+func (enc *encoder) writeInt64(field uint64, value int64) {
+	if value == 0 {
+		return
+	}
+
+	enc.writeTag(field, wireVarint)
+	enc.buff = binary.AppendVarint(enc.buff, value)
+}
+
+// writeBool writes the value if it is true
+func (enc *encoder) writeBool(field uint64, value bool) {
+	if value {
+		enc.writeUint64(field, 1)
+	}
+}
+
+// writeString writes the value if it is not empty
+func (enc *encoder) writeString(field uint64, value string) {
+	if len(value) == 0 {
+		return
+	}
+
+	enc.writeBytes(field, []byte(value))
+}
+
+// writeBytes always writes the value, so it is used for the elements of the repeated fields
+func (enc *encoder) writeBytes(field uint64, value []byte) {
+	enc.writeTag(field, wireLengthDelimited)
+	enc.buff = binary.AppendUvarint(enc.buff, uint64(len(value)))
+	enc.buff = append(enc.buff, value...)
+}
+
+// writeOptionalBytes writes the value if it is not nil, so an empty value is kept apart from a missing one
+func (enc *encoder) writeOptionalBytes(field uint64, value []byte) {
+	if value == nil {
+		return
+	}
+
+	enc.writeBytes(field, value)
+}
+
+// writeBigInt writes the value if it is not nil as a sign byte followed by the big endian magnitude
+func (enc *encoder) writeBigInt(field uint64, value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	sign := byte(bigIntPositive)
+	if value.Sign() < 0 {
+		sign = bigIntNegative
+	}
+	enc.writeBytes(field, append([]byte{sign}, value.Bytes()...))
+}
+
+// writeMessage always writes the message encoded by the provided function
+func (enc *encoder) writeMessage(field uint64, encode func(enc *encoder) error) error {
+	message := &encoder{}
+	err := encode(message)
+	if err != nil {
+		return err
+	}
+
+	enc.writeBytes(field, message.buff)
+	return nil
+}
+
+type decoder struct {
+	buff []byte
+}
+
+// decodeMessage calls the provided function for every field of the message
+func decodeMessage(buff []byte, decodeField func(dec *decoder, field uint64, wireType uint64) error) error {
+	dec := &decoder{buff: buff}
+	for len(dec.buff) > 0 {
+		tag, err := dec.readUvarint()
+		if err != nil {
+			return err
+		}
+
+		field, wireType := tag>>3, tag&7
+		err = decodeField(dec, field, wireType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unknownField(field uint64) error {
+	return fmt.Errorf("%w: unknown field %d", ErrInvalidEncoding, field)
+}
+
+func (dec *decoder) readUvarint() (uint64, error) {
+	value, n := binary.Uvarint(dec.buff)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: invalid varint", ErrInvalidEncoding)
+	}
+
+	dec.buff = dec.buff[n:]
+	return value, nil
+}
+
+func checkWireType(expected uint64, actual uint64) error {
+	if expected != actual {
+		return fmt.Errorf("%w: wire type %d, expected %d", ErrInvalidEncoding, actual, expected)
+	}
+
+	return nil
+}
+
+func (dec *decoder) readUint64(wireType uint64) (uint64, error) {
+	err := checkWireType(wireVarint, wireType)
+	if err != nil {
+		return 0, err
+	}
+
+	return dec.readUvarint()
+}
+
+func (dec *decoder) readInt64(wireType uint64) (int64, error) {
+	err := checkWireType(wireVarint, wireType)
+	if err != nil {
+		return 0, err
+	}
+
+	value, n := binary.Varint(dec.buff)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: invalid varint", ErrInvalidEncoding)
+	}
+
+	dec.buff = dec.buff[n:]
+	return value, nil
+}
+
+func (dec *decoder) readBool(wireType uint64) (bool, error) {
+	value, err := dec.readUint64(wireType)
+	if err != nil {
+		return false, err
+	}
+	if value > 1 {
+		return false, fmt.Errorf("%w: invalid bool %d", ErrInvalidEncoding, value)
+	}
+
+	return value == 1, nil
+}
+
+// readBytes returns a copy of the value, which is never nil
+func (dec *decoder) readBytes(wireType uint64) ([]byte, error) {
+	err := checkWireType(wireLengthDelimited, wireType)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := dec.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(len(dec.buff)) {
+		return nil, fmt.Errorf("%w: length %d exceeds the remaining %d bytes", ErrInvalidEncoding, length, len(dec.buff))
+	}
+
+	value := make([]byte, length)
+	copy(value, dec.buff[:length])
+	dec.buff = dec.buff[length:]
+
+	return value, nil
+}
+
+func (dec *decoder) readString(wireType uint64) (string, error) {
+	value, err := dec.readBytes(wireType)
+	return string(value), err
+}
+
+// readBigInt reads a value written by writeBigInt, rejecting the encodings which writeBigInt never produces
+func (dec *decoder) readBigInt(wireType uint64) (*big.Int, error) {
+	value, err := dec.readBytes(wireType)
+	if err != nil {
+		return nil, err
+	}
+
+	isValid := len(value) > 0 &&
+		(value[0] == bigIntPositive || value[0] == bigIntNegative) &&
+		(len(value) == 1 || value[1] != 0) &&
+		(value[0] == bigIntPositive || len(value) > 1)
+	if !isValid {
+		return nil, fmt.Errorf("%w: invalid big integer %x", ErrInvalidEncoding, value)
+	}
+
+	result := big.NewInt(0).SetBytes(value[1:])
+	if value[0] == bigIntNegative {
+		result.Neg(result)
+	}
+
+	return result, nil
+}
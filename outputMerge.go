@@ -0,0 +1,383 @@
+package vmcommon
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+)
+
+// MergeConflictKind describes which field of an output account could not be merged
+type MergeConflictKind string
+
+const (
+	// CodeConflict signals that both outputs deploy a different code to the same address
+	CodeConflict MergeConflictKind = "code conflict"
+	// CodeMetadataConflict signals that both outputs set a different code metadata on the same address
+	CodeMetadataConflict MergeConflictKind = "code metadata conflict"
+	// CodeDeployerConflict signals that both outputs set a different code deployer on the same address
+	CodeDeployerConflict MergeConflictKind = "code deployer conflict"
+)
+
+// MergeConflict describes an account field holding different values in the merged outputs. The merged output
+// keeps the value of the parent output.
+type MergeConflict struct {
+	Address []byte
+	Kind    MergeConflictKind
+	Parent  []byte
+	Nested  []byte
+}
+
+// String returns the conflict in a human-readable form
+func (conflict *MergeConflict) String() string {
+	return fmt.Sprintf("%s for %x: parent %x, nested %x", conflict.Kind, conflict.Address, conflict.Parent, conflict.Nested)
+}
+
+// MergeVMOutputs combines the output of a parent call with the output of a nested or asynchronous call that ran
+// after it. None of the provided outputs is changed.
+//   - the return data, the logs and the gas charges are concatenated, parent first;
+//   - the return code and message are the ones of the parent, unless the parent succeeded;
+//   - the gas remaining is the one of the nested output, which ran last, and the gas refunds are summed;
+//   - the balance deltas, the used gas and the storage byte counters of the accounts are summed, the nonce is the
+//     highest one and the balance is the last one set;
+//   - a storage update of the nested output replaces the one of the parent, unless only the parent wrote the key;
+//   - the transfers of the nested output which the parent already has are dropped, the others receive new
+//     indexes from the index provider, in the order of their original indexes;
+//   - the deleted and touched accounts are deduplicated and the state diffs are merged per account.
+//
+// Different codes, code metadata or code deployers set on the same account are returned as conflicts.
+func MergeVMOutputs(
+	parent *VMOutput,
+	nested *VMOutput,
+	nextIndexProvider NextOutputTransferIndexProvider,
+) (*VMOutput, []*MergeConflict, error) {
+	if parent == nil || nested == nil {
+		return nil, nil, ErrNilVMOutput
+	}
+	if check.IfNil(nextIndexProvider) {
+		return nil, nil, ErrNilTransferIndexer
+	}
+
+	merged := &VMOutput{
+		ReturnData:      append(copySlices(parent.ReturnData), nested.ReturnData...),
+		ReturnCode:      parent.ReturnCode,
+		ReturnMessage:   parent.ReturnMessage,
+		GasRemaining:    nested.GasRemaining,
+		GasRefund:       addBigInts(parent.GasRefund, nested.GasRefund),
+		OutputAccounts:  make(map[string]*OutputAccount, len(parent.OutputAccounts)+len(nested.OutputAccounts)),
+		DeletedAccounts: appendUniqueAddresses(nil, parent.DeletedAccounts, nested.DeletedAccounts),
+		TouchedAccounts: appendUniqueAddresses(nil, parent.TouchedAccounts, nested.TouchedAccounts),
+		Logs:            append(append(make([]*LogEntry, 0, len(parent.Logs)+len(nested.Logs)), parent.Logs...), nested.Logs...),
+		StateDiff:       mergeStateDiffs(parent.StateDiff, nested.StateDiff),
+		GasCharges:      append(append(make([]*GasCharge, 0, len(parent.GasCharges)+len(nested.GasCharges)), parent.GasCharges...), nested.GasCharges...),
+	}
+	if parent.ReturnCode == Ok {
+		merged.ReturnCode = nested.ReturnCode
+		if nested.ReturnCode != Ok || len(merged.ReturnMessage) == 0 {
+			merged.ReturnMessage = nested.ReturnMessage
+		}
+	}
+
+	for key, account := range parent.OutputAccounts {
+		if account == nil {
+			continue
+		}
+		merged.OutputAccounts[key] = copyOutputAccount(account)
+	}
+
+	conflicts := make([]*MergeConflict, 0)
+	newTransfers := make([]*transferToReindex, 0)
+	for _, key := range sortedOutputAccountKeys(nested.OutputAccounts) {
+		nestedAccount := nested.OutputAccounts[key]
+		mergedAccount, found := merged.OutputAccounts[key]
+		if !found {
+			mergedAccount = copyOutputAccount(nestedAccount)
+			mergedAccount.OutputTransfers = nil
+			merged.OutputAccounts[key] = mergedAccount
+		} else {
+			conflicts = append(conflicts, mergeOutputAccount(mergedAccount, nestedAccount)...)
+		}
+
+		newTransfers = append(newTransfers, newOutputTransfers(mergedAccount, nestedAccount)...)
+	}
+
+	err := reindexTransfers(newTransfers, nextIndexProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return merged, conflicts, nil
+}
+
+type transferToReindex struct {
+	account  *OutputAccount
+	transfer OutputTransfer
+}
+
+func mergeOutputAccount(merged *OutputAccount, nested *OutputAccount) []*MergeConflict {
+	conflicts := make([]*MergeConflict, 0)
+	mergeField := func(kind MergeConflictKind, mergedValue *[]byte, nestedValue []byte) {
+		if len(nestedValue) == 0 {
+			return
+		}
+		if len(*mergedValue) == 0 {
+			*mergedValue = nestedValue
+			return
+		}
+		if !bytes.Equal(*mergedValue, nestedValue) {
+			conflicts = append(conflicts, &MergeConflict{
+				Address: merged.Address,
+				Kind:    kind,
+				Parent:  *mergedValue,
+				Nested:  nestedValue,
+			})
+		}
+	}
+	mergeField(CodeConflict, &merged.Code, nested.Code)
+	mergeField(CodeMetadataConflict, &merged.CodeMetadata, nested.CodeMetadata)
+	mergeField(CodeDeployerConflict, &merged.CodeDeployerAddress, nested.CodeDeployerAddress)
+
+	if nested.Nonce > merged.Nonce {
+		merged.Nonce = nested.Nonce
+	}
+	if nested.Balance != nil {
+		merged.Balance = big.NewInt(0).Set(nested.Balance)
+	}
+	merged.BalanceDelta = addBigInts(merged.BalanceDelta, nested.BalanceDelta)
+	merged.GasUsed += nested.GasUsed
+	merged.BytesAddedToStorage += nested.BytesAddedToStorage
+	merged.BytesDeletedFromStorage += nested.BytesDeletedFromStorage
+	merged.BytesConsumedByTxAsNetworking += nested.BytesConsumedByTxAsNetworking
+
+	for key, update := range nested.StorageUpdates {
+		if update == nil {
+			continue
+		}
+		existing, found := merged.StorageUpdates[key]
+		if found && existing.Written && !update.Written {
+			continue
+		}
+		merged.StorageUpdates[key] = update
+	}
+
+	return conflicts
+}
+
+// newOutputTransfers returns the transfers of the nested account which the merged account does not already have
+func newOutputTransfers(merged *OutputAccount, nested *OutputAccount) []*transferToReindex {
+	newTransfers := make([]*transferToReindex, 0, len(nested.OutputTransfers))
+	for _, transfer := range nested.OutputTransfers {
+		if containsOutputTransfer(merged.OutputTransfers, transfer) {
+			continue
+		}
+		newTransfers = append(newTransfers, &transferToReindex{
+			account:  merged,
+			transfer: transfer,
+		})
+	}
+
+	return newTransfers
+}
+
+func containsOutputTransfer(transfers []OutputTransfer, transfer OutputTransfer) bool {
+	for _, existing := range transfers {
+		isSame := existing.Index == transfer.Index &&
+			bigIntsEqual(existing.Value, transfer.Value) &&
+			existing.GasLimit == transfer.GasLimit &&
+			existing.GasLocked == transfer.GasLocked &&
+			bytes.Equal(existing.AsyncData, transfer.AsyncData) &&
+			bytes.Equal(existing.Data, transfer.Data) &&
+			existing.CallType == transfer.CallType &&
+			bytes.Equal(existing.SenderAddress, transfer.SenderAddress)
+		if isSame {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reindexTransfers appends the new transfers to their accounts with indexes taken from the provider, in the order
+// of their original indexes
+func reindexTransfers(newTransfers []*transferToReindex, nextIndexProvider NextOutputTransferIndexProvider) error {
+	for _, newTransfer := range newTransfers {
+		if newTransfer.transfer.Index == 0 {
+			return ErrTransfersNotIndexed
+		}
+	}
+	sort.SliceStable(newTransfers, func(i, j int) bool {
+		return newTransfers[i].transfer.Index < newTransfers[j].transfer.Index
+	})
+
+	for _, newTransfer := range newTransfers {
+		transfer := newTransfer.transfer
+		transfer.Index = nextIndexProvider.NextOutputTransferIndex()
+		newTransfer.account.OutputTransfers = append(newTransfer.account.OutputTransfers, transfer)
+	}
+
+	return nil
+}
+
+func mergeStateDiffs(parent []*AccountDiff, nested []*AccountDiff) []*AccountDiff {
+	if len(parent) == 0 && len(nested) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]*AccountDiff, len(parent)+len(nested))
+	for _, diff := range append(append(make([]*AccountDiff, 0, len(parent)+len(nested)), parent...), nested...) {
+		if diff == nil {
+			continue
+		}
+
+		existing, found := merged[string(diff.Address)]
+		if !found {
+			copied := *diff
+			copied.BalanceDelta = addBigInts(nil, diff.BalanceDelta)
+			copied.DeveloperRewardDelta = addBigInts(nil, diff.DeveloperRewardDelta)
+			copied.StorageReads = append(make([]*StorageRead, 0, len(diff.StorageReads)), diff.StorageReads...)
+			copied.StorageChanges = append(make([]*StorageChange, 0, len(diff.StorageChanges)), diff.StorageChanges...)
+			merged[string(diff.Address)] = &copied
+			continue
+		}
+
+		mergeAccountDiff(existing, diff)
+	}
+
+	result := make([]*AccountDiff, 0, len(merged))
+	for _, diff := range merged {
+		result = append(result, diff)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Address, result[j].Address) < 0
+	})
+
+	return result
+}
+
+// mergeAccountDiff keeps the first old value and the last new value of every changed field and storage key
+func mergeAccountDiff(merged *AccountDiff, nested *AccountDiff) {
+	merged.NonceDelta += nested.NonceDelta
+	merged.BalanceDelta = addBigInts(merged.BalanceDelta, nested.BalanceDelta)
+	merged.DeveloperRewardDelta = addBigInts(merged.DeveloperRewardDelta, nested.DeveloperRewardDelta)
+	merged.OwnerAddress = mergeValueChanges(merged.OwnerAddress, nested.OwnerAddress)
+	merged.UserName = mergeValueChanges(merged.UserName, nested.UserName)
+	merged.CodeMetadata = mergeValueChanges(merged.CodeMetadata, nested.CodeMetadata)
+
+	for _, read := range nested.StorageReads {
+		if !containsStorageRead(merged.StorageReads, read.Key) {
+			merged.StorageReads = append(merged.StorageReads, read)
+		}
+	}
+	for _, change := range nested.StorageChanges {
+		index := indexOfStorageChange(merged.StorageChanges, change.Key)
+		if index < 0 {
+			merged.StorageChanges = append(merged.StorageChanges, change)
+			continue
+		}
+
+		merged.StorageChanges[index] = &StorageChange{
+			Key:      change.Key,
+			OldValue: merged.StorageChanges[index].OldValue,
+			NewValue: change.NewValue,
+		}
+	}
+}
+
+func mergeValueChanges(first *ValueChange, last *ValueChange) *ValueChange {
+	if first == nil {
+		return last
+	}
+	if last == nil {
+		return first
+	}
+
+	return &ValueChange{Old: first.Old, New: last.New}
+}
+
+func containsStorageRead(reads []*StorageRead, key []byte) bool {
+	for _, read := range reads {
+		if bytes.Equal(read.Key, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func indexOfStorageChange(changes []*StorageChange, key []byte) int {
+	for index, change := range changes {
+		if bytes.Equal(change.Key, key) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func copyOutputAccount(account *OutputAccount) *OutputAccount {
+	copied := *account
+	copied.StorageUpdates = make(map[string]*StorageUpdate, len(account.StorageUpdates))
+	for key, update := range account.StorageUpdates {
+		if update == nil {
+			continue
+		}
+		copied.StorageUpdates[key] = update
+	}
+	copied.OutputTransfers = append(make([]OutputTransfer, 0, len(account.OutputTransfers)), account.OutputTransfers...)
+	if account.Balance != nil {
+		copied.Balance = big.NewInt(0).Set(account.Balance)
+	}
+	if account.BalanceDelta != nil {
+		copied.BalanceDelta = big.NewInt(0).Set(account.BalanceDelta)
+	}
+
+	return &copied
+}
+
+func sortedOutputAccountKeys(outputAccounts map[string]*OutputAccount) []string {
+	keys := make([]string, 0, len(outputAccounts))
+	for key, account := range outputAccounts {
+		if account == nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func appendUniqueAddresses(result [][]byte, lists ...[][]byte) [][]byte {
+	seen := make(map[string]struct{})
+	for _, list := range lists {
+		for _, address := range list {
+			_, found := seen[string(address)]
+			if found {
+				continue
+			}
+			seen[string(address)] = struct{}{}
+			result = append(result, address)
+		}
+	}
+
+	return result
+}
+
+func copySlices(slices [][]byte) [][]byte {
+	return append(make([][]byte, 0, len(slices)), slices...)
+}
+
+// addBigInts returns a new value holding the sum, or nil if both values are nil
+func addBigInts(first *big.Int, second *big.Int) *big.Int {
+	if first == nil && second == nil {
+		return nil
+	}
+
+	return big.NewInt(0).Add(ZeroValueIfNil(first), ZeroValueIfNil(second))
+}
+
+func bigIntsEqual(first *big.Int, second *big.Int) bool {
+	return ZeroValueIfNil(first).Cmp(ZeroValueIfNil(second)) == 0
+}
@@ -0,0 +1,215 @@
+package vmcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transferIndexProviderStub struct {
+	crtIndex uint32
+}
+
+func (stub *transferIndexProviderStub) NextOutputTransferIndex() uint32 {
+	index := stub.crtIndex
+	stub.crtIndex++
+	return index
+}
+
+func (stub *transferIndexProviderStub) GetCrtTransferIndex() uint32 {
+	return stub.crtIndex
+}
+
+func (stub *transferIndexProviderStub) SetCrtTransferIndex(index uint32) {
+	stub.crtIndex = index
+}
+
+func (stub *transferIndexProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMergeOutputs() (*VMOutput, *VMOutput) {
+	parent := &VMOutput{
+		ReturnData:      [][]byte{[]byte("parent")},
+		ReturnCode:      Ok,
+		GasRemaining:    500,
+		GasRefund:       big.NewInt(5),
+		TouchedAccounts: [][]byte{[]byte("alice"), []byte("bob")},
+		Logs:            []*LogEntry{{Identifier: []byte("first")}},
+		OutputAccounts: map[string]*OutputAccount{
+			"alice": {
+				Address:      []byte("alice"),
+				Nonce:        3,
+				BalanceDelta: big.NewInt(-100),
+				GasUsed:      20,
+				StorageUpdates: map[string]*StorageUpdate{
+					"written": {Offset: []byte("written"), Data: []byte("parent"), Written: true},
+					"read":    {Offset: []byte("read"), Data: []byte("old")},
+				},
+				OutputTransfers: []OutputTransfer{{Index: 1, Value: big.NewInt(100), Data: []byte("transfer")}},
+			},
+			"contract": {
+				Address: []byte("contract"),
+				Code:    []byte("code"),
+			},
+		},
+		StateDiff: []*AccountDiff{
+			{
+				Address:        []byte("alice"),
+				NonceDelta:     1,
+				BalanceDelta:   big.NewInt(-100),
+				UserName:       &ValueChange{Old: []byte("a"), New: []byte("b")},
+				StorageChanges: []*StorageChange{{Key: []byte("written"), OldValue: []byte("initial"), NewValue: []byte("parent")}},
+			},
+		},
+	}
+	nested := &VMOutput{
+		ReturnData:      [][]byte{[]byte("nested")},
+		ReturnCode:      Ok,
+		ReturnMessage:   "nested message",
+		GasRemaining:    300,
+		GasRefund:       big.NewInt(2),
+		TouchedAccounts: [][]byte{[]byte("bob"), []byte("carol")},
+		Logs:            []*LogEntry{{Identifier: []byte("second")}},
+		OutputAccounts: map[string]*OutputAccount{
+			"alice": {
+				Address:      []byte("alice"),
+				Nonce:        2,
+				BalanceDelta: big.NewInt(40),
+				GasUsed:      7,
+				StorageUpdates: map[string]*StorageUpdate{
+					"written": {Offset: []byte("written"), Data: []byte("parent")},
+					"read":    {Offset: []byte("read"), Data: []byte("new"), Written: true},
+				},
+				OutputTransfers: []OutputTransfer{
+					{Index: 1, Value: big.NewInt(100), Data: []byte("transfer")},
+					{Index: 3, Value: big.NewInt(7)},
+				},
+			},
+			"carol": {
+				Address:         []byte("carol"),
+				BalanceDelta:    big.NewInt(60),
+				OutputTransfers: []OutputTransfer{{Index: 2, Value: big.NewInt(60)}},
+			},
+			"contract": {
+				Address:      []byte("contract"),
+				Code:         []byte("other code"),
+				CodeMetadata: []byte{1, 0},
+			},
+		},
+		StateDiff: []*AccountDiff{
+			{
+				Address:      []byte("alice"),
+				BalanceDelta: big.NewInt(40),
+				UserName:     &ValueChange{Old: []byte("b"), New: []byte("c")},
+				StorageChanges: []*StorageChange{
+					{Key: []byte("written"), OldValue: []byte("parent"), NewValue: []byte("nested")},
+					{Key: []byte("other"), NewValue: []byte("value")},
+				},
+			},
+		},
+	}
+
+	return parent, nested
+}
+
+func TestMergeVMOutputs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		parent, nested := createMergeOutputs()
+		_, _, err := MergeVMOutputs(nil, nested, &transferIndexProviderStub{})
+		assert.Equal(t, ErrNilVMOutput, err)
+		_, _, err = MergeVMOutputs(parent, nil, &transferIndexProviderStub{})
+		assert.Equal(t, ErrNilVMOutput, err)
+		_, _, err = MergeVMOutputs(parent, nested, nil)
+		assert.Equal(t, ErrNilTransferIndexer, err)
+	})
+	t.Run("unindexed transfers should error", func(t *testing.T) {
+		t.Parallel()
+
+		parent, nested := createMergeOutputs()
+		nested.OutputAccounts["carol"].OutputTransfers[0].Index = 0
+		_, _, err := MergeVMOutputs(parent, nested, &transferIndexProviderStub{crtIndex: 2})
+		assert.Equal(t, ErrTransfersNotIndexed, err)
+	})
+	t.Run("should merge", func(t *testing.T) {
+		t.Parallel()
+
+		parent, nested := createMergeOutputs()
+		indexProvider := &transferIndexProviderStub{crtIndex: 2}
+		merged, conflicts, err := MergeVMOutputs(parent, nested, indexProvider)
+		require.Nil(t, err)
+
+		assert.Equal(t, [][]byte{[]byte("parent"), []byte("nested")}, merged.ReturnData)
+		assert.Equal(t, Ok, merged.ReturnCode)
+		assert.Equal(t, "nested message", merged.ReturnMessage)
+		assert.Equal(t, uint64(300), merged.GasRemaining)
+		assert.Equal(t, big.NewInt(7), merged.GasRefund)
+		assert.Equal(t, [][]byte{[]byte("alice"), []byte("bob"), []byte("carol")}, merged.TouchedAccounts)
+		assert.Equal(t, []*LogEntry{{Identifier: []byte("first")}, {Identifier: []byte("second")}}, merged.Logs)
+
+		alice := merged.OutputAccounts["alice"]
+		assert.Equal(t, uint64(3), alice.Nonce)
+		assert.Equal(t, big.NewInt(-60), alice.BalanceDelta)
+		assert.Equal(t, uint64(27), alice.GasUsed)
+		assert.Equal(t, []byte("parent"), alice.StorageUpdates["written"].Data)
+		assert.True(t, alice.StorageUpdates["written"].Written)
+		assert.Equal(t, []byte("new"), alice.StorageUpdates["read"].Data)
+		assert.Equal(t, []OutputTransfer{
+			{Index: 1, Value: big.NewInt(100), Data: []byte("transfer")},
+			{Index: 3, Value: big.NewInt(7)},
+		}, alice.OutputTransfers)
+		assert.Equal(t, []OutputTransfer{{Index: 2, Value: big.NewInt(60)}}, merged.OutputAccounts["carol"].OutputTransfers)
+		assert.Equal(t, uint32(4), indexProvider.GetCrtTransferIndex())
+
+		contract := merged.OutputAccounts["contract"]
+		assert.Equal(t, []byte("code"), contract.Code)
+		assert.Equal(t, []byte{1, 0}, contract.CodeMetadata)
+		require.Equal(t, 1, len(conflicts))
+		assert.Equal(t, "code conflict for 636f6e7472616374: parent 636f6465, nested 6f7468657220636f6465", conflicts[0].String())
+
+		require.Equal(t, 1, len(merged.StateDiff))
+		diff := merged.StateDiff[0]
+		assert.Equal(t, uint64(1), diff.NonceDelta)
+		assert.Equal(t, big.NewInt(-60), diff.BalanceDelta)
+		assert.Equal(t, &ValueChange{Old: []byte("a"), New: []byte("c")}, diff.UserName)
+		assert.Equal(t, []*StorageChange{
+			{Key: []byte("written"), OldValue: []byte("initial"), NewValue: []byte("nested")},
+			{Key: []byte("other"), NewValue: []byte("value")},
+		}, diff.StorageChanges)
+	})
+	t.Run("should not change the provided outputs", func(t *testing.T) {
+		t.Parallel()
+
+		parent, nested := createMergeOutputs()
+		_, _, err := MergeVMOutputs(parent, nested, &transferIndexProviderStub{crtIndex: 2})
+		require.Nil(t, err)
+
+		expectedParent, expectedNested := createMergeOutputs()
+		assert.Equal(t, expectedParent, parent)
+		assert.Equal(t, expectedNested, nested)
+	})
+	t.Run("failed parent should keep its return code", func(t *testing.T) {
+		t.Parallel()
+
+		parent, nested := createMergeOutputs()
+		parent.ReturnCode = UserError
+		parent.ReturnMessage = "parent failed"
+		nested.ReturnCode = OutOfGas
+		merged, _, err := MergeVMOutputs(parent, nested, &transferIndexProviderStub{crtIndex: 2})
+		require.Nil(t, err)
+		assert.Equal(t, UserError, merged.ReturnCode)
+		assert.Equal(t, "parent failed", merged.ReturnMessage)
+
+		parent.ReturnCode = Ok
+		merged, _, err = MergeVMOutputs(parent, nested, &transferIndexProviderStub{crtIndex: 2})
+		require.Nil(t, err)
+		assert.Equal(t, OutOfGas, merged.ReturnCode)
+		assert.Equal(t, "nested message", merged.ReturnMessage)
+	})
+}
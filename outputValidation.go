@@ -0,0 +1,154 @@
+package vmcommon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+)
+
+// OutputViolationKind describes which structural invariant of a VM output is broken
+type OutputViolationKind string
+
+const (
+	// NilOutputAccountViolation signals a nil output account
+	NilOutputAccountViolation OutputViolationKind = "nil output account"
+	// OutputAccountKeyViolation signals an output account stored under a key different from its address
+	OutputAccountKeyViolation OutputViolationKind = "output account key mismatch"
+	// NilStorageUpdateViolation signals a nil storage update
+	NilStorageUpdateViolation OutputViolationKind = "nil storage update"
+	// StorageUpdateKeyViolation signals a storage update stored under a key different from its offset
+	StorageUpdateKeyViolation OutputViolationKind = "storage update key mismatch"
+	// UnindexedTransferViolation signals an output transfer with the zero index
+	UnindexedTransferViolation OutputViolationKind = "unindexed transfer"
+	// DuplicatedTransferIndexViolation signals an output transfer index used more than once
+	DuplicatedTransferIndexViolation OutputViolationKind = "duplicated transfer index"
+	// NegativeBalanceOnNewAccountViolation signals a negative balance delta on an account missing from the state
+	NegativeBalanceOnNewAccountViolation OutputViolationKind = "negative balance delta on new account"
+	// NilLogEntryViolation signals a nil log entry
+	NilLogEntryViolation OutputViolationKind = "nil log entry"
+)
+
+// OutputViolation describes a broken invariant. Address is the key of the output account, Key is the key of the
+// storage update and Index is the position of the output transfer or of the log entry, when they apply.
+type OutputViolation struct {
+	Kind    OutputViolationKind
+	Address []byte
+	Key     []byte
+	Index   int
+}
+
+// String returns the violation in a human-readable form
+func (violation *OutputViolation) String() string {
+	description := string(violation.Kind)
+	switch violation.Kind {
+	case NilLogEntryViolation:
+		return fmt.Sprintf("%s at %d", description, violation.Index)
+	case UnindexedTransferViolation, DuplicatedTransferIndexViolation:
+		return fmt.Sprintf("%s for %x at %d", description, violation.Address, violation.Index)
+	case NilStorageUpdateViolation, StorageUpdateKeyViolation:
+		return fmt.Sprintf("%s for %x, key %x", description, violation.Address, violation.Key)
+	default:
+		return fmt.Sprintf("%s for %x", description, violation.Address)
+	}
+}
+
+// Validate checks the structural invariants of the VM output and returns the broken ones, sorted by the address
+// of the output account. The balance deltas of the new accounts are checked only if the existing accounts getter
+// is provided.
+func (vmOutput *VMOutput) Validate(existingAccounts ExistingAccountGetter) []*OutputViolation {
+	violations := make([]*OutputViolation, 0)
+
+	keys := make([]string, 0, len(vmOutput.OutputAccounts))
+	for key := range vmOutput.OutputAccounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	usedTransferIndexes := make(map[uint32]struct{})
+	for _, key := range keys {
+		account := vmOutput.OutputAccounts[key]
+		if account == nil {
+			violations = append(violations, &OutputViolation{Kind: NilOutputAccountViolation, Address: []byte(key)})
+			continue
+		}
+		if key != string(account.Address) {
+			violations = append(violations, &OutputViolation{Kind: OutputAccountKeyViolation, Address: []byte(key)})
+		}
+
+		violations = append(violations, validateStorageUpdates(key, account.StorageUpdates)...)
+
+		for index, transfer := range account.OutputTransfers {
+			if transfer.Index == 0 {
+				violations = append(violations, &OutputViolation{Kind: UnindexedTransferViolation, Address: []byte(key), Index: index})
+				continue
+			}
+
+			_, found := usedTransferIndexes[transfer.Index]
+			if found {
+				violations = append(violations, &OutputViolation{Kind: DuplicatedTransferIndexViolation, Address: []byte(key), Index: index})
+			}
+			usedTransferIndexes[transfer.Index] = struct{}{}
+		}
+
+		if account.BalanceDelta != nil && account.BalanceDelta.Sign() < 0 && isNewAccount(existingAccounts, account.Address) {
+			violations = append(violations, &OutputViolation{Kind: NegativeBalanceOnNewAccountViolation, Address: []byte(key)})
+		}
+	}
+
+	for index, logEntry := range vmOutput.Logs {
+		if logEntry == nil {
+			violations = append(violations, &OutputViolation{Kind: NilLogEntryViolation, Index: index})
+		}
+	}
+
+	return violations
+}
+
+// CheckValid returns an error describing all the broken invariants of the VM output, or nil if there are none
+func (vmOutput *VMOutput) CheckValid(existingAccounts ExistingAccountGetter) error {
+	violations := vmOutput.Validate(existingAccounts)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.String())
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidVMOutput, strings.Join(descriptions, "; "))
+}
+
+func validateStorageUpdates(address string, storageUpdates map[string]*StorageUpdate) []*OutputViolation {
+	keys := make([]string, 0, len(storageUpdates))
+	for key := range storageUpdates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	violations := make([]*OutputViolation, 0)
+	for _, key := range keys {
+		storageUpdate := storageUpdates[key]
+		if storageUpdate == nil {
+			violations = append(violations, &OutputViolation{Kind: NilStorageUpdateViolation, Address: []byte(address), Key: []byte(key)})
+			continue
+		}
+		if key != string(storageUpdate.Offset) {
+			violations = append(violations, &OutputViolation{Kind: StorageUpdateKeyViolation, Address: []byte(address), Key: []byte(key)})
+		}
+	}
+
+	return violations
+}
+
+func isNewAccount(existingAccounts ExistingAccountGetter, address []byte) bool {
+	if check.IfNil(existingAccounts) {
+		return false
+	}
+
+	account, err := existingAccounts.GetExistingAccount(address)
+
+	return err != nil || check.IfNil(account)
+}
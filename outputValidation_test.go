@@ -0,0 +1,136 @@
+package vmcommon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errAccountNotFound = errors.New("account not found")
+
+type existingAccountGetterStub struct {
+	existing map[string]struct{}
+}
+
+func (stub *existingAccountGetterStub) GetExistingAccount(address []byte) (AccountHandler, error) {
+	_, found := stub.existing[string(address)]
+	if !found {
+		return nil, errAccountNotFound
+	}
+
+	return &accountHandlerStub{address: address}, nil
+}
+
+func (stub *existingAccountGetterStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+type accountHandlerStub struct {
+	address []byte
+}
+
+func (stub *accountHandlerStub) AddressBytes() []byte {
+	return stub.address
+}
+
+func (stub *accountHandlerStub) IncreaseNonce(_ uint64) {
+}
+
+func (stub *accountHandlerStub) GetNonce() uint64 {
+	return 0
+}
+
+func (stub *accountHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createValidVMOutput() *VMOutput {
+	return &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"alice": {
+				Address:      []byte("alice"),
+				BalanceDelta: big.NewInt(-10),
+				StorageUpdates: map[string]*StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte("value")},
+				},
+				OutputTransfers: []OutputTransfer{{Index: 1}, {Index: 2}},
+			},
+			"bob": {
+				Address:         []byte("bob"),
+				BalanceDelta:    big.NewInt(10),
+				OutputTransfers: []OutputTransfer{{Index: 3}},
+			},
+		},
+		Logs: []*LogEntry{{Identifier: []byte("event")}},
+	}
+}
+
+func TestVMOutput_Validate(t *testing.T) {
+	t.Parallel()
+
+	existingAccounts := &existingAccountGetterStub{existing: map[string]struct{}{"alice": {}, "bob": {}}}
+
+	t.Run("valid VM output should not have violations", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createValidVMOutput()
+		assert.Empty(t, vmOutput.Validate(existingAccounts))
+		assert.Empty(t, vmOutput.Validate(nil))
+		assert.Nil(t, vmOutput.CheckValid(existingAccounts))
+		assert.Empty(t, (&VMOutput{}).Validate(nil))
+	})
+	t.Run("broken invariants should be reported", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createValidVMOutput()
+		vmOutput.OutputAccounts["carol"] = &OutputAccount{Address: []byte("karol"), BalanceDelta: big.NewInt(-1)}
+		vmOutput.OutputAccounts["dave"] = nil
+		alice := vmOutput.OutputAccounts["alice"]
+		alice.StorageUpdates["other"] = &StorageUpdate{Offset: []byte("key")}
+		alice.StorageUpdates["nil"] = nil
+		alice.OutputTransfers = append(alice.OutputTransfers, OutputTransfer{Index: 0})
+		vmOutput.OutputAccounts["bob"].OutputTransfers = append(vmOutput.OutputAccounts["bob"].OutputTransfers, OutputTransfer{Index: 2})
+		vmOutput.Logs = append(vmOutput.Logs, nil)
+
+		violations := vmOutput.Validate(existingAccounts)
+		assert.Equal(t, []*OutputViolation{
+			{Kind: NilStorageUpdateViolation, Address: []byte("alice"), Key: []byte("nil")},
+			{Kind: StorageUpdateKeyViolation, Address: []byte("alice"), Key: []byte("other")},
+			{Kind: UnindexedTransferViolation, Address: []byte("alice"), Index: 2},
+			{Kind: DuplicatedTransferIndexViolation, Address: []byte("bob"), Index: 1},
+			{Kind: OutputAccountKeyViolation, Address: []byte("carol")},
+			{Kind: NegativeBalanceOnNewAccountViolation, Address: []byte("carol")},
+			{Kind: NilOutputAccountViolation, Address: []byte("dave")},
+			{Kind: NilLogEntryViolation, Index: 1},
+		}, violations)
+
+		descriptions := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			descriptions = append(descriptions, violation.String())
+		}
+		assert.Equal(t, []string{
+			"nil storage update for 616c696365, key 6e696c",
+			"storage update key mismatch for 616c696365, key 6f74686572",
+			"unindexed transfer for 616c696365 at 2",
+			"duplicated transfer index for 626f62 at 1",
+			"output account key mismatch for 6361726f6c",
+			"negative balance delta on new account for 6361726f6c",
+			"nil output account for 64617665",
+			"nil log entry at 1",
+		}, descriptions)
+	})
+	t.Run("new accounts should be checked only with the existing accounts", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := createValidVMOutput()
+		vmOutput.OutputAccounts["carol"] = &OutputAccount{Address: []byte("carol"), BalanceDelta: big.NewInt(-1)}
+		assert.Empty(t, vmOutput.Validate(nil))
+
+		err := vmOutput.CheckValid(existingAccounts)
+		require.True(t, errors.Is(err, ErrInvalidVMOutput))
+		assert.Equal(t, "invalid VM output: negative balance delta on new account for 6361726f6c", err.Error())
+	})
+}
@@ -0,0 +1,210 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+// currentVersion is the version of the encoding written by Marshal
+const currentVersion = 1
+
+// recordData is the portable form of an execution record, in which the maps of the output are replaced by
+// lists sorted by key. Byte slices are encoded as base64 strings and big integers as JSON numbers.
+type recordData struct {
+	Version  uint32
+	Input    *vmcommon.ContractCallInput
+	PreState []*vmcommon.AccountState
+	VMOutput *vmOutputData
+	Error    string
+}
+
+type vmOutputData struct {
+	ReturnData      [][]byte
+	ReturnCode      vmcommon.ReturnCode
+	ReturnMessage   string
+	GasRemaining    uint64
+	GasRefund       *big.Int
+	OutputAccounts  []*outputAccountData
+	DeletedAccounts [][]byte
+	TouchedAccounts [][]byte
+	Logs            []*vmcommon.LogEntry
+	StateDiff       []*vmcommon.AccountDiff
+	GasCharges      []*vmcommon.GasCharge
+}
+
+type outputAccountData struct {
+	Address                       []byte
+	Nonce                         uint64
+	Balance                       *big.Int
+	StorageUpdates                []*vmcommon.StorageUpdate
+	Code                          []byte
+	CodeMetadata                  []byte
+	CodeDeployerAddress           []byte
+	BalanceDelta                  *big.Int
+	OutputTransfers               []vmcommon.OutputTransfer
+	GasUsed                       uint64
+	BytesAddedToStorage           uint64
+	BytesDeletedFromStorage       uint64
+	BytesConsumedByTxAsNetworking uint64
+}
+
+// Marshal encodes the execution record in a portable JSON form
+func Marshal(record *vmcommon.ExecutionRecord) ([]byte, error) {
+	if record == nil {
+		return nil, ErrNilRecord
+	}
+	if record.Input == nil {
+		return nil, ErrNilInput
+	}
+
+	vmOutput, err := newVMOutputData(record.VMOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&recordData{
+		Version:  currentVersion,
+		Input:    record.Input,
+		PreState: record.PreState,
+		VMOutput: vmOutput,
+		Error:    record.Error,
+	})
+}
+
+// Unmarshal decodes an execution record encoded by Marshal
+func Unmarshal(data []byte) (*vmcommon.ExecutionRecord, error) {
+	decoded := &recordData{}
+	err := json.Unmarshal(data, decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	if decoded.Version != currentVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, decoded.Version)
+	}
+	if decoded.Input == nil {
+		return nil, ErrNilInput
+	}
+
+	return &vmcommon.ExecutionRecord{
+		Input:    decoded.Input,
+		PreState: decoded.PreState,
+		VMOutput: decoded.VMOutput.toVMOutput(),
+		Error:    decoded.Error,
+	}, nil
+}
+
+func newVMOutputData(vmOutput *vmcommon.VMOutput) (*vmOutputData, error) {
+	if vmOutput == nil {
+		return nil, nil
+	}
+
+	outputAccounts := make([]*outputAccountData, 0, len(vmOutput.OutputAccounts))
+	for key, outputAccount := range vmOutput.OutputAccounts {
+		if outputAccount == nil || key != string(outputAccount.Address) {
+			return nil, fmt.Errorf("%w: output account key does not match its address", ErrInvalidRecord)
+		}
+
+		storageUpdates, err := sortedStorageUpdates(outputAccount.StorageUpdates)
+		if err != nil {
+			return nil, err
+		}
+
+		outputAccounts = append(outputAccounts, &outputAccountData{
+			Address:                       outputAccount.Address,
+			Nonce:                         outputAccount.Nonce,
+			Balance:                       outputAccount.Balance,
+			StorageUpdates:                storageUpdates,
+			Code:                          outputAccount.Code,
+			CodeMetadata:                  outputAccount.CodeMetadata,
+			CodeDeployerAddress:           outputAccount.CodeDeployerAddress,
+			BalanceDelta:                  outputAccount.BalanceDelta,
+			OutputTransfers:               outputAccount.OutputTransfers,
+			GasUsed:                       outputAccount.GasUsed,
+			BytesAddedToStorage:           outputAccount.BytesAddedToStorage,
+			BytesDeletedFromStorage:       outputAccount.BytesDeletedFromStorage,
+			BytesConsumedByTxAsNetworking: outputAccount.BytesConsumedByTxAsNetworking,
+		})
+	}
+	sort.Slice(outputAccounts, func(i, j int) bool {
+		return bytes.Compare(outputAccounts[i].Address, outputAccounts[j].Address) < 0
+	})
+
+	return &vmOutputData{
+		ReturnData:      vmOutput.ReturnData,
+		ReturnCode:      vmOutput.ReturnCode,
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       vmOutput.GasRefund,
+		OutputAccounts:  outputAccounts,
+		DeletedAccounts: vmOutput.DeletedAccounts,
+		TouchedAccounts: vmOutput.TouchedAccounts,
+		Logs:            vmOutput.Logs,
+		StateDiff:       vmOutput.StateDiff,
+		GasCharges:      vmOutput.GasCharges,
+	}, nil
+}
+
+func sortedStorageUpdates(storageUpdates map[string]*vmcommon.StorageUpdate) ([]*vmcommon.StorageUpdate, error) {
+	sorted := make([]*vmcommon.StorageUpdate, 0, len(storageUpdates))
+	for key, storageUpdate := range storageUpdates {
+		if storageUpdate == nil || key != string(storageUpdate.Offset) {
+			return nil, fmt.Errorf("%w: storage update key does not match its offset", ErrInvalidRecord)
+		}
+
+		sorted = append(sorted, storageUpdate)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Offset, sorted[j].Offset) < 0
+	})
+
+	return sorted, nil
+}
+
+func (data *vmOutputData) toVMOutput() *vmcommon.VMOutput {
+	if data == nil {
+		return nil
+	}
+
+	outputAccounts := make(map[string]*vmcommon.OutputAccount, len(data.OutputAccounts))
+	for _, outputAccount := range data.OutputAccounts {
+		storageUpdates := make(map[string]*vmcommon.StorageUpdate, len(outputAccount.StorageUpdates))
+		for _, storageUpdate := range outputAccount.StorageUpdates {
+			storageUpdates[string(storageUpdate.Offset)] = storageUpdate
+		}
+
+		outputAccounts[string(outputAccount.Address)] = &vmcommon.OutputAccount{
+			Address:                       outputAccount.Address,
+			Nonce:                         outputAccount.Nonce,
+			Balance:                       outputAccount.Balance,
+			StorageUpdates:                storageUpdates,
+			Code:                          outputAccount.Code,
+			CodeMetadata:                  outputAccount.CodeMetadata,
+			CodeDeployerAddress:           outputAccount.CodeDeployerAddress,
+			BalanceDelta:                  outputAccount.BalanceDelta,
+			OutputTransfers:               outputAccount.OutputTransfers,
+			GasUsed:                       outputAccount.GasUsed,
+			BytesAddedToStorage:           outputAccount.BytesAddedToStorage,
+			BytesDeletedFromStorage:       outputAccount.BytesDeletedFromStorage,
+			BytesConsumedByTxAsNetworking: outputAccount.BytesConsumedByTxAsNetworking,
+		}
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnData:      data.ReturnData,
+		ReturnCode:      data.ReturnCode,
+		ReturnMessage:   data.ReturnMessage,
+		GasRemaining:    data.GasRemaining,
+		GasRefund:       data.GasRefund,
+		OutputAccounts:  outputAccounts,
+		DeletedAccounts: data.DeletedAccounts,
+		TouchedAccounts: data.TouchedAccounts,
+		Logs:            data.Logs,
+		StateDiff:       data.StateDiff,
+		GasCharges:      data.GasCharges,
+	}
+}
@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid record should error", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Marshal(nil)
+		assert.Nil(t, data)
+		assert.Equal(t, ErrNilRecord, err)
+
+		data, err = Marshal(&vmcommon.ExecutionRecord{})
+		assert.Nil(t, data)
+		assert.Equal(t, ErrNilInput, err)
+
+		data, err = Marshal(&vmcommon.ExecutionRecord{
+			Input: createTransferInput(createAddress(1), createAddress(2), 1),
+			VMOutput: &vmcommon.VMOutput{
+				OutputAccounts: map[string]*vmcommon.OutputAccount{
+					"other": {Address: createAddress(1)},
+				},
+			},
+		})
+		assert.Nil(t, data)
+		assert.ErrorIs(t, err, ErrInvalidRecord)
+	})
+	t.Run("should be deterministic and round trip", func(t *testing.T) {
+		t.Parallel()
+
+		record := recordTransfer(t, 30)
+		data, err := Marshal(record)
+		require.Nil(t, err)
+
+		for i := 0; i < 10; i++ {
+			otherData, errMarshal := Marshal(record)
+			require.Nil(t, errMarshal)
+			assert.Equal(t, data, otherData)
+		}
+
+		decoded, err := Unmarshal(data)
+		require.Nil(t, err)
+		assert.Equal(t, record.Input, decoded.Input)
+		assert.Equal(t, record.PreState, decoded.PreState)
+		assert.Equal(t, record.Error, decoded.Error)
+		assert.Equal(t, len(record.VMOutput.OutputAccounts), len(decoded.VMOutput.OutputAccounts))
+		for address, outputAccount := range record.VMOutput.OutputAccounts {
+			assert.Equal(t, outputAccount.Address, decoded.VMOutput.OutputAccounts[address].Address)
+		}
+
+		reencoded, err := Marshal(decoded)
+		require.Nil(t, err)
+		assert.Equal(t, data, reencoded)
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	record, err := Unmarshal([]byte("not json"))
+	assert.Nil(t, record)
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+
+	data, _ := json.Marshal(&recordData{Version: currentVersion + 1})
+	record, err = Unmarshal(data)
+	assert.Nil(t, record)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	data, _ = json.Marshal(&recordData{Version: currentVersion})
+	record, err = Unmarshal(data)
+	assert.Nil(t, record)
+	assert.Equal(t, ErrNilInput, err)
+
+	data, _ = json.Marshal(&recordData{
+		Version: currentVersion,
+		Input:   createTransferInput(createAddress(1), createAddress(2), 1),
+		PreState: []*vmcommon.AccountState{
+			{Address: createAddress(1), Balance: big.NewInt(7)},
+		},
+		Error: "failed",
+	})
+	record, err = Unmarshal(data)
+	require.Nil(t, err)
+	assert.Nil(t, record.VMOutput)
+	assert.Equal(t, "failed", record.Error)
+	assert.Equal(t, big.NewInt(7), record.PreState[0].Balance)
+}
@@ -0,0 +1,30 @@
+package replay
+
+import "errors"
+
+// ErrNilRecord signals that a nil execution record was provided
+var ErrNilRecord = errors.New("nil execution record")
+
+// ErrNilInput signals that an execution record without input was provided
+var ErrNilInput = errors.New("nil input")
+
+// ErrInvalidRecord signals that an execution record can not be encoded or decoded
+var ErrInvalidRecord = errors.New("invalid execution record")
+
+// ErrUnsupportedVersion signals that the execution record was encoded with an unknown format version
+var ErrUnsupportedVersion = errors.New("unsupported execution record version")
+
+// ErrNilMarshalizer signals that a nil marshalizer was provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
+var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrNilGuardedAccountHandler signals that a nil guarded account handler was provided
+var ErrNilGuardedAccountHandler = errors.New("nil guarded account handler")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator was provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilGasMap signals that a nil gas map was provided
+var ErrNilGasMap = errors.New("nil gas map")
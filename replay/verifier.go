@@ -0,0 +1,265 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/builtInFunctions"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+)
+
+// ArgsVerifier defines the arguments needed to create a verifier. They describe the environment the records
+// are replayed in, which should match the one they were recorded in.
+type ArgsVerifier struct {
+	GasMap                           map[string]map[string]uint64
+	Marshalizer                      vmcommon.Marshalizer
+	ShardCoordinator                 vmcommon.Coordinator
+	EnableEpochsHandler              vmcommon.EnableEpochsHandler
+	GuardedAccountHandler            vmcommon.GuardedAccountHandler
+	MaxNumOfAddressesForTransferRole uint32
+}
+
+// Divergence describes a field of the replayed execution that differs from the recorded one. The values are
+// the JSON encoding of the field.
+type Divergence struct {
+	Field    string
+	Expected string
+	Actual   string
+}
+
+// String returns the divergence in a human-readable form
+func (d *Divergence) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Field, d.Expected, d.Actual)
+}
+
+// Report holds the outcome of replaying an execution record
+type Report struct {
+	Replayed    *vmcommon.ExecutionRecord
+	Divergences []*Divergence
+}
+
+// IsMatch returns true if the replayed execution has the same results as the recorded one
+func (r *Report) IsMatch() bool {
+	return len(r.Divergences) == 0
+}
+
+type verifier struct {
+	args ArgsVerifier
+}
+
+// NewVerifier creates a component which replays execution records against the current built-in functions
+func NewVerifier(args ArgsVerifier) (*verifier, error) {
+	if args.GasMap == nil {
+		return nil, ErrNilGasMap
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+	if check.IfNil(args.GuardedAccountHandler) {
+		return nil, ErrNilGuardedAccountHandler
+	}
+
+	return &verifier{
+		args: args,
+	}, nil
+}
+
+// Verify replays the record on an in-memory state built from its pre-state and reports every result which
+// differs from the recorded one: the error, the accessed pre-state and the output fields, including the state
+// diff. The gas charges are not compared, as they are reported only when a gas tracer is set.
+func (v *verifier) Verify(record *vmcommon.ExecutionRecord) (*Report, error) {
+	if record == nil {
+		return nil, ErrNilRecord
+	}
+	if record.Input == nil {
+		return nil, ErrNilInput
+	}
+
+	recorder, err := v.createRecorder(record.PreState)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed, err := recorder.RecordBuiltInFunction(record.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	divergences, err := compareRecords(record, replayed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		Replayed:    replayed,
+		Divergences: divergences,
+	}, nil
+}
+
+// VerifyData decodes the record with Unmarshal and verifies it
+func (v *verifier) VerifyData(data []byte) (*Report, error) {
+	record, err := Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Verify(record)
+}
+
+func (v *verifier) createRecorder(preState []*vmcommon.AccountState) (vmcommon.BuiltInFunctionRecorder, error) {
+	accounts := inMemoryState.NewAccountsAdapter()
+	for _, accountState := range preState {
+		err := setAccountState(accounts, accountState)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           v.args.GasMap,
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      v.args.Marshalizer,
+		Accounts:                         accounts,
+		ShardCoordinator:                 v.args.ShardCoordinator,
+		EnableEpochsHandler:              v.args.EnableEpochsHandler,
+		GuardedAccountHandler:            v.args.GuardedAccountHandler,
+		MaxNumOfAddressesForTransferRole: v.args.MaxNumOfAddressesForTransferRole,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = creator.CreateBuiltInFunctionContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	blockchainHook, err := inMemoryState.NewBlockchainHook(inMemoryState.ArgsBlockchainHook{
+		Accounts:              accounts,
+		BuiltInFunctions:      creator.BuiltInFunctionContainer(),
+		ShardCoordinator:      v.args.ShardCoordinator,
+		NFTStorageHandler:     creator.NFTStorageHandler(),
+		GlobalSettingsHandler: creator.DCTGlobalSettingsHandler(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = creator.SetPayableHandler(blockchainHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return creator, nil
+}
+
+func setAccountState(accounts *inMemoryState.AccountsAdapter, accountState *vmcommon.AccountState) error {
+	if accountState == nil {
+		return fmt.Errorf("%w: nil account state", ErrInvalidRecord)
+	}
+
+	account, err := inMemoryState.NewUserAccount(accountState.Address)
+	if err != nil {
+		return err
+	}
+
+	account.IncreaseNonce(accountState.Nonce)
+	if accountState.Balance != nil {
+		err = account.AddToBalance(accountState.Balance)
+		if err != nil {
+			return err
+		}
+	}
+	if accountState.DeveloperReward != nil {
+		account.AddToDeveloperReward(accountState.DeveloperReward)
+	}
+	account.SetOwnerAddress(accountState.OwnerAddress)
+	account.SetUserName(accountState.UserName)
+	account.SetCodeMetadata(accountState.CodeMetadata)
+
+	for _, entry := range accountState.Storage {
+		if entry == nil || entry.Value == nil {
+			continue
+		}
+
+		err = account.AccountDataHandler().SaveKeyValue(entry.Key, entry.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return accounts.SaveAccount(account)
+}
+
+type comparedField struct {
+	name     string
+	expected interface{}
+	actual   interface{}
+}
+
+func compareRecords(expected *vmcommon.ExecutionRecord, actual *vmcommon.ExecutionRecord) ([]*Divergence, error) {
+	fields := []comparedField{
+		{"Error", expected.Error, actual.Error},
+		{"PreState", expected.PreState, actual.PreState},
+	}
+
+	expectedOutput, err := newVMOutputData(expected.VMOutput)
+	if err != nil {
+		return nil, err
+	}
+	actualOutput, err := newVMOutputData(actual.VMOutput)
+	if err != nil {
+		return nil, err
+	}
+	if expectedOutput != nil && actualOutput != nil {
+		fields = append(fields,
+			comparedField{"ReturnCode", expectedOutput.ReturnCode, actualOutput.ReturnCode},
+			comparedField{"ReturnMessage", expectedOutput.ReturnMessage, actualOutput.ReturnMessage},
+			comparedField{"ReturnData", expectedOutput.ReturnData, actualOutput.ReturnData},
+			comparedField{"GasRemaining", expectedOutput.GasRemaining, actualOutput.GasRemaining},
+			comparedField{"GasRefund", expectedOutput.GasRefund, actualOutput.GasRefund},
+			comparedField{"OutputAccounts", expectedOutput.OutputAccounts, actualOutput.OutputAccounts},
+			comparedField{"DeletedAccounts", expectedOutput.DeletedAccounts, actualOutput.DeletedAccounts},
+			comparedField{"TouchedAccounts", expectedOutput.TouchedAccounts, actualOutput.TouchedAccounts},
+			comparedField{"Logs", expectedOutput.Logs, actualOutput.Logs},
+			comparedField{"StateDiff", expectedOutput.StateDiff, actualOutput.StateDiff},
+		)
+	}
+
+	divergences := make([]*Divergence, 0)
+	for _, field := range fields {
+		expectedValue, errMarshal := json.Marshal(field.expected)
+		if errMarshal != nil {
+			return nil, errMarshal
+		}
+		actualValue, errMarshal := json.Marshal(field.actual)
+		if errMarshal != nil {
+			return nil, errMarshal
+		}
+		if string(expectedValue) == string(actualValue) {
+			continue
+		}
+
+		divergences = append(divergences, &Divergence{
+			Field:    field.name,
+			Expected: string(expectedValue),
+			Actual:   string(actualValue),
+		})
+	}
+
+	return divergences, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (v *verifier) IsInterfaceNil() bool {
+	return v == nil
+}
@@ -0,0 +1,233 @@
+package replay
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/builtInFunctions"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+var tokenID = []byte("TKN-abcdef")
+
+func createGasMap(value uint64) map[string]map[string]uint64 {
+	baseOperationCost := make(map[string]uint64)
+	for _, key := range []string{"StorePerByte", "ReleasePerByte", "DataCopyPerByte", "PersistPerByte", "CompilePerByte", "AoTPreparePerByte"} {
+		baseOperationCost[key] = value
+	}
+
+	builtInCost := make(map[string]uint64)
+	for _, key := range []string{"ChangeOwnerAddress", "ClaimDeveloperRewards", "SaveUserName", "SaveKeyValue", "DCTTransfer",
+		"DCTBurn", "DCTLocalMint", "DCTLocalBurn", "DCTNFTCreate", "DCTNFTAddQuantity", "DCTNFTBurn", "DCTNFTTransfer",
+		"DCTNFTChangeCreateOwner", "DCTNFTMultiTransfer", "DCTNFTAddURI", "DCTNFTUpdateAttributes", "SetGuardian",
+		"GuardAccount", "TrieLoadPerNode", "TrieStorePerNode"} {
+		builtInCost[key] = value
+	}
+
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: baseOperationCost,
+		core.BuiltInCostString:       builtInCost,
+	}
+}
+
+func createMockArgsVerifier() ArgsVerifier {
+	return ArgsVerifier{
+		GasMap:           createGasMap(1),
+		Marshalizer:      &mock.MarshalizerMock{},
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsSaveToSystemAccountFlagEnabledField: true,
+			IsCheckTransferFlagEnabledField:       true,
+		},
+		GuardedAccountHandler:            &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	}
+}
+
+func createAddress(firstByte byte) []byte {
+	address := make([]byte, 32)
+	address[0] = firstByte
+
+	return address
+}
+
+func saveDCTBalance(t *testing.T, accounts *inMemoryState.AccountsAdapter, address []byte, value int64) {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	tokenKey := append([]byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier), tokenID...)
+	marshalledToken, err := (&mock.MarshalizerMock{}).Marshal(&dct.DCToken{Value: big.NewInt(value)})
+	require.Nil(t, err)
+
+	userAccount := account.(vmcommon.UserAccountHandler)
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue(tokenKey, marshalledToken))
+	require.Nil(t, accounts.SaveAccount(userAccount))
+}
+
+func createTransferInput(sender []byte, receiver []byte, value int64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   [][]byte{tokenID, big.NewInt(value).Bytes()},
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+		},
+		RecipientAddr: receiver,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+}
+
+// recordTransfer records a DCT transfer in an environment built the same way the verifier builds its own
+func recordTransfer(t *testing.T, value int64) *vmcommon.ExecutionRecord {
+	sender := createAddress(1)
+	receiver := createAddress(2)
+	accounts := inMemoryState.NewAccountsAdapter()
+	saveDCTBalance(t, accounts, sender, 100)
+	saveDCTBalance(t, accounts, receiver, 5)
+
+	args := createMockArgsVerifier()
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           args.GasMap,
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      args.Marshalizer,
+		Accounts:                         accounts,
+		ShardCoordinator:                 args.ShardCoordinator,
+		EnableEpochsHandler:              args.EnableEpochsHandler,
+		GuardedAccountHandler:            args.GuardedAccountHandler,
+		MaxNumOfAddressesForTransferRole: args.MaxNumOfAddressesForTransferRole,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	record, err := creator.RecordBuiltInFunction(createTransferInput(sender, receiver, value))
+	require.Nil(t, err)
+
+	return record
+}
+
+func TestNewVerifier(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsVerifier()
+	args.GasMap = nil
+	v, err := NewVerifier(args)
+	assert.Nil(t, v)
+	assert.Equal(t, ErrNilGasMap, err)
+
+	args = createMockArgsVerifier()
+	args.Marshalizer = nil
+	v, err = NewVerifier(args)
+	assert.Nil(t, v)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	args = createMockArgsVerifier()
+	args.ShardCoordinator = nil
+	v, err = NewVerifier(args)
+	assert.Nil(t, v)
+	assert.Equal(t, ErrNilShardCoordinator, err)
+
+	args = createMockArgsVerifier()
+	args.EnableEpochsHandler = nil
+	v, err = NewVerifier(args)
+	assert.Nil(t, v)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	args = createMockArgsVerifier()
+	args.GuardedAccountHandler = nil
+	v, err = NewVerifier(args)
+	assert.Nil(t, v)
+	assert.Equal(t, ErrNilGuardedAccountHandler, err)
+
+	v, err = NewVerifier(createMockArgsVerifier())
+	assert.Nil(t, err)
+	assert.False(t, v.IsInterfaceNil())
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid record should error", func(t *testing.T) {
+		t.Parallel()
+
+		v, _ := NewVerifier(createMockArgsVerifier())
+		report, err := v.Verify(nil)
+		assert.Nil(t, report)
+		assert.Equal(t, ErrNilRecord, err)
+
+		report, err = v.Verify(&vmcommon.ExecutionRecord{})
+		assert.Nil(t, report)
+		assert.Equal(t, ErrNilInput, err)
+
+		report, err = v.Verify(&vmcommon.ExecutionRecord{
+			Input:    createTransferInput(createAddress(1), createAddress(2), 1),
+			PreState: []*vmcommon.AccountState{nil},
+		})
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, ErrInvalidRecord)
+	})
+	t.Run("decoded record should match", func(t *testing.T) {
+		t.Parallel()
+
+		record := recordTransfer(t, 30)
+		require.Empty(t, record.Error)
+		require.Equal(t, 3, len(record.PreState))
+
+		data, err := Marshal(record)
+		require.Nil(t, err)
+
+		v, _ := NewVerifier(createMockArgsVerifier())
+		report, err := v.VerifyData(data)
+		require.Nil(t, err)
+		assert.True(t, report.IsMatch(), "%v", report.Divergences)
+		assert.Equal(t, vmcommon.Ok, report.Replayed.VMOutput.ReturnCode)
+	})
+	t.Run("failed call should match", func(t *testing.T) {
+		t.Parallel()
+
+		record := recordTransfer(t, 1000)
+		require.NotEmpty(t, record.Error)
+		require.Nil(t, record.VMOutput)
+
+		v, _ := NewVerifier(createMockArgsVerifier())
+		report, err := v.Verify(record)
+		require.Nil(t, err)
+		assert.True(t, report.IsMatch(), "%v", report.Divergences)
+	})
+	t.Run("changed output should diverge", func(t *testing.T) {
+		t.Parallel()
+
+		record := recordTransfer(t, 30)
+		record.VMOutput.GasRemaining++
+
+		v, _ := NewVerifier(createMockArgsVerifier())
+		report, err := v.Verify(record)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(report.Divergences))
+		assert.Equal(t, "GasRemaining", report.Divergences[0].Field)
+		assert.Equal(t, "GasRemaining: expected 100, got 99", report.Divergences[0].String())
+	})
+	t.Run("changed pre-state should diverge", func(t *testing.T) {
+		t.Parallel()
+
+		record := recordTransfer(t, 30)
+		record.PreState[0].Storage = nil
+
+		v, _ := NewVerifier(createMockArgsVerifier())
+		report, err := v.Verify(record)
+		require.Nil(t, err)
+
+		fields := make([]string, 0, len(report.Divergences))
+		for _, divergence := range report.Divergences {
+			fields = append(fields, divergence.Field)
+		}
+		assert.Equal(t, []string{"Error", "PreState"}, fields)
+	})
+}
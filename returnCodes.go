@@ -1,6 +1,10 @@
 package vmcommon
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
 
 // ReturnCode is an enum with the possible error codes returned by the VM
 type ReturnCode int
@@ -29,6 +33,10 @@ func (rc ReturnCode) String() string {
 		return "contract invalid"
 	case ExecutionFailed:
 		return "execution failed"
+	case UpgradeFailed:
+		return "upgrade failed"
+	case SimulateFailed:
+		return "simulate failed"
 	default:
 		return fmt.Sprintf("unknown error, code: %d", rc)
 	}
@@ -74,3 +82,119 @@ const (
 	// SimulateFailed is returned when tx simulation fails execution
 	SimulateFailed ReturnCode = 12
 )
+
+// maxKnownReturnCode is the highest return code defined above
+const maxKnownReturnCode = SimulateFailed
+
+// ReturnCodeCategory groups the return codes by the way a caller should react to them
+type ReturnCodeCategory string
+
+const (
+	// SuccessCategory holds the return code of the calls which completed normally
+	SuccessCategory ReturnCodeCategory = "success"
+	// RetriableCategory holds the return codes of the calls which may succeed if retried unchanged with more gas
+	// or after the sender received more funds
+	RetriableCategory ReturnCodeCategory = "retriable"
+	// UserFaultCategory holds the return codes of the calls which failed because of their input or of the called
+	// contract
+	UserFaultCategory ReturnCodeCategory = "user fault"
+	// ProtocolFaultCategory holds the return codes of the calls which failed because of the execution environment
+	ProtocolFaultCategory ReturnCodeCategory = "protocol fault"
+	// UnknownCategory holds the return codes which are not defined
+	UnknownCategory ReturnCodeCategory = "unknown"
+)
+
+// Category returns the category of the return code
+func (rc ReturnCode) Category() ReturnCodeCategory {
+	switch rc {
+	case Ok:
+		return SuccessCategory
+	case OutOfGas, OutOfFunds:
+		return RetriableCategory
+	case FunctionNotFound, FunctionWrongSignature, ContractNotFound, UserError, CallStackOverFlow, ContractInvalid, UpgradeFailed:
+		return UserFaultCategory
+	case AccountCollision, ExecutionFailed, SimulateFailed:
+		return ProtocolFaultCategory
+	default:
+		return UnknownCategory
+	}
+}
+
+// IsRetriable returns true if the call may succeed if retried unchanged with more gas or funds
+func (rc ReturnCode) IsRetriable() bool {
+	return rc.Category() == RetriableCategory
+}
+
+// IsUserFault returns true if the call failed because of its input or of the called contract
+func (rc ReturnCode) IsUserFault() bool {
+	return rc.Category() == UserFaultCategory
+}
+
+// IsProtocolFault returns true if the call failed because of the execution environment
+func (rc ReturnCode) IsProtocolFault() bool {
+	return rc.Category() == ProtocolFaultCategory
+}
+
+// ParseReturnCode parses either the textual representation or the numeric value of a return code
+func ParseReturnCode(text string) (ReturnCode, error) {
+	for returnCode := Ok; returnCode <= maxKnownReturnCode; returnCode++ {
+		if returnCode.String() == text {
+			return returnCode, nil
+		}
+	}
+
+	number, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownReturnCode, text)
+	}
+
+	return ReturnCode(number), nil
+}
+
+// MarshalText returns the textual representation of the known return codes and the numeric value of the others
+func (rc ReturnCode) MarshalText() ([]byte, error) {
+	if rc < Ok || rc > maxKnownReturnCode {
+		return []byte(strconv.Itoa(int(rc))), nil
+	}
+
+	return []byte(rc.String()), nil
+}
+
+// UnmarshalText parses either the textual representation or the numeric value of a return code
+func (rc *ReturnCode) UnmarshalText(text []byte) error {
+	returnCode, err := ParseReturnCode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*rc = returnCode
+	return nil
+}
+
+// MarshalJSON encodes the return code as its textual representation
+func (rc ReturnCode) MarshalJSON() ([]byte, error) {
+	text, err := rc.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a return code encoded either as a string or, as before the textual representation was
+// used, as a number
+func (rc *ReturnCode) UnmarshalJSON(data []byte) error {
+	var number int
+	if json.Unmarshal(data, &number) == nil {
+		*rc = ReturnCode(number)
+		return nil
+	}
+
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownReturnCode, data)
+	}
+
+	return rc.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,112 @@
+package vmcommon
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturnCode_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "upgrade failed", UpgradeFailed.String())
+	assert.Equal(t, "simulate failed", SimulateFailed.String())
+	assert.Equal(t, "unknown error, code: 13", ReturnCode(13).String())
+	for returnCode := Ok; returnCode <= maxKnownReturnCode; returnCode++ {
+		assert.NotContains(t, returnCode.String(), "unknown", returnCode)
+	}
+}
+
+func TestReturnCode_Category(t *testing.T) {
+	t.Parallel()
+
+	expected := map[ReturnCode]ReturnCodeCategory{
+		Ok:                     SuccessCategory,
+		FunctionNotFound:       UserFaultCategory,
+		FunctionWrongSignature: UserFaultCategory,
+		ContractNotFound:       UserFaultCategory,
+		UserError:              UserFaultCategory,
+		OutOfGas:               RetriableCategory,
+		AccountCollision:       ProtocolFaultCategory,
+		OutOfFunds:             RetriableCategory,
+		CallStackOverFlow:      UserFaultCategory,
+		ContractInvalid:        UserFaultCategory,
+		ExecutionFailed:        ProtocolFaultCategory,
+		UpgradeFailed:          UserFaultCategory,
+		SimulateFailed:         ProtocolFaultCategory,
+		ReturnCode(-1):         UnknownCategory,
+		ReturnCode(13):         UnknownCategory,
+	}
+	for returnCode, category := range expected {
+		assert.Equal(t, category, returnCode.Category(), returnCode)
+		assert.Equal(t, category == RetriableCategory, returnCode.IsRetriable(), returnCode)
+		assert.Equal(t, category == UserFaultCategory, returnCode.IsUserFault(), returnCode)
+		assert.Equal(t, category == ProtocolFaultCategory, returnCode.IsProtocolFault(), returnCode)
+	}
+}
+
+func TestParseReturnCode(t *testing.T) {
+	t.Parallel()
+
+	returnCode, err := ParseReturnCode("out of funds")
+	assert.Nil(t, err)
+	assert.Equal(t, OutOfFunds, returnCode)
+
+	returnCode, err = ParseReturnCode("12")
+	assert.Nil(t, err)
+	assert.Equal(t, SimulateFailed, returnCode)
+
+	returnCode, err = ParseReturnCode("42")
+	assert.Nil(t, err)
+	assert.Equal(t, ReturnCode(42), returnCode)
+
+	_, err = ParseReturnCode("out of time")
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+}
+
+func TestReturnCode_TextRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for returnCode := ReturnCode(-1); returnCode <= maxKnownReturnCode+1; returnCode++ {
+		text, err := returnCode.MarshalText()
+		require.Nil(t, err)
+
+		var decoded ReturnCode
+		require.Nil(t, decoded.UnmarshalText(text))
+		assert.Equal(t, returnCode, decoded)
+	}
+
+	text, _ := OutOfGas.MarshalText()
+	assert.Equal(t, "out of gas", string(text))
+	text, _ = ReturnCode(13).MarshalText()
+	assert.Equal(t, "13", string(text))
+}
+
+func TestReturnCode_JSON(t *testing.T) {
+	t.Parallel()
+
+	type output struct {
+		ReturnCode ReturnCode
+		Codes      map[string]ReturnCode
+	}
+
+	encoded, err := json.Marshal(&output{ReturnCode: UserError, Codes: map[string]ReturnCode{"a": Ok}})
+	require.Nil(t, err)
+	assert.Equal(t, `{"ReturnCode":"user error","Codes":{"a":"ok"}}`, string(encoded))
+
+	decoded := &output{}
+	require.Nil(t, json.Unmarshal(encoded, decoded))
+	assert.Equal(t, UserError, decoded.ReturnCode)
+	assert.Equal(t, Ok, decoded.Codes["a"])
+
+	require.Nil(t, json.Unmarshal([]byte(`{"ReturnCode":7}`), decoded))
+	assert.Equal(t, OutOfFunds, decoded.ReturnCode)
+
+	err = json.Unmarshal([]byte(`{"ReturnCode":"not a code"}`), decoded)
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+	err = json.Unmarshal([]byte(`{"ReturnCode":true}`), decoded)
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+}
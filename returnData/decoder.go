@@ -0,0 +1,351 @@
+package returnData
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+const lengthPrefixSize = 4
+
+// DecodeVMOutput decodes the return data of the provided VM output according to the provided types
+func DecodeVMOutput(vmOutput *vmcommon.VMOutput, types ...*Type) ([]interface{}, error) {
+	if vmOutput == nil {
+		return nil, ErrNilVMOutput
+	}
+
+	return Decode(vmOutput.ReturnData, types...)
+}
+
+// Decode decodes each return data element according to the type on the same position. Only the last types
+// can be optional; they are decoded as nil when the return data ends before them.
+func Decode(returnData [][]byte, types ...*Type) ([]interface{}, error) {
+	for i, t := range types {
+		err := t.check()
+		if err != nil {
+			return nil, fmt.Errorf("%w for type %d", err, i)
+		}
+		isLastOrFollowedByOptional := i == len(types)-1 || types[i+1].IsOptional()
+		if t.IsOptional() && !isLastOrFollowedByOptional {
+			return nil, fmt.Errorf("%w, found %s at position %d", ErrOptionalNotLast, t, i)
+		}
+	}
+	if len(returnData) > len(types) {
+		return nil, fmt.Errorf("%w, expected at most %d, got %d", ErrTooManyReturnData, len(types), len(returnData))
+	}
+
+	values := make([]interface{}, len(types))
+	for i, t := range types {
+		if i >= len(returnData) {
+			if !t.IsOptional() {
+				return nil, fmt.Errorf("%w, expected %s at position %d", ErrMissingReturnData, t, i)
+			}
+			continue
+		}
+
+		elementType := t
+		if t.IsOptional() {
+			elementType = t.Element
+		}
+		value, err := DecodeTopLevel(returnData[i], elementType)
+		if err != nil {
+			return nil, fmt.Errorf("%w for return data %d", err, i)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// DecodeTopLevel decodes a single return data element holding the top-level encoding of the provided type
+func DecodeTopLevel(data []byte, t *Type) (interface{}, error) {
+	err := t.check()
+	if err != nil {
+		return nil, err
+	}
+	if t.IsOptional() {
+		return nil, fmt.Errorf("%w, %s can not be decoded from a single element", ErrOptionalNotLast, t)
+	}
+
+	return decodeTopLevel(data, t)
+}
+
+// DecodeNested decodes the nested encoding of the provided type found at the beginning of data and returns the
+// decoded value along with the number of bytes read
+func DecodeNested(data []byte, t *Type) (interface{}, int, error) {
+	err := t.check()
+	if err != nil {
+		return nil, 0, err
+	}
+	if t.IsOptional() {
+		return nil, 0, fmt.Errorf("%w, %s can not be nested", ErrOptionalNotLast, t)
+	}
+
+	reader := &reader{data: data}
+	value, err := reader.readNested(t)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value, reader.offset, nil
+}
+
+func decodeTopLevel(data []byte, t *Type) (interface{}, error) {
+	switch t.Kind {
+	case KindU8, KindU16, KindU32, KindU64:
+		if len(data) > t.fixedWidth() {
+			return nil, fmt.Errorf("%w, %d bytes for %s", ErrInvalidEncoding, len(data), t)
+		}
+		return toUnsigned(big.NewInt(0).SetBytes(data).Uint64(), t), nil
+	case KindI64:
+		if len(data) > t.fixedWidth() {
+			return nil, fmt.Errorf("%w, %d bytes for %s", ErrInvalidEncoding, len(data), t)
+		}
+		return fromTwosComplement(data).Int64(), nil
+	case KindBigUint:
+		return big.NewInt(0).SetBytes(data), nil
+	case KindBigInt:
+		return fromTwosComplement(data), nil
+	case KindBool:
+		if len(data) == 0 {
+			return false, nil
+		}
+		if len(data) == 1 && data[0] == 1 {
+			return true, nil
+		}
+		return nil, fmt.Errorf("%w, %x for %s", ErrInvalidEncoding, data, t)
+	case KindString:
+		return string(data), nil
+	case KindBytes:
+		return append(make([]byte, 0, len(data)), data...), nil
+	case KindOption:
+		if len(data) == 0 {
+			return nil, nil
+		}
+		if data[0] != 1 {
+			return nil, fmt.Errorf("%w, option tag %d", ErrInvalidEncoding, data[0])
+		}
+	}
+
+	reader := &reader{data: data}
+	var value interface{}
+	var err error
+	switch t.Kind {
+	case KindList:
+		list := make([]interface{}, 0)
+		for reader.remaining() > 0 {
+			value, err = reader.readNested(t.Element)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case KindOption:
+		reader.offset = 1
+		value, err = reader.readNested(t.Element)
+	case KindTokenIdentifier:
+		value, err = reader.readTokenIdentifier(len(data))
+	default:
+		value, err = reader.readNested(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if reader.remaining() > 0 {
+		return nil, fmt.Errorf("%w, %d trailing bytes for %s", ErrInvalidEncoding, reader.remaining(), t)
+	}
+
+	return value, nil
+}
+
+type reader struct {
+	data   []byte
+	offset int
+}
+
+func (r *reader) remaining() int {
+	return len(r.data) - r.offset
+}
+
+func (r *reader) read(length int) ([]byte, error) {
+	if length < 0 || length > r.remaining() {
+		return nil, fmt.Errorf("%w, need %d bytes, have %d", ErrInvalidEncoding, length, r.remaining())
+	}
+
+	value := r.data[r.offset : r.offset+length]
+	r.offset += length
+
+	return value, nil
+}
+
+func (r *reader) readLength() (int, error) {
+	buff, err := r.read(lengthPrefixSize)
+	if err != nil {
+		return 0, err
+	}
+
+	length := binary.BigEndian.Uint32(buff)
+	if int64(length) > int64(r.remaining()) {
+		return 0, fmt.Errorf("%w, length %d exceeds the %d remaining bytes", ErrInvalidEncoding, length, r.remaining())
+	}
+
+	return int(length), nil
+}
+
+func (r *reader) readLengthPrefixed() ([]byte, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.read(length)
+}
+
+func (r *reader) readTokenIdentifier(length int) (string, error) {
+	buff, err := r.read(length)
+	if err != nil {
+		return "", err
+	}
+	if !vmcommon.ValidateToken(buff) {
+		return "", fmt.Errorf("%w, invalid token identifier %q", ErrInvalidEncoding, buff)
+	}
+
+	return string(buff), nil
+}
+
+func (r *reader) readNested(t *Type) (interface{}, error) {
+	switch t.Kind {
+	case KindU8, KindU16, KindU32, KindU64, KindI64:
+		buff, err := r.read(t.fixedWidth())
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == KindI64 {
+			return int64(binary.BigEndian.Uint64(buff)), nil
+		}
+		return toUnsigned(big.NewInt(0).SetBytes(buff).Uint64(), t), nil
+	case KindBigUint, KindBigInt:
+		buff, err := r.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == KindBigInt {
+			return fromTwosComplement(buff), nil
+		}
+		return big.NewInt(0).SetBytes(buff), nil
+	case KindAddress:
+		buff, err := r.read(AddressLength)
+		if err != nil {
+			return nil, err
+		}
+		return append(make([]byte, 0, AddressLength), buff...), nil
+	case KindBool:
+		buff, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if buff[0] > 1 {
+			return nil, fmt.Errorf("%w, %d for %s", ErrInvalidEncoding, buff[0], t)
+		}
+		return buff[0] == 1, nil
+	case KindString:
+		buff, err := r.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return string(buff), nil
+	case KindBytes:
+		buff, err := r.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return append(make([]byte, 0, len(buff)), buff...), nil
+	case KindTokenIdentifier:
+		length, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		return r.readTokenIdentifier(length)
+	case KindList:
+		return r.readNestedList(t)
+	case KindOption:
+		return r.readNestedOption(t)
+	case KindStruct:
+		return r.readNestedStruct(t)
+	}
+
+	return nil, fmt.Errorf("%w %d", ErrUnknownKind, t.Kind)
+}
+
+func (r *reader) readNestedList(t *Type) (interface{}, error) {
+	numElements, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]interface{}, 0, numElements)
+	for i := 0; i < numElements; i++ {
+		value, errRead := r.readNested(t.Element)
+		if errRead != nil {
+			return nil, errRead
+		}
+		list = append(list, value)
+	}
+
+	return list, nil
+}
+
+func (r *reader) readNestedOption(t *Type) (interface{}, error) {
+	tag, err := r.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag[0] {
+	case 0:
+		return nil, nil
+	case 1:
+		return r.readNested(t.Element)
+	}
+
+	return nil, fmt.Errorf("%w, option tag %d", ErrInvalidEncoding, tag[0])
+}
+
+func (r *reader) readNestedStruct(t *Type) (interface{}, error) {
+	fields := make(map[string]interface{}, len(t.Fields))
+	for _, field := range t.Fields {
+		value, err := r.readNested(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%w for field %s", err, field.Name)
+		}
+		fields[field.Name] = value
+	}
+
+	return fields, nil
+}
+
+func toUnsigned(value uint64, t *Type) interface{} {
+	switch t.Kind {
+	case KindU8:
+		return uint8(value)
+	case KindU16:
+		return uint16(value)
+	case KindU32:
+		return uint32(value)
+	}
+
+	return value
+}
+
+// fromTwosComplement interprets the provided bytes as a big endian two's complement number
+func fromTwosComplement(data []byte) *big.Int {
+	value := big.NewInt(0).SetBytes(data)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		value.Sub(value, big.NewInt(0).Lsh(big.NewInt(1), uint(len(data)*8)))
+	}
+
+	return value
+}
@@ -0,0 +1,193 @@
+package returnData
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func TestType_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "optional<List<Option<BigUint>>>", Optional(List(Option(BigUint))).String())
+	assert.Equal(t, "TokenIdentifier", TokenIdentifier.String())
+	assert.Equal(t, "List<Pair>", List(Struct("Pair")).String())
+	assert.Equal(t, "unknown(100)", (&Type{Kind: 100}).String())
+}
+
+func TestDecodeTopLevel(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{7}, AddressLength)
+	testCases := []struct {
+		name     string
+		data     []byte
+		t        *Type
+		expected interface{}
+	}{
+		{name: "empty u8", data: []byte{}, t: U8, expected: uint8(0)},
+		{name: "u16", data: []byte{1, 2}, t: U16, expected: uint16(258)},
+		{name: "short u32", data: []byte{5}, t: U32, expected: uint32(5)},
+		{name: "u64", data: []byte{1, 0, 0, 0, 0, 0, 0, 0}, t: U64, expected: uint64(1) << 56},
+		{name: "negative i64", data: []byte{0xff}, t: I64, expected: int64(-1)},
+		{name: "positive i64", data: []byte{0x00, 0x80}, t: I64, expected: int64(128)},
+		{name: "BigUint", data: []byte{0xff, 0xff}, t: BigUint, expected: big.NewInt(65535)},
+		{name: "negative BigInt", data: []byte{0xff, 0x00}, t: BigInt, expected: big.NewInt(-256)},
+		{name: "empty BigInt", data: []byte{}, t: BigInt, expected: big.NewInt(0)},
+		{name: "address", data: address, t: Address, expected: address},
+		{name: "false", data: []byte{}, t: Bool, expected: false},
+		{name: "true", data: []byte{1}, t: Bool, expected: true},
+		{name: "string", data: []byte("text"), t: String, expected: "text"},
+		{name: "token identifier", data: []byte("TKN-abcdef"), t: TokenIdentifier, expected: "TKN-abcdef"},
+		{name: "list", data: []byte{0, 1, 0, 2}, t: List(U16), expected: []interface{}{uint16(1), uint16(2)}},
+		{name: "empty list", data: []byte{}, t: List(U16), expected: []interface{}{}},
+		{name: "missing option", data: []byte{}, t: Option(U8), expected: nil},
+		{name: "present option", data: []byte{1, 0, 0, 0, 1, 9}, t: Option(BigUint), expected: big.NewInt(9)},
+		{
+			name: "nested lists",
+			data: []byte{0, 0, 0, 2, 1, 0, 0, 0, 0, 0},
+			t:    List(List(Bool)),
+			expected: []interface{}{
+				[]interface{}{true, false},
+				[]interface{}{},
+			},
+		},
+		{name: "bytes", data: []byte{0, 1}, t: Bytes, expected: []byte{0, 1}},
+		{
+			name:     "struct",
+			data:     []byte{0, 0, 0, 1, 0xaa, 0, 0, 0, 2, 'o', 'k', 1},
+			t:        Struct("Info", &Field{Name: "data", Type: Bytes}, &Field{Name: "text", Type: String}, &Field{Name: "ok", Type: Bool}),
+			expected: map[string]interface{}{"data": []byte{0xaa}, "text": "ok", "ok": true},
+		},
+		{
+			name:     "list of options",
+			data:     []byte{0, 1, 0, 0, 0, 1, 0xff},
+			t:        List(Option(BigInt)),
+			expected: []interface{}{nil, big.NewInt(-1)},
+		},
+	}
+
+	for _, tc := range testCases {
+		value, err := DecodeTopLevel(tc.data, tc.t)
+		require.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expected, value, tc.name)
+	}
+}
+
+func TestDecodeTopLevel_InvalidDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		data []byte
+		t    *Type
+	}{
+		{name: "u8 too long", data: []byte{1, 2}, t: U8},
+		{name: "i64 too long", data: make([]byte, 9), t: I64},
+		{name: "address too short", data: []byte{1}, t: Address},
+		{name: "address too long", data: make([]byte, AddressLength+1), t: Address},
+		{name: "invalid bool", data: []byte{2}, t: Bool},
+		{name: "invalid token identifier", data: []byte("tkn-abcdef"), t: TokenIdentifier},
+		{name: "truncated list element", data: []byte{0, 1, 0}, t: List(U16)},
+		{name: "invalid option tag", data: []byte{2, 1}, t: Option(U8)},
+		{name: "option trailing bytes", data: []byte{1, 1, 1}, t: Option(U8)},
+		{name: "length exceeding data", data: []byte{0, 0, 0, 5, 1}, t: List(String)},
+	}
+
+	for _, tc := range testCases {
+		_, err := DecodeTopLevel(tc.data, tc.t)
+		assert.True(t, errors.Is(err, ErrInvalidEncoding), tc.name)
+	}
+
+	_, err := DecodeTopLevel(nil, nil)
+	assert.Equal(t, ErrNilType, err)
+	_, err = DecodeTopLevel(nil, List(nil))
+	assert.Equal(t, ErrNilType, err)
+	_, err = DecodeTopLevel(nil, &Type{Kind: 100})
+	assert.True(t, errors.Is(err, ErrUnknownKind))
+	_, err = DecodeTopLevel(nil, Optional(U8))
+	assert.True(t, errors.Is(err, ErrOptionalNotLast))
+	_, err = DecodeTopLevel(nil, List(Optional(U8)))
+	assert.True(t, errors.Is(err, ErrOptionalNotLast))
+	_, err = DecodeTopLevel(nil, Struct("Pair", &Field{Name: "a", Type: U8}, &Field{Name: "a", Type: U16}))
+	assert.True(t, errors.Is(err, ErrDuplicatedField))
+}
+
+func TestDecodeNested(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{0, 0, 0, 10, 'T', 'K', 'N', '-', 'a', 'b', 'c', 'd', 'e', 'f', 0xff}
+	value, read, err := DecodeNested(data, TokenIdentifier)
+	require.Nil(t, err)
+	assert.Equal(t, "TKN-abcdef", value)
+	assert.Equal(t, 14, read)
+
+	value, read, err = DecodeNested([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe, 1}, I64)
+	require.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+	assert.Equal(t, 8, read)
+
+	_, _, err = DecodeNested([]byte{0, 0}, U32)
+	assert.True(t, errors.Is(err, ErrInvalidEncoding))
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should decode all elements", func(t *testing.T) {
+		t.Parallel()
+
+		values, err := Decode(
+			[][]byte{{1}, []byte("text"), {5}},
+			Bool, String, List(U8), Optional(U8), Optional(String),
+		)
+		require.Nil(t, err)
+		assert.Equal(t, []interface{}{true, "text", []interface{}{uint8(5)}, nil, nil}, values)
+	})
+	t.Run("should decode present optionals", func(t *testing.T) {
+		t.Parallel()
+
+		values, err := Decode([][]byte{{1}, {}}, U8, Optional(BigUint))
+		require.Nil(t, err)
+		assert.Equal(t, []interface{}{uint8(1), big.NewInt(0)}, values)
+	})
+	t.Run("arity mismatches should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode([][]byte{{1}}, U8, U8)
+		assert.True(t, errors.Is(err, ErrMissingReturnData))
+
+		_, err = Decode([][]byte{{1}, {2}}, U8)
+		assert.True(t, errors.Is(err, ErrTooManyReturnData))
+
+		_, err = Decode([][]byte{{1}, {2}}, U8, Optional(U8), U8)
+		assert.True(t, errors.Is(err, ErrOptionalNotLast))
+	})
+	t.Run("invalid elements should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode([][]byte{{1}, {1, 2}}, U8, U8)
+		assert.True(t, errors.Is(err, ErrInvalidEncoding))
+		assert.Contains(t, err.Error(), "for return data 1")
+
+		_, err = Decode([][]byte{{1}}, U8, nil)
+		assert.True(t, errors.Is(err, ErrNilType))
+	})
+}
+
+func TestDecodeVMOutput(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeVMOutput(nil, U8)
+	assert.Equal(t, ErrNilVMOutput, err)
+
+	vmOutput := &vmcommon.VMOutput{ReturnData: [][]byte{big.NewInt(1000).Bytes(), []byte("TKN-abcdef")}}
+	values, err := DecodeVMOutput(vmOutput, BigUint, TokenIdentifier)
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{big.NewInt(1000), "TKN-abcdef"}, values)
+}
@@ -0,0 +1,27 @@
+package returnData
+
+import "errors"
+
+// ErrNilVMOutput signals that a nil VM output was provided
+var ErrNilVMOutput = errors.New("nil VM output")
+
+// ErrNilType signals that a nil type was provided
+var ErrNilType = errors.New("nil type")
+
+// ErrUnknownKind signals that the provided type has an unknown kind
+var ErrUnknownKind = errors.New("unknown type kind")
+
+// ErrMissingReturnData signals that there are fewer return data elements than required types
+var ErrMissingReturnData = errors.New("missing return data")
+
+// ErrTooManyReturnData signals that there are more return data elements than provided types
+var ErrTooManyReturnData = errors.New("too many return data")
+
+// ErrOptionalNotLast signals that an optional type was followed by other types
+var ErrOptionalNotLast = errors.New("optional type must be the last one")
+
+// ErrInvalidEncoding signals that the data does not hold a valid encoding of the requested type
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// ErrDuplicatedField signals that a struct type holds the same field name more than once
+var ErrDuplicatedField = errors.New("duplicated field")
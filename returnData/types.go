@@ -0,0 +1,225 @@
+package returnData
+
+import "fmt"
+
+// Kind specifies the kind of value held by a type
+type Kind uint8
+
+const (
+	// KindU8 is an unsigned 8 bits integer, decoded as uint8
+	KindU8 Kind = iota + 1
+	// KindU16 is an unsigned 16 bits integer, decoded as uint16
+	KindU16
+	// KindU32 is an unsigned 32 bits integer, decoded as uint32
+	KindU32
+	// KindU64 is an unsigned 64 bits integer, decoded as uint64
+	KindU64
+	// KindI64 is a signed 64 bits integer, decoded as int64
+	KindI64
+	// KindBigUint is an arbitrary size unsigned integer, decoded as *big.Int
+	KindBigUint
+	// KindBigInt is an arbitrary size signed integer, decoded as *big.Int
+	KindBigInt
+	// KindAddress is an account address, decoded as []byte
+	KindAddress
+	// KindBool is a boolean, decoded as bool
+	KindBool
+	// KindString is an arbitrary string, decoded as string
+	KindString
+	// KindTokenIdentifier is a token identifier, decoded as string
+	KindTokenIdentifier
+	// KindList is a list of elements of the same type, decoded as []interface{}
+	KindList
+	// KindOption is a value that might be missing, decoded as nil or as the element value
+	KindOption
+	// KindOptional is a trailing return data element that might be missing, decoded as nil or as the element value
+	KindOptional
+	// KindBytes is an arbitrary bytes buffer, decoded as []byte
+	KindBytes
+	// KindStruct is a named sequence of fields, decoded as map[string]interface{} keyed by the field names
+	KindStruct
+)
+
+const maxKind = KindStruct
+
+// AddressLength is the length of the encoded addresses
+const AddressLength = 32
+
+// Type describes how a return data value is encoded
+type Type struct {
+	Kind    Kind
+	Element *Type
+	Name    string
+	Fields  []*Field
+}
+
+// Field is a named member of a struct type
+type Field struct {
+	Name string
+	Type *Type
+}
+
+// U8 is the unsigned 8 bits integer type
+var U8 = &Type{Kind: KindU8}
+
+// U16 is the unsigned 16 bits integer type
+var U16 = &Type{Kind: KindU16}
+
+// U32 is the unsigned 32 bits integer type
+var U32 = &Type{Kind: KindU32}
+
+// U64 is the unsigned 64 bits integer type
+var U64 = &Type{Kind: KindU64}
+
+// I64 is the signed 64 bits integer type
+var I64 = &Type{Kind: KindI64}
+
+// BigUint is the arbitrary size unsigned integer type
+var BigUint = &Type{Kind: KindBigUint}
+
+// BigInt is the arbitrary size signed integer type
+var BigInt = &Type{Kind: KindBigInt}
+
+// Address is the account address type
+var Address = &Type{Kind: KindAddress}
+
+// Bool is the boolean type
+var Bool = &Type{Kind: KindBool}
+
+// String is the arbitrary string type
+var String = &Type{Kind: KindString}
+
+// TokenIdentifier is the token identifier type
+var TokenIdentifier = &Type{Kind: KindTokenIdentifier}
+
+// Bytes is the arbitrary bytes buffer type
+var Bytes = &Type{Kind: KindBytes}
+
+// List returns the type of a list holding elements of the provided type
+func List(element *Type) *Type {
+	return &Type{Kind: KindList, Element: element}
+}
+
+// Option returns the type of a value of the provided type that might be missing
+func Option(element *Type) *Type {
+	return &Type{Kind: KindOption, Element: element}
+}
+
+// Optional returns the type of a trailing return data element of the provided type that might be missing
+func Optional(element *Type) *Type {
+	return &Type{Kind: KindOptional, Element: element}
+}
+
+// Struct returns the type of a struct with the provided name and fields
+func Struct(name string, fields ...*Field) *Type {
+	return &Type{Kind: KindStruct, Name: name, Fields: fields}
+}
+
+// String returns the human-readable name of the type
+func (t *Type) String() string {
+	if t == nil {
+		return "nil"
+	}
+
+	switch t.Kind {
+	case KindU8:
+		return "u8"
+	case KindU16:
+		return "u16"
+	case KindU32:
+		return "u32"
+	case KindU64:
+		return "u64"
+	case KindI64:
+		return "i64"
+	case KindBigUint:
+		return "BigUint"
+	case KindBigInt:
+		return "BigInt"
+	case KindAddress:
+		return "Address"
+	case KindBool:
+		return "bool"
+	case KindString:
+		return "string"
+	case KindTokenIdentifier:
+		return "TokenIdentifier"
+	case KindList:
+		return fmt.Sprintf("List<%s>", t.Element)
+	case KindOption:
+		return fmt.Sprintf("Option<%s>", t.Element)
+	case KindOptional:
+		return fmt.Sprintf("optional<%s>", t.Element)
+	case KindBytes:
+		return "bytes"
+	case KindStruct:
+		return t.Name
+	}
+
+	return fmt.Sprintf("unknown(%d)", t.Kind)
+}
+
+func (t *Type) check() error {
+	if t == nil {
+		return ErrNilType
+	}
+
+	switch t.Kind {
+	case KindList, KindOption, KindOptional:
+		if t.Element.IsOptional() {
+			return fmt.Errorf("%w in %s", ErrOptionalNotLast, t)
+		}
+		return t.Element.check()
+	case KindStruct:
+		return t.checkFields()
+	}
+	if t.Kind < KindU8 || t.Kind > maxKind {
+		return fmt.Errorf("%w %d", ErrUnknownKind, t.Kind)
+	}
+
+	return nil
+}
+
+func (t *Type) checkFields() error {
+	names := make(map[string]struct{}, len(t.Fields))
+	for _, field := range t.Fields {
+		if field == nil {
+			return fmt.Errorf("%w in %s", ErrNilType, t)
+		}
+		_, found := names[field.Name]
+		if found {
+			return fmt.Errorf("%w %s in %s", ErrDuplicatedField, field.Name, t)
+		}
+		names[field.Name] = struct{}{}
+		if field.Type.IsOptional() {
+			return fmt.Errorf("%w in %s", ErrOptionalNotLast, t)
+		}
+		err := field.Type.check()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsOptional returns true if the type describes a trailing return data element that might be missing
+func (t *Type) IsOptional() bool {
+	return t != nil && t.Kind == KindOptional
+}
+
+// fixedWidth returns the width of the fixed size integers
+func (t *Type) fixedWidth() int {
+	switch t.Kind {
+	case KindU8:
+		return 1
+	case KindU16:
+		return 2
+	case KindU32:
+		return 4
+	case KindU64, KindI64:
+		return 8
+	}
+
+	return 0
+}
@@ -0,0 +1,395 @@
+package scenarios
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/builtInFunctions"
+)
+
+func checkExpectation(expect *Expectation, vmOutput *vmcommon.VMOutput, errProcess error) error {
+	if expect != nil && len(expect.Error) > 0 {
+		if errProcess == nil {
+			return fmt.Errorf("%w: expected error containing \"%s\", got none", ErrCheckFailed, expect.Error)
+		}
+		if !strings.Contains(errProcess.Error(), expect.Error) {
+			return fmt.Errorf("%w: expected error containing \"%s\", got \"%s\"", ErrCheckFailed, expect.Error, errProcess.Error())
+		}
+
+		return nil
+	}
+	if errProcess != nil {
+		return fmt.Errorf("%w: unexpected error \"%s\"", ErrCheckFailed, errProcess.Error())
+	}
+	if expect == nil {
+		return nil
+	}
+
+	err := checkReturnCode(expect.ReturnCode, vmOutput.ReturnCode)
+	if err != nil {
+		return err
+	}
+	if !isUnchecked(expect.ReturnMessage) && expect.ReturnMessage != vmOutput.ReturnMessage {
+		return fmt.Errorf("%w: return message, expected \"%s\", got \"%s\"", ErrCheckFailed, expect.ReturnMessage, vmOutput.ReturnMessage)
+	}
+
+	err = checkUint64("gas remaining", expect.GasRemaining, vmOutput.GasRemaining)
+	if err != nil {
+		return err
+	}
+
+	err = checkBytesList("return data", expect.ReturnData, vmOutput.ReturnData)
+	if err != nil {
+		return err
+	}
+
+	err = checkLogs(expect.Logs, vmOutput.Logs)
+	if err != nil {
+		return err
+	}
+
+	return checkOutputAccounts(expect.OutputAccounts, vmOutput.OutputAccounts)
+}
+
+// checkReturnCode accepts both the numeric value and the textual representation of the return code
+func checkReturnCode(expected string, actual vmcommon.ReturnCode) error {
+	if isUnchecked(expected) {
+		return nil
+	}
+
+	expectedReturnCode, err := vmcommon.ParseReturnCode(expected)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidValueFormat, err.Error())
+	}
+	if expectedReturnCode != actual {
+		return fmt.Errorf("%w: return code, expected %s, got %s", ErrCheckFailed, expected, actual.String())
+	}
+
+	return nil
+}
+
+func checkLogs(expected []*LogCheck, actual []*vmcommon.LogEntry) error {
+	if expected == nil {
+		return nil
+	}
+	if len(expected) != len(actual) {
+		return fmt.Errorf("%w: expected %d logs, got %d", ErrCheckFailed, len(expected), len(actual))
+	}
+
+	for i, expectedLog := range expected {
+		if expectedLog == nil {
+			continue
+		}
+
+		field := fmt.Sprintf("logs[%d]", i)
+		err := checkBytes(field+".identifier", expectedLog.Identifier, actual[i].Identifier)
+		if err != nil {
+			return err
+		}
+		err = checkBytes(field+".address", expectedLog.Address, actual[i].Address)
+		if err != nil {
+			return err
+		}
+		err = checkBytesList(field+".topics", expectedLog.Topics, actual[i].Topics)
+		if err != nil {
+			return err
+		}
+		err = checkBytesList(field+".data", expectedLog.Data, actual[i].Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkOutputAccounts(expected map[string]*OutputAccountCheck, actual map[string]*vmcommon.OutputAccount) error {
+	for _, addressValue := range sortedKeys(expected) {
+		address, err := InterpretValue(addressValue)
+		if err != nil {
+			return err
+		}
+
+		outputAccount, found := actual[string(address)]
+		if !found {
+			return fmt.Errorf("%w: output account %s not found", ErrCheckFailed, addressValue)
+		}
+
+		err = checkOutputAccount(addressValue, expected[addressValue], outputAccount)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkOutputAccount(addressValue string, expected *OutputAccountCheck, actual *vmcommon.OutputAccount) error {
+	if expected == nil {
+		return nil
+	}
+
+	field := fmt.Sprintf("output account %s", addressValue)
+	err := checkBigInt(field+" balance delta", expected.BalanceDelta, actual.BalanceDelta)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(expected.Storage) {
+		keyBytes, errInterpret := InterpretValue(key)
+		if errInterpret != nil {
+			return errInterpret
+		}
+
+		var actualValue []byte
+		storageUpdate, found := actual.StorageUpdates[string(keyBytes)]
+		if found {
+			actualValue = storageUpdate.Data
+		}
+
+		err = checkBytes(fmt.Sprintf("%s storage %s", field, key), expected.Storage[key], actualValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	if expected.Transfers == nil {
+		return nil
+	}
+	if len(expected.Transfers) != len(actual.OutputTransfers) {
+		return fmt.Errorf("%w: %s, expected %d transfers, got %d", ErrCheckFailed, field, len(expected.Transfers), len(actual.OutputTransfers))
+	}
+
+	for i, expectedTransfer := range expected.Transfers {
+		err = checkOutputTransfer(fmt.Sprintf("%s transfers[%d]", field, i), expectedTransfer, actual.OutputTransfers[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkOutputTransfer(field string, expected *TransferCheck, actual vmcommon.OutputTransfer) error {
+	if expected == nil {
+		return nil
+	}
+
+	err := checkBigInt(field+".value", expected.Value, actual.Value)
+	if err != nil {
+		return err
+	}
+	err = checkUint64(field+".gasLimit", expected.GasLimit, actual.GasLimit)
+	if err != nil {
+		return err
+	}
+	err = checkUint64(field+".gasLocked", expected.GasLocked, actual.GasLocked)
+	if err != nil {
+		return err
+	}
+	err = checkBytes(field+".data", expected.Data, actual.Data)
+	if err != nil {
+		return err
+	}
+	err = checkUint64(field+".callType", expected.CallType, uint64(actual.CallType))
+	if err != nil {
+		return err
+	}
+
+	return checkBytes(field+".sender", expected.Sender, actual.SenderAddress)
+}
+
+func (env *environment) checkAccounts(checks map[string]*AccountCheck) error {
+	for _, addressValue := range sortedKeys(checks) {
+		address, err := InterpretValue(addressValue)
+		if err != nil {
+			return err
+		}
+
+		err = env.checkAccount(addressValue, address, checks[addressValue])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (env *environment) checkAccount(addressValue string, address []byte, expected *AccountCheck) error {
+	if expected == nil {
+		return nil
+	}
+
+	account, err := env.loadAccount(address)
+	if err != nil {
+		return err
+	}
+
+	field := fmt.Sprintf("account %s", addressValue)
+	err = checkUint64(field+" nonce", expected.Nonce, account.GetNonce())
+	if err != nil {
+		return err
+	}
+	err = checkBigInt(field+" balance", expected.Balance, account.GetBalance())
+	if err != nil {
+		return err
+	}
+	err = checkBytes(field+" owner", expected.Owner, account.GetOwnerAddress())
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(expected.Storage) {
+		keyBytes, errInterpret := InterpretValue(key)
+		if errInterpret != nil {
+			return errInterpret
+		}
+
+		value, _, errRetrieve := account.AccountDataHandler().RetrieveValue(keyBytes)
+		if errRetrieve != nil {
+			return errRetrieve
+		}
+
+		err = checkBytes(fmt.Sprintf("%s storage %s", field, key), expected.Storage[key], value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tokenIDValue := range sortedKeys(expected.DCT) {
+		err = env.checkDCT(field, account, tokenIDValue, expected.DCT[tokenIDValue])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (env *environment) checkDCT(accountField string, account vmcommon.UserAccountHandler, tokenIDValue string, expected *DCTCheck) error {
+	if expected == nil {
+		return nil
+	}
+
+	tokenID, err := InterpretValue(tokenIDValue)
+	if err != nil {
+		return err
+	}
+
+	field := fmt.Sprintf("%s dct %s", accountField, tokenIDValue)
+	fungible, err := env.blockchainHook.GetDCTToken(account.AddressBytes(), tokenID, 0)
+	if err != nil {
+		return err
+	}
+	err = checkBigInt(field+" balance", expected.Balance, fungible.Value)
+	if err != nil {
+		return err
+	}
+	if !isUnchecked(expected.Frozen) {
+		isFrozen := builtInFunctions.DCTUserMetadataFromBytes(fungible.Properties).Frozen
+		if strconv.FormatBool(isFrozen) != expected.Frozen {
+			return fmt.Errorf("%w: %s frozen, expected %s, got %v", ErrCheckFailed, field, expected.Frozen, isFrozen)
+		}
+	}
+
+	err = env.checkRoles(field, account, tokenID, expected.Roles)
+	if err != nil {
+		return err
+	}
+
+	lastNonce, _, err := account.AccountDataHandler().RetrieveValue([]byte(dctNonceKeyPrefix + string(tokenID)))
+	if err != nil {
+		return err
+	}
+	err = checkBigInt(field+" last nonce", expected.LastNonce, bigIntFromBytes(lastNonce))
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range expected.Instances {
+		err = env.checkDCTInstance(field, account, tokenID, instance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (env *environment) checkRoles(field string, account vmcommon.UserAccountHandler, tokenID []byte, expected []string) error {
+	if expected == nil {
+		return nil
+	}
+
+	roles := &dct.DCTRoles{}
+	marshalledRoles, _, err := account.AccountDataHandler().RetrieveValue([]byte(dctRoleKeyPrefix + string(tokenID)))
+	if err != nil {
+		return err
+	}
+	if len(marshalledRoles) > 0 {
+		err = env.marshaller.Unmarshal(roles, marshalledRoles)
+		if err != nil {
+			return err
+		}
+	}
+
+	expectedRoles := make([]string, 0, len(expected))
+	for _, role := range expected {
+		expectedRoles = append(expectedRoles, "str:"+role)
+	}
+
+	return checkBytesList(field+" roles", expectedRoles, roles.Roles)
+}
+
+func (env *environment) checkDCTInstance(field string, account vmcommon.UserAccountHandler, tokenID []byte, expected *DCTInstance) error {
+	if expected == nil {
+		return nil
+	}
+
+	nonce, err := InterpretUint64(expected.Nonce)
+	if err != nil {
+		return err
+	}
+
+	instanceField := fmt.Sprintf("%s nonce %d", field, nonce)
+	dctData, err := env.blockchainHook.GetDCTToken(account.AddressBytes(), tokenID, nonce)
+	if err != nil {
+		return err
+	}
+	err = checkBigInt(instanceField+" balance", expected.Balance, dctData.Value)
+	if err != nil {
+		return err
+	}
+
+	metadata := dctData.TokenMetaData
+	if metadata == nil {
+		metadata = &dct.MetaData{}
+	}
+
+	err = checkUint64(instanceField+" royalties", expected.Royalties, uint64(metadata.Royalties))
+	if err != nil {
+		return err
+	}
+
+	fields := []struct {
+		name     string
+		expected string
+		actual   []byte
+	}{
+		{"name", expected.Name, metadata.Name},
+		{"creator", expected.Creator, metadata.Creator},
+		{"hash", expected.Hash, metadata.Hash},
+		{"attributes", expected.Attributes, metadata.Attributes},
+	}
+	for _, f := range fields {
+		err = checkBytes(instanceField+" "+f.name, f.expected, f.actual)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkBytesList(instanceField+" uris", expected.URIs, metadata.URIs)
+}
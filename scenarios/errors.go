@@ -0,0 +1,30 @@
+package scenarios
+
+import "errors"
+
+// ErrNilMarshalizer signals that a nil marshalizer was provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
+var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrNilGuardedAccountHandler signals that a nil guarded account handler was provided
+var ErrNilGuardedAccountHandler = errors.New("nil guarded account handler")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator was provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilGasMap signals that a nil gas map was provided
+var ErrNilGasMap = errors.New("nil gas map")
+
+// ErrNilScenario signals that a nil scenario was provided
+var ErrNilScenario = errors.New("nil scenario")
+
+// ErrInvalidValueFormat signals that a scenario value could not be interpreted
+var ErrInvalidValueFormat = errors.New("invalid value format")
+
+// ErrInvalidStep signals that a scenario step is malformed
+var ErrInvalidStep = errors.New("invalid step")
+
+// ErrCheckFailed signals that the result of a step does not match the expected result
+var ErrCheckFailed = errors.New("check failed")
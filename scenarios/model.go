@@ -0,0 +1,130 @@
+package scenarios
+
+// All the values in a scenario are strings interpreted by the rules described in values.go.
+// In the expectation and check sections, an empty string or "*" means that the field is not checked.
+
+// Scenario describes the initial state, the steps to be executed and the expected results
+type Scenario struct {
+	Name           string                       `json:"name"`
+	Comment        string                       `json:"comment,omitempty"`
+	GasSchedule    map[string]map[string]uint64 `json:"gasSchedule,omitempty"`
+	GlobalSettings map[string]*GlobalSettings   `json:"globalSettings,omitempty"`
+	Accounts       map[string]*AccountState     `json:"accounts"`
+	Steps          []*Step                      `json:"steps"`
+}
+
+// GlobalSettings defines the token settings saved on the system account
+type GlobalSettings struct {
+	Paused          bool `json:"paused,omitempty"`
+	LimitedTransfer bool `json:"limitedTransfer,omitempty"`
+	BurnRoleForAll  bool `json:"burnRoleForAll,omitempty"`
+}
+
+// AccountState defines the initial state of an account
+type AccountState struct {
+	Nonce        string               `json:"nonce,omitempty"`
+	Balance      string               `json:"balance,omitempty"`
+	Owner        string               `json:"owner,omitempty"`
+	Username     string               `json:"username,omitempty"`
+	CodeMetadata string               `json:"codeMetadata,omitempty"`
+	DCT          map[string]*DCTState `json:"dct,omitempty"`
+	Storage      map[string]string    `json:"storage,omitempty"`
+}
+
+// DCTState defines the state of a token held by an account
+type DCTState struct {
+	Balance   string         `json:"balance,omitempty"`
+	Frozen    bool           `json:"frozen,omitempty"`
+	Roles     []string       `json:"roles,omitempty"`
+	LastNonce string         `json:"lastNonce,omitempty"`
+	Instances []*DCTInstance `json:"instances,omitempty"`
+}
+
+// DCTInstance defines a NFT/SFT instance together with its metadata
+type DCTInstance struct {
+	Nonce      string   `json:"nonce"`
+	Balance    string   `json:"balance,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Creator    string   `json:"creator,omitempty"`
+	Royalties  string   `json:"royalties,omitempty"`
+	Hash       string   `json:"hash,omitempty"`
+	Attributes string   `json:"attributes,omitempty"`
+	URIs       []string `json:"uris,omitempty"`
+}
+
+// Step is a built-in function call together with its expected results
+type Step struct {
+	ID            string                   `json:"id"`
+	Comment       string                   `json:"comment,omitempty"`
+	Tx            *TxInput                 `json:"tx"`
+	Expect        *Expectation             `json:"expect,omitempty"`
+	CheckAccounts map[string]*AccountCheck `json:"checkAccounts,omitempty"`
+}
+
+// TxInput defines the fields used to build the vmcommon.ContractCallInput of a step
+type TxInput struct {
+	From                 string   `json:"from"`
+	To                   string   `json:"to"`
+	Function             string   `json:"function"`
+	Arguments            []string `json:"arguments,omitempty"`
+	Value                string   `json:"value,omitempty"`
+	GasLimit             string   `json:"gasLimit,omitempty"`
+	GasLocked            string   `json:"gasLocked,omitempty"`
+	CallType             string   `json:"callType,omitempty"`
+	ReturnCallAfterError bool     `json:"returnCallAfterError,omitempty"`
+}
+
+// Expectation defines the expected result of a step. When Error is set, the call must fail with an error
+// containing the given text and the other fields are ignored.
+type Expectation struct {
+	Error          string                         `json:"error,omitempty"`
+	ReturnCode     string                         `json:"returnCode,omitempty"`
+	ReturnMessage  string                         `json:"returnMessage,omitempty"`
+	GasRemaining   string                         `json:"gasRemaining,omitempty"`
+	ReturnData     []string                       `json:"returnData,omitempty"`
+	Logs           []*LogCheck                    `json:"logs,omitempty"`
+	OutputAccounts map[string]*OutputAccountCheck `json:"outputAccounts,omitempty"`
+}
+
+// LogCheck defines an expected log entry
+type LogCheck struct {
+	Identifier string   `json:"identifier,omitempty"`
+	Address    string   `json:"address,omitempty"`
+	Topics     []string `json:"topics,omitempty"`
+	Data       []string `json:"data,omitempty"`
+}
+
+// OutputAccountCheck defines an expected output account
+type OutputAccountCheck struct {
+	BalanceDelta string            `json:"balanceDelta,omitempty"`
+	Storage      map[string]string `json:"storage,omitempty"`
+	Transfers    []*TransferCheck  `json:"transfers,omitempty"`
+}
+
+// TransferCheck defines an expected output transfer
+type TransferCheck struct {
+	Value     string `json:"value,omitempty"`
+	GasLimit  string `json:"gasLimit,omitempty"`
+	GasLocked string `json:"gasLocked,omitempty"`
+	Data      string `json:"data,omitempty"`
+	CallType  string `json:"callType,omitempty"`
+	Sender    string `json:"sender,omitempty"`
+}
+
+// AccountCheck defines the expected state of an account after a step
+type AccountCheck struct {
+	Nonce   string               `json:"nonce,omitempty"`
+	Balance string               `json:"balance,omitempty"`
+	Owner   string               `json:"owner,omitempty"`
+	DCT     map[string]*DCTCheck `json:"dct,omitempty"`
+	Storage map[string]string    `json:"storage,omitempty"`
+}
+
+// DCTCheck defines the expected state of a token held by an account
+type DCTCheck struct {
+	Balance   string         `json:"balance,omitempty"`
+	Frozen    string         `json:"frozen,omitempty"`
+	Roles     []string       `json:"roles,omitempty"`
+	LastNonce string         `json:"lastNonce,omitempty"`
+	Instances []*DCTInstance `json:"instances,omitempty"`
+}
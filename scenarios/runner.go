@@ -0,0 +1,552 @@
+package scenarios
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-core-16/core/check"
+	"github.com/subrahamanyam341/andes-core-16/data/dct"
+	"github.com/subrahamanyam341/andes-core-16/data/vm"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+	"github.com/subrahamanyam341/andes-vm-common-1234/builtInFunctions"
+	"github.com/subrahamanyam341/andes-vm-common-1234/inMemoryState"
+)
+
+const (
+	dctKeyPrefix      = core.ProtectedKeyPrefix + core.DCTKeyIdentifier
+	dctRoleKeyPrefix  = core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier
+	dctNonceKeyPrefix = core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier
+)
+
+// ArgsRunner defines the arguments needed to create a scenario runner. The gas map is used for the scenarios
+// that do not define their own gas schedule.
+type ArgsRunner struct {
+	GasMap                           map[string]map[string]uint64
+	Marshalizer                      vmcommon.Marshalizer
+	ShardCoordinator                 vmcommon.Coordinator
+	EnableEpochsHandler              vmcommon.EnableEpochsHandler
+	GuardedAccountHandler            vmcommon.GuardedAccountHandler
+	MaxNumOfAddressesForTransferRole uint32
+}
+
+type runner struct {
+	args ArgsRunner
+}
+
+// environment holds the components created for a single scenario run
+type environment struct {
+	accounts          *inMemoryState.AccountsAdapter
+	blockchainHook    *inMemoryState.BlockchainHook
+	nftStorageHandler vmcommon.DCTNFTStorageHandler
+	marshaller        vmcommon.Marshalizer
+}
+
+// NewRunner creates a scenario runner that executes the built-in functions against an in-memory state
+func NewRunner(args ArgsRunner) (*runner, error) {
+	if args.GasMap == nil {
+		return nil, ErrNilGasMap
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+	if check.IfNil(args.GuardedAccountHandler) {
+		return nil, ErrNilGuardedAccountHandler
+	}
+
+	return &runner{
+		args: args,
+	}, nil
+}
+
+// LoadScenario reads and decodes a JSON scenario file
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	scenario := &Scenario{}
+	err = json.Unmarshal(data, scenario)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding scenario %s", err, path)
+	}
+
+	return scenario, nil
+}
+
+// RunFile loads and runs the scenario from the given file
+func (r *runner) RunFile(path string) error {
+	scenario, err := LoadScenario(path)
+	if err != nil {
+		return err
+	}
+
+	err = r.RunScenario(scenario)
+	if err != nil {
+		return fmt.Errorf("%w, file %s", err, path)
+	}
+
+	return nil
+}
+
+// RunScenario sets up the initial state, executes all the steps in order and checks their results.
+// It stops at the first step that does not match its expectations.
+func (r *runner) RunScenario(scenario *Scenario) error {
+	if scenario == nil {
+		return ErrNilScenario
+	}
+
+	gasMap := r.args.GasMap
+	if scenario.GasSchedule != nil {
+		gasMap = scenario.GasSchedule
+	}
+
+	env, err := r.createEnvironment(gasMap)
+	if err != nil {
+		return err
+	}
+
+	err = env.setGlobalSettings(scenario.GlobalSettings)
+	if err != nil {
+		return err
+	}
+
+	err = env.setAccounts(scenario.Accounts)
+	if err != nil {
+		return err
+	}
+
+	for stepIndex, step := range scenario.Steps {
+		err = env.runStep(step)
+		if err != nil {
+			return fmt.Errorf("scenario %s, step %d (%s): %w", scenario.Name, stepIndex, stepID(step), err)
+		}
+	}
+
+	return nil
+}
+
+func stepID(step *Step) string {
+	if step == nil {
+		return ""
+	}
+
+	return step.ID
+}
+
+func (r *runner) createEnvironment(gasMap map[string]map[string]uint64) (*environment, error) {
+	accounts := inMemoryState.NewAccountsAdapter()
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           gasMap,
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      r.args.Marshalizer,
+		Accounts:                         accounts,
+		ShardCoordinator:                 r.args.ShardCoordinator,
+		EnableEpochsHandler:              r.args.EnableEpochsHandler,
+		GuardedAccountHandler:            r.args.GuardedAccountHandler,
+		MaxNumOfAddressesForTransferRole: r.args.MaxNumOfAddressesForTransferRole,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = creator.CreateBuiltInFunctionContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	nftStorageHandler, ok := creator.NFTStorageHandler().(vmcommon.DCTNFTStorageHandler)
+	if !ok {
+		return nil, fmt.Errorf("%w for the NFT storage handler", builtInFunctions.ErrWrongTypeAssertion)
+	}
+
+	blockchainHook, err := inMemoryState.NewBlockchainHook(inMemoryState.ArgsBlockchainHook{
+		Accounts:              accounts,
+		BuiltInFunctions:      creator.BuiltInFunctionContainer(),
+		ShardCoordinator:      r.args.ShardCoordinator,
+		NFTStorageHandler:     creator.NFTStorageHandler(),
+		GlobalSettingsHandler: creator.DCTGlobalSettingsHandler(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = creator.SetPayableHandler(blockchainHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return &environment{
+		accounts:          accounts,
+		blockchainHook:    blockchainHook,
+		nftStorageHandler: nftStorageHandler,
+		marshaller:        r.args.Marshalizer,
+	}, nil
+}
+
+func (env *environment) loadAccount(address []byte) (*inMemoryState.UserAccount, error) {
+	account, err := env.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(*inMemoryState.UserAccount)
+	if !ok {
+		return nil, builtInFunctions.ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+func (env *environment) setGlobalSettings(globalSettings map[string]*GlobalSettings) error {
+	if len(globalSettings) == 0 {
+		return nil
+	}
+
+	systemAccount, err := env.loadAccount(vmcommon.SystemAccountAddress)
+	if err != nil {
+		return err
+	}
+
+	for _, tokenID := range sortedKeys(globalSettings) {
+		tokenIDBytes, errInterpret := InterpretValue(tokenID)
+		if errInterpret != nil {
+			return errInterpret
+		}
+
+		settings := globalSettings[tokenID]
+		if settings == nil {
+			continue
+		}
+
+		metadata := builtInFunctions.DCTGlobalMetadata{
+			Paused:          settings.Paused,
+			LimitedTransfer: settings.LimitedTransfer,
+			BurnRoleForAll:  settings.BurnRoleForAll,
+		}
+		err = systemAccount.AccountDataHandler().SaveKeyValue([]byte(dctKeyPrefix+string(tokenIDBytes)), metadata.ToBytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return env.accounts.SaveAccount(systemAccount)
+}
+
+func (env *environment) setAccounts(accounts map[string]*AccountState) error {
+	for _, addressValue := range sortedKeys(accounts) {
+		address, err := InterpretValue(addressValue)
+		if err != nil {
+			return err
+		}
+
+		err = env.setAccount(address, accounts[addressValue])
+		if err != nil {
+			return fmt.Errorf("%w while setting account %s", err, addressValue)
+		}
+	}
+
+	return nil
+}
+
+func (env *environment) setAccount(address []byte, state *AccountState) error {
+	account, err := env.loadAccount(address)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return env.accounts.SaveAccount(account)
+	}
+
+	nonce, err := InterpretUint64(state.Nonce)
+	if err != nil {
+		return err
+	}
+	account.IncreaseNonce(nonce)
+
+	balance, err := InterpretBigInt(state.Balance)
+	if err != nil {
+		return err
+	}
+	err = account.AddToBalance(balance)
+	if err != nil {
+		return err
+	}
+
+	fields := []struct {
+		value  string
+		setter func([]byte)
+	}{
+		{state.Owner, account.SetOwnerAddress},
+		{state.Username, account.SetUserName},
+		{state.CodeMetadata, account.SetCodeMetadata},
+	}
+	for _, field := range fields {
+		value, errInterpret := InterpretValue(field.value)
+		if errInterpret != nil {
+			return errInterpret
+		}
+		field.setter(value)
+	}
+
+	for _, key := range sortedKeys(state.Storage) {
+		err = saveInterpretedKeyValue(account, key, state.Storage[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tokenID := range sortedKeys(state.DCT) {
+		err = env.setDCT(account, tokenID, state.DCT[tokenID])
+		if err != nil {
+			return err
+		}
+	}
+
+	return env.accounts.SaveAccount(account)
+}
+
+func saveInterpretedKeyValue(account vmcommon.UserAccountHandler, key string, value string) error {
+	keyBytes, err := InterpretValue(key)
+	if err != nil {
+		return err
+	}
+	valueBytes, err := InterpretValue(value)
+	if err != nil {
+		return err
+	}
+
+	return account.AccountDataHandler().SaveKeyValue(keyBytes, valueBytes)
+}
+
+func (env *environment) setDCT(account *inMemoryState.UserAccount, tokenIDValue string, state *DCTState) error {
+	if state == nil {
+		return nil
+	}
+
+	tokenID, err := InterpretValue(tokenIDValue)
+	if err != nil {
+		return err
+	}
+	dctTokenKey := []byte(dctKeyPrefix + string(tokenID))
+
+	if len(state.Balance) > 0 || state.Frozen {
+		err = env.setFungibleDCT(account, dctTokenKey, state)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(state.Roles) > 0 {
+		roles := &dct.DCTRoles{Roles: make([][]byte, 0, len(state.Roles))}
+		for _, role := range state.Roles {
+			roles.Roles = append(roles.Roles, []byte(role))
+		}
+
+		err = env.saveMarshalled(account, []byte(dctRoleKeyPrefix+string(tokenID)), roles)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(state.LastNonce) > 0 {
+		err = saveInterpretedKeyValue(account, "str:"+dctNonceKeyPrefix+string(tokenID), state.LastNonce)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, instance := range state.Instances {
+		err = env.setDCTInstance(account, dctTokenKey, instance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (env *environment) setFungibleDCT(account *inMemoryState.UserAccount, dctTokenKey []byte, state *DCTState) error {
+	balance, err := InterpretBigInt(state.Balance)
+	if err != nil {
+		return err
+	}
+
+	dctData := &dct.DCToken{
+		Type:  uint32(core.Fungible),
+		Value: balance,
+	}
+	if state.Frozen {
+		userMetadata := builtInFunctions.DCTUserMetadata{Frozen: true}
+		dctData.Properties = userMetadata.ToBytes()
+	}
+
+	return env.saveMarshalled(account, dctTokenKey, dctData)
+}
+
+func (env *environment) setDCTInstance(account *inMemoryState.UserAccount, dctTokenKey []byte, instance *DCTInstance) error {
+	if instance == nil {
+		return nil
+	}
+
+	nonce, err := InterpretUint64(instance.Nonce)
+	if err != nil {
+		return err
+	}
+	balance, err := InterpretBigInt(instance.Balance)
+	if err != nil {
+		return err
+	}
+	metadata, err := createMetaData(nonce, instance)
+	if err != nil {
+		return err
+	}
+
+	dctData := &dct.DCToken{
+		Type:          uint32(core.NonFungible),
+		Value:         balance,
+		TokenMetaData: metadata,
+	}
+	_, err = env.nftStorageHandler.SaveDCTNFTToken(account.AddressBytes(), account, dctTokenKey, nonce, dctData, true, false)
+	if err != nil {
+		return err
+	}
+
+	return env.nftStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nonce, balance)
+}
+
+func createMetaData(nonce uint64, instance *DCTInstance) (*dct.MetaData, error) {
+	royalties, err := InterpretUint64(instance.Royalties)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &dct.MetaData{
+		Nonce:     nonce,
+		Royalties: uint32(royalties),
+	}
+
+	fields := []struct {
+		value  string
+		target *[]byte
+	}{
+		{instance.Name, &metadata.Name},
+		{instance.Creator, &metadata.Creator},
+		{instance.Hash, &metadata.Hash},
+		{instance.Attributes, &metadata.Attributes},
+	}
+	for _, field := range fields {
+		*field.target, err = InterpretValue(field.value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, uri := range instance.URIs {
+		uriBytes, errInterpret := InterpretValue(uri)
+		if errInterpret != nil {
+			return nil, errInterpret
+		}
+		metadata.URIs = append(metadata.URIs, uriBytes)
+	}
+
+	return metadata, nil
+}
+
+func (env *environment) saveMarshalled(account vmcommon.UserAccountHandler, key []byte, value interface{}) error {
+	buff, err := env.marshaller.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return account.AccountDataHandler().SaveKeyValue(key, buff)
+}
+
+func (env *environment) runStep(step *Step) error {
+	if step == nil || step.Tx == nil {
+		return fmt.Errorf("%w: missing tx", ErrInvalidStep)
+	}
+
+	input, err := createContractCallInput(step.Tx)
+	if err != nil {
+		return err
+	}
+
+	vmOutput, err := env.blockchainHook.ProcessBuiltInFunction(input)
+	err = checkExpectation(step.Expect, vmOutput, err)
+	if err != nil {
+		return err
+	}
+
+	return env.checkAccounts(step.CheckAccounts)
+}
+
+func createContractCallInput(tx *TxInput) (*vmcommon.ContractCallInput, error) {
+	from, err := InterpretValue(tx.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := InterpretValue(tx.To)
+	if err != nil {
+		return nil, err
+	}
+	value, err := InterpretBigInt(tx.Value)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := InterpretUint64(tx.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+	gasLocked, err := InterpretUint64(tx.GasLocked)
+	if err != nil {
+		return nil, err
+	}
+	callType, err := InterpretUint64(tx.CallType)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := make([][]byte, 0, len(tx.Arguments))
+	for _, argument := range tx.Arguments {
+		argumentBytes, errInterpret := InterpretValue(argument)
+		if errInterpret != nil {
+			return nil, errInterpret
+		}
+		arguments = append(arguments, argumentBytes)
+	}
+
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:           from,
+			Arguments:            arguments,
+			CallValue:            value,
+			CallType:             vm.CallType(callType),
+			GasProvided:          gasLimit,
+			GasLocked:            gasLocked,
+			ReturnCallAfterError: tx.ReturnCallAfterError,
+		},
+		RecipientAddr: to,
+		Function:      tx.Function,
+	}, nil
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
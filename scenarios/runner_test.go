@@ -0,0 +1,148 @@
+package scenarios
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subrahamanyam341/andes-core-16/core"
+	"github.com/subrahamanyam341/andes-vm-common-1234/mock"
+)
+
+func createGasMap(value uint64) map[string]map[string]uint64 {
+	baseOperationCost := make(map[string]uint64)
+	for _, key := range []string{"StorePerByte", "ReleasePerByte", "DataCopyPerByte", "PersistPerByte", "CompilePerByte", "AoTPreparePerByte"} {
+		baseOperationCost[key] = value
+	}
+
+	builtInCost := make(map[string]uint64)
+	for _, key := range []string{"ChangeOwnerAddress", "ClaimDeveloperRewards", "SaveUserName", "SaveKeyValue", "DCTTransfer",
+		"DCTBurn", "DCTLocalMint", "DCTLocalBurn", "DCTNFTCreate", "DCTNFTAddQuantity", "DCTNFTBurn", "DCTNFTTransfer",
+		"DCTNFTChangeCreateOwner", "DCTNFTMultiTransfer", "DCTNFTAddURI", "DCTNFTUpdateAttributes", "SetGuardian",
+		"GuardAccount", "TrieLoadPerNode", "TrieStorePerNode"} {
+		builtInCost[key] = value
+	}
+
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: baseOperationCost,
+		core.BuiltInCostString:       builtInCost,
+	}
+}
+
+func createMockArgsRunner() ArgsRunner {
+	return ArgsRunner{
+		GasMap:           createGasMap(1),
+		Marshalizer:      &mock.MarshalizerMock{},
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsSaveToSystemAccountFlagEnabledField:                true,
+			IsCheckFrozenCollectionFlagEnabledField:              true,
+			IsValueLengthCheckFlagEnabledField:                   true,
+			IsCheckTransferFlagEnabledField:                      true,
+			IsTransferToMetaFlagEnabledField:                     true,
+			IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+		},
+		GuardedAccountHandler:            &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	}
+}
+
+func TestNewRunner(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsRunner()
+	args.GasMap = nil
+	_, err := NewRunner(args)
+	assert.Equal(t, ErrNilGasMap, err)
+
+	args = createMockArgsRunner()
+	args.Marshalizer = nil
+	_, err = NewRunner(args)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	args = createMockArgsRunner()
+	args.ShardCoordinator = nil
+	_, err = NewRunner(args)
+	assert.Equal(t, ErrNilShardCoordinator, err)
+
+	args = createMockArgsRunner()
+	args.EnableEpochsHandler = nil
+	_, err = NewRunner(args)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	args = createMockArgsRunner()
+	args.GuardedAccountHandler = nil
+	_, err = NewRunner(args)
+	assert.Equal(t, ErrNilGuardedAccountHandler, err)
+
+	r, err := NewRunner(createMockArgsRunner())
+	assert.Nil(t, err)
+	assert.NotNil(t, r)
+}
+
+func TestRunner_RunTestdataScenarios(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.scen.json"))
+	require.Nil(t, err)
+	require.NotEmpty(t, files)
+
+	r, _ := NewRunner(createMockArgsRunner())
+	for _, file := range files {
+		assert.Nil(t, r.RunFile(file), file)
+	}
+}
+
+func TestRunner_RunScenarioReportsMismatches(t *testing.T) {
+	t.Parallel()
+
+	r, _ := NewRunner(createMockArgsRunner())
+	assert.Equal(t, ErrNilScenario, r.RunScenario(nil))
+
+	scenario := &Scenario{
+		Name: "mismatch",
+		Accounts: map[string]*AccountState{
+			"address:alice": {DCT: map[string]*DCTState{"str:TKN-abcdef": {Balance: "10"}}},
+		},
+		Steps: []*Step{
+			{
+				ID: "wrong-gas",
+				Tx: &TxInput{
+					From:      "address:alice",
+					To:        "address:bob",
+					Function:  core.BuiltInFunctionDCTTransfer,
+					Arguments: []string{"str:TKN-abcdef", "1"},
+					GasLimit:  "10",
+				},
+				Expect: &Expectation{GasRemaining: "10"},
+			},
+		},
+	}
+	err := r.RunScenario(scenario)
+	assert.True(t, errors.Is(err, ErrCheckFailed))
+	assert.Contains(t, err.Error(), "wrong-gas")
+
+	scenario.Steps[0].Expect = &Expectation{Error: "some error"}
+	err = r.RunScenario(scenario)
+	assert.True(t, errors.Is(err, ErrCheckFailed))
+
+	scenario.Steps[0].Expect = nil
+	scenario.Steps[0].CheckAccounts = map[string]*AccountCheck{
+		"address:bob": {DCT: map[string]*DCTCheck{"str:TKN-abcdef": {Balance: "2"}}},
+	}
+	err = r.RunScenario(scenario)
+	assert.True(t, errors.Is(err, ErrCheckFailed))
+
+	scenario.Steps[0].Tx = nil
+	err = r.RunScenario(scenario)
+	assert.True(t, errors.Is(err, ErrInvalidStep))
+}
+
+func TestRunner_RunFileMissingFile(t *testing.T) {
+	t.Parallel()
+
+	r, _ := NewRunner(createMockArgsRunner())
+	assert.NotNil(t, r.RunFile(filepath.Join("testdata", "missing.scen.json")))
+}
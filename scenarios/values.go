@@ -0,0 +1,167 @@
+package scenarios
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+const (
+	strPrefix        = "str:"
+	addressPrefix    = "address:"
+	scAddressPrefix  = "sc:"
+	hexPrefix        = "0x"
+	systemAccountKey = "system"
+	addressLength    = 32
+	anyValue         = "*"
+	addressPadding   = '_'
+)
+
+var scAddressVMType = []byte{5, 0}
+
+// InterpretValue converts a scenario value to bytes. The accepted formats are:
+//   - "" - empty value
+//   - "str:<text>" - the bytes of the text
+//   - "address:<name>" - a 32 bytes user address, the name right padded with '_'
+//   - "sc:<name>" - a 32 bytes smart contract address, the name right padded with '_'
+//   - "system" - the system account address
+//   - "0x<hex>" - the decoded hex bytes
+//   - "<decimal>" - the big endian bytes of the unsigned number, "0" being the empty value
+func InterpretValue(value string) ([]byte, error) {
+	switch {
+	case len(value) == 0:
+		return make([]byte, 0), nil
+	case strings.HasPrefix(value, strPrefix):
+		return []byte(value[len(strPrefix):]), nil
+	case strings.HasPrefix(value, addressPrefix):
+		return createAddress(nil, value[len(addressPrefix):])
+	case strings.HasPrefix(value, scAddressPrefix):
+		prefix := append(make([]byte, vmcommon.NumInitCharactersForScAddress-vmcommon.VMTypeLen), scAddressVMType...)
+		return createAddress(prefix, value[len(scAddressPrefix):])
+	case value == systemAccountKey:
+		return vmcommon.SystemAccountAddress, nil
+	case strings.HasPrefix(value, hexPrefix):
+		decoded, err := hex.DecodeString(value[len(hexPrefix):])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s, %s", ErrInvalidValueFormat, value, err.Error())
+		}
+		return decoded, nil
+	}
+
+	number, ok := big.NewInt(0).SetString(value, 10)
+	if !ok || number.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidValueFormat, value)
+	}
+
+	return number.Bytes(), nil
+}
+
+func createAddress(prefix []byte, name string) ([]byte, error) {
+	if len(prefix)+len(name) > addressLength {
+		return nil, fmt.Errorf("%w: name too long for address %s", ErrInvalidValueFormat, name)
+	}
+
+	address := append(append(make([]byte, 0, addressLength), prefix...), name...)
+	padding := bytes.Repeat([]byte{addressPadding}, addressLength-len(address))
+
+	return append(address, padding...), nil
+}
+
+// InterpretBigInt converts a scenario value to a big integer. Decimal values can be negative.
+func InterpretBigInt(value string) (*big.Int, error) {
+	if strings.HasPrefix(value, "-") {
+		number, ok := big.NewInt(0).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidValueFormat, value)
+		}
+		return number, nil
+	}
+
+	buff, err := InterpretValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetBytes(buff), nil
+}
+
+// InterpretUint64 converts a scenario value to an uint64
+func InterpretUint64(value string) (uint64, error) {
+	number, err := InterpretBigInt(value)
+	if err != nil {
+		return 0, err
+	}
+	if !number.IsUint64() {
+		return 0, fmt.Errorf("%w: %s does not fit in uint64", ErrInvalidValueFormat, value)
+	}
+
+	return number.Uint64(), nil
+}
+
+func isUnchecked(expected string) bool {
+	return len(expected) == 0 || expected == anyValue
+}
+
+func checkBytes(field string, expected string, actual []byte) error {
+	if isUnchecked(expected) {
+		return nil
+	}
+
+	expectedBytes, err := InterpretValue(expected)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expectedBytes, actual) {
+		return fmt.Errorf("%w: %s, expected %s (0x%s), got 0x%s", ErrCheckFailed, field, expected, hex.EncodeToString(expectedBytes), hex.EncodeToString(actual))
+	}
+
+	return nil
+}
+
+func checkBigInt(field string, expected string, actual *big.Int) error {
+	if isUnchecked(expected) {
+		return nil
+	}
+
+	expectedValue, err := InterpretBigInt(expected)
+	if err != nil {
+		return err
+	}
+
+	actualValue := vmcommon.ZeroValueIfNil(actual)
+	if expectedValue.Cmp(actualValue) != 0 {
+		return fmt.Errorf("%w: %s, expected %s, got %s", ErrCheckFailed, field, expectedValue.String(), actualValue.String())
+	}
+
+	return nil
+}
+
+func checkUint64(field string, expected string, actual uint64) error {
+	return checkBigInt(field, expected, big.NewInt(0).SetUint64(actual))
+}
+
+func checkBytesList(field string, expected []string, actual [][]byte) error {
+	if expected == nil {
+		return nil
+	}
+	if len(expected) != len(actual) {
+		return fmt.Errorf("%w: %s, expected %d items, got %d", ErrCheckFailed, field, len(expected), len(actual))
+	}
+
+	for i := range expected {
+		err := checkBytes(fmt.Sprintf("%s[%d]", field, i), expected[i], actual[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func bigIntFromBytes(value []byte) *big.Int {
+	return big.NewInt(0).SetBytes(value)
+}
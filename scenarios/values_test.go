@@ -0,0 +1,88 @@
+package scenarios
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vmcommon "github.com/subrahamanyam341/andes-vm-common-1234"
+)
+
+func TestInterpretValue(t *testing.T) {
+	t.Parallel()
+
+	value, err := InterpretValue("")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{}, value)
+
+	value, _ = InterpretValue("str:TKN-abcdef")
+	assert.Equal(t, []byte("TKN-abcdef"), value)
+
+	value, _ = InterpretValue("address:alice")
+	assert.Equal(t, append([]byte("alice"), bytes.Repeat([]byte("_"), 27)...), value)
+
+	value, _ = InterpretValue("sc:contract")
+	assert.Equal(t, 32, len(value))
+	assert.True(t, vmcommon.IsSmartContractAddress(value))
+
+	value, _ = InterpretValue("system")
+	assert.Equal(t, vmcommon.SystemAccountAddress, value)
+
+	value, _ = InterpretValue("0x0a0b")
+	assert.Equal(t, []byte{10, 11}, value)
+
+	value, _ = InterpretValue("256")
+	assert.Equal(t, []byte{1, 0}, value)
+
+	value, _ = InterpretValue("0")
+	assert.Equal(t, 0, len(value))
+
+	_, err = InterpretValue("0xzz")
+	assert.True(t, errors.Is(err, ErrInvalidValueFormat))
+
+	_, err = InterpretValue("unknown")
+	assert.True(t, errors.Is(err, ErrInvalidValueFormat))
+
+	_, err = InterpretValue("address:" + string(bytes.Repeat([]byte("a"), 33)))
+	assert.True(t, errors.Is(err, ErrInvalidValueFormat))
+}
+
+func TestInterpretBigIntAndUint64(t *testing.T) {
+	t.Parallel()
+
+	value, err := InterpretBigInt("-15")
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(-15), value)
+
+	value, _ = InterpretBigInt("0x10")
+	assert.Equal(t, big.NewInt(16), value)
+
+	number, err := InterpretUint64("100")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(100), number)
+
+	_, err = InterpretUint64("-1")
+	assert.True(t, errors.Is(err, ErrInvalidValueFormat))
+
+	_, err = InterpretUint64("18446744073709551616")
+	assert.True(t, errors.Is(err, ErrInvalidValueFormat))
+}
+
+func TestCheckHelpers(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, checkBytes("field", "", []byte("x")))
+	assert.Nil(t, checkBytes("field", "*", []byte("x")))
+	assert.Nil(t, checkBytes("field", "str:x", []byte("x")))
+	assert.True(t, errors.Is(checkBytes("field", "str:y", []byte("x")), ErrCheckFailed))
+
+	assert.Nil(t, checkBigInt("field", "0", nil))
+	assert.True(t, errors.Is(checkBigInt("field", "1", nil), ErrCheckFailed))
+	assert.Nil(t, checkUint64("field", "5", 5))
+
+	assert.Nil(t, checkBytesList("field", nil, [][]byte{[]byte("x")}))
+	assert.Nil(t, checkBytesList("field", []string{"*", "str:y"}, [][]byte{[]byte("x"), []byte("y")}))
+	assert.True(t, errors.Is(checkBytesList("field", []string{"*"}, nil), ErrCheckFailed))
+}
@@ -0,0 +1,70 @@
+package vmcommon
+
+import "math/big"
+
+// ValueChange holds an account field value before and after an execution
+type ValueChange struct {
+	Old []byte
+	New []byte
+}
+
+// StorageChange represents the change of one account storage key
+type StorageChange struct {
+	// Key is the storage key.
+	Key []byte
+
+	// OldValue is the value held before the execution. Nil means that the key did not exist.
+	OldValue []byte
+
+	// NewValue is the value held after the execution. Nil means that the key was deleted.
+	NewValue []byte
+}
+
+// StorageRead represents the read of one account storage key
+type StorageRead struct {
+	// Key is the storage key.
+	Key []byte
+
+	// Value is the value returned by the first read of the key. Nil means that the key did not exist.
+	Value []byte
+}
+
+// AccountDiff holds the storage accesses and the changes an execution produced on one account
+type AccountDiff struct {
+	// Address is the public key of the account.
+	Address []byte
+
+	// NonceDelta is the amount the nonce was increased with.
+	NonceDelta uint64
+
+	// BalanceDelta is the difference between the new and the old balance. It is never nil.
+	BalanceDelta *big.Int
+
+	// DeveloperRewardDelta is the difference between the new and the old developer reward. It is never nil.
+	DeveloperRewardDelta *big.Int
+
+	// OwnerAddress, UserName and CodeMetadata are nil if the field was not changed.
+	OwnerAddress *ValueChange
+	UserName     *ValueChange
+	CodeMetadata *ValueChange
+
+	// StorageReads lists the read storage keys, in the order they were first read.
+	StorageReads []*StorageRead
+
+	// StorageChanges lists the changed storage keys, in the order they were first written.
+	StorageChanges []*StorageChange
+}
+
+// SimulationResult is the outcome of a simulated built-in function call
+type SimulationResult struct {
+	// VMOutput is the output the call would produce. The state diff of a successful call lists the accessed
+	// accounts, sorted by address. A failed call has the return code mapped from the error and the error in the
+	// return message.
+	VMOutput *VMOutput
+}
+
+// BuiltInFunctionProcessor defines a built-in function container that processes the calls of its functions itself,
+// for example to attach the state diff to the output. The host should run the functions through it when available.
+type BuiltInFunctionProcessor interface {
+	ProcessBuiltinFunction(function BuiltinFunction, acntSnd, acntDst UserAccountHandler, vmInput *ContractCallInput) (*VMOutput, error)
+}